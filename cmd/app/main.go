@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/Pedrommb91/go-auth/config"
 	"github.com/Pedrommb91/go-auth/internal/app"
@@ -12,5 +13,20 @@ func main() {
 	if err != nil {
 		log.Fatalf("Config error: %s", err)
 	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate error: %s", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		if err := schema(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Schema error: %s", err)
+		}
+		return
+	}
+
 	app.Run(cfg)
 }
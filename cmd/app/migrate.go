@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/migrations"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+)
+
+const migrateUsage = "usage: app migrate up | down | status | to <version>"
+
+// migrate runs the migrate subcommand with the arguments following it.
+func migrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	db, err := database.Connect(context.Background(), cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m := database.NewMigrator(db, migrations.FS, os.Stdout)
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		return m.Up()
+	case args[0] == "down" && len(args) == 1:
+		return m.Down()
+	case args[0] == "status" && len(args) == 1:
+		return m.Status()
+	case args[0] == "to" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		return m.To(version)
+	default:
+		return fmt.Errorf(migrateUsage)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+)
+
+const schemaUsage = "usage: app schema check | scaffold <name>"
+
+// schemaModels are the models written by the query builder, their tags must
+// match the tables created by the migrations.
+var schemaModels = []database.TableSchema{
+	database.ModelSchema[models.Credentials](),
+	database.ModelSchema[models.Users](),
+}
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// schema runs the schema subcommand with the arguments following it. check
+// reports the drift of the database from the models and fails when there is
+// any, scaffold writes a migration fixing it for the configured driver.
+func schema(cfg *config.Config, args []string) error {
+	switch {
+	case len(args) == 1 && args[0] == "check":
+	case len(args) == 2 && args[0] == "scaffold":
+		if !migrationName.MatchString(args[1]) {
+			return fmt.Errorf("invalid migration name %q, use lowercase letters, digits and _", args[1])
+		}
+	default:
+		return fmt.Errorf(schemaUsage)
+	}
+
+	ctx := context.Background()
+	db, err := database.Connect(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var up, down []string
+	drifted := false
+	for _, model := range schemaModels {
+		table, err := database.InspectTable(ctx, db, model.Table)
+		if err != nil {
+			return err
+		}
+
+		drifts := database.Compare(model, table)
+		for _, drift := range drifts {
+			fmt.Println(drift)
+		}
+		drifted = drifted || len(drifts) > 0
+
+		u, d := database.Scaffold(database.DialectOf(db), model, drifts)
+		up = append(up, u...)
+		down = append(d, down...)
+	}
+
+	if !drifted {
+		fmt.Println("the database matches the models")
+		return nil
+	}
+	if args[0] == "check" {
+		return fmt.Errorf("the database drifted from the models")
+	}
+
+	dialect := database.DialectOf(db).Name()
+	path := filepath.Join("migrations", dialect, time.Now().UTC().Format("20060102150405")+"_"+args[1]+".sql")
+	if err := os.WriteFile(path, []byte(database.MigrationFile(up, down)), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s, review it and write the migrations of the other dialects\n", path)
+	return nil
+}
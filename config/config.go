@@ -3,17 +3,26 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type (
 	Config struct {
-		App      `mapstructure:"app"`
-		Log      `mapstructure:"logger"`
-		API      `mapstructure:"api"`
-		Database `mapstructure:"database"`
-		Encrypt  `mapstructure:"encrypt"`
+		App         `mapstructure:"app"`
+		Log         `mapstructure:"logger"`
+		API         `mapstructure:"api"`
+		Database    `mapstructure:"database"`
+		Encrypt     `mapstructure:"encrypt"`
+		Auth        `mapstructure:"auth"`
+		SAML        `mapstructure:"saml"`
+		Tenancy     `mapstructure:"tenancy"`
+		Mailer      `mapstructure:"mailer"`
+		Invitations `mapstructure:"invitations"`
+		SCIM        `mapstructure:"scim"`
+		Outbox      `mapstructure:"outbox"`
+		Webhooks    `mapstructure:"webhooks"`
 	}
 
 	App struct {
@@ -28,9 +37,19 @@ type (
 	API struct {
 		CORSAllowOrigins []string `env-required:"true" mapstructure:"cors_allow_origins" env:"API_CORS_ALLOW_ORIGINS"`
 		Address          string   `env-required:"true" mapstructure:"address" env:"API_ADDRESS"`
+		// AdminToken protects the organization management endpoints, they are
+		// disabled while it is empty
+		AdminToken string `mapstructure:"admin_token" env:"API_ADMIN_TOKEN"`
+		// CursorSecret signs the pagination cursors, a random key is used
+		// while it is empty so cursors do not survive a restart
+		CursorSecret string `mapstructure:"cursor_secret" env:"API_CURSOR_SECRET"`
 	}
 
 	Database struct {
+		// Driver is the dialect of the database, postgres, sqlite or mysql
+		Driver string `mapstructure:"driver" env:"DATABASE_DRIVER"`
+		// Path is the file of the sqlite database
+		Path     string `mapstructure:"path" env:"DATABASE_PATH"`
 		Host     string `env-required:"true" mapstructure:"host" env:"DATABASE_HOST"`
 		Port     string `env-required:"true" mapstructure:"port" env:"DATABASE_PORT"`
 		User     string `env-required:"true" mapstructure:"user" env:"DATABASE_USER"`
@@ -38,11 +57,148 @@ type (
 		Password string `env-required:"true" mapstructure:"password" env:"DATABASE_PASSWORD"`
 		SslMode  string `env-required:"true" mapstructure:"sslmode" env:"DATABASE_SSLMODE"`
 		Schema   string `env-required:"true" mapstructure:"schema" env:"DATABASE_SCHEMA"`
+		// QueryTimeout bounds the database work of a request, zero disables it
+		QueryTimeout time.Duration `mapstructure:"query_timeout" env:"DATABASE_QUERY_TIMEOUT"`
+		// AutoMigrate applies the pending migrations at startup, it needs a
+		// pool of at least two connections on postgres and mysql
+		AutoMigrate bool `mapstructure:"auto_migrate" env:"DATABASE_AUTO_MIGRATE"`
+		// MaxOpenConns and MaxIdleConns size the connection pool, zero keeps
+		// the driver defaults
+		MaxOpenConns    int           `mapstructure:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS"`
+		MaxIdleConns    int           `mapstructure:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS"`
+		ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime" env:"DATABASE_CONN_MAX_LIFETIME"`
+		ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time" env:"DATABASE_CONN_MAX_IDLE_TIME"`
+		// PingRetries is how many times the startup ping is retried, the wait
+		// starts at PingBackoff and doubles after every attempt
+		PingRetries int           `mapstructure:"ping_retries" env:"DATABASE_PING_RETRIES"`
+		PingBackoff time.Duration `mapstructure:"ping_backoff" env:"DATABASE_PING_BACKOFF"`
+		// Replicas are the DSNs of the read replicas, in the format of the
+		// driver
+		Replicas []string `mapstructure:"replicas" env:"DATABASE_REPLICAS"`
+		// ReplicaCheckInterval is how often the replicas are pinged, the
+		// failing ones stop serving reads until they answer again
+		ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval" env:"DATABASE_REPLICA_CHECK_INTERVAL"`
+		// ReadYourWrites sends the reads of a request to the primary once the
+		// request wrote
+		ReadYourWrites bool `mapstructure:"read_your_writes" env:"DATABASE_READ_YOUR_WRITES"`
+		// SlowQueryThreshold is the duration past which a query is logged as
+		// slow, zero disables the warnings
+		SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold" env:"DATABASE_SLOW_QUERY_THRESHOLD"`
+		// Tracing emits an OpenTelemetry span for every request and for the
+		// queries it runs, through the global tracer provider
+		Tracing bool `mapstructure:"tracing" env:"DATABASE_TRACING"`
 	}
 
 	Encrypt struct {
 		Password string `env-required:"true" mapstructure:"password" env:"ENCRYPT_PASSWORD"`
 	}
+
+	Auth struct {
+		// Backends are tried in order until one of them accepts the credentials
+		Backends []string      `mapstructure:"backends" env:"AUTH_BACKENDS"`
+		CacheTTL time.Duration `mapstructure:"cache_ttl" env:"AUTH_CACHE_TTL"`
+		LDAP     LDAP          `mapstructure:"ldap"`
+	}
+
+	// LDAP is the directory shared by the tenants, each tenant searches its
+	// own base dn set in the organization settings
+	LDAP struct {
+		URL                string        `mapstructure:"url" env:"AUTH_LDAP_URL"`
+		BindDN             string        `mapstructure:"bind_dn" env:"AUTH_LDAP_BIND_DN"`
+		BindPassword       string        `mapstructure:"bind_password" env:"AUTH_LDAP_BIND_PASSWORD"`
+		UserFilter         string        `mapstructure:"user_filter" env:"AUTH_LDAP_USER_FILTER"`
+		UsernameAttribute  string        `mapstructure:"username_attribute" env:"AUTH_LDAP_USERNAME_ATTRIBUTE"`
+		EmailAttribute     string        `mapstructure:"email_attribute" env:"AUTH_LDAP_EMAIL_ATTRIBUTE"`
+		GroupAttribute     string        `mapstructure:"group_attribute" env:"AUTH_LDAP_GROUP_ATTRIBUTE"`
+		StartTLS           bool          `mapstructure:"start_tls" env:"AUTH_LDAP_START_TLS"`
+		InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" env:"AUTH_LDAP_INSECURE_SKIP_VERIFY"`
+		Timeout            time.Duration `mapstructure:"timeout" env:"AUTH_LDAP_TIMEOUT"`
+	}
+
+	SAML struct {
+		// BaseURL is the public address of the api used to build the metadata and acs urls
+		BaseURL         string `mapstructure:"base_url" env:"SAML_BASE_URL"`
+		CertificateFile string `mapstructure:"certificate_file" env:"SAML_CERTIFICATE_FILE"`
+		KeyFile         string `mapstructure:"key_file" env:"SAML_KEY_FILE"`
+		// Providers are keyed by organization slug
+		Providers map[string]SAMLProvider `mapstructure:"providers"`
+	}
+
+	// SAMLProvider configures the identity provider of a single tenant
+	SAMLProvider struct {
+		IDPMetadataURL    string            `mapstructure:"idp_metadata_url"`
+		IDPMetadata       string            `mapstructure:"idp_metadata"`
+		UsernameAttribute string            `mapstructure:"username_attribute"`
+		EmailAttribute    string            `mapstructure:"email_attribute"`
+		GroupAttribute    string            `mapstructure:"group_attribute"`
+		GroupRoles        map[string]string `mapstructure:"group_roles"`
+		AllowIDPInitiated bool              `mapstructure:"allow_idp_initiated"`
+	}
+
+	Tenancy struct {
+		// Resolvers are tried in order, the supported ones are path, header and host
+		Resolvers []string `mapstructure:"resolvers" env:"TENANCY_RESOLVERS"`
+		Header    string   `mapstructure:"header" env:"TENANCY_HEADER"`
+		// DefaultOrganization is used when no resolver finds a tenant, leave it
+		// empty to reject those requests
+		DefaultOrganization string `mapstructure:"default_organization" env:"TENANCY_DEFAULT_ORGANIZATION"`
+	}
+
+	Mailer struct {
+		// Backend is either smtp or log, the latter only writes the emails to the log
+		Backend  string `mapstructure:"backend" env:"MAILER_BACKEND"`
+		Host     string `mapstructure:"host" env:"MAILER_HOST"`
+		Port     string `mapstructure:"port" env:"MAILER_PORT"`
+		Username string `mapstructure:"username" env:"MAILER_USERNAME"`
+		Password string `mapstructure:"password" env:"MAILER_PASSWORD"`
+		From     string `mapstructure:"from" env:"MAILER_FROM"`
+	}
+
+	Invitations struct {
+		TTL time.Duration `mapstructure:"ttl" env:"INVITATIONS_TTL"`
+		// AcceptURL is sent in the email with the token appended as a query parameter
+		AcceptURL string `mapstructure:"accept_url" env:"INVITATIONS_ACCEPT_URL"`
+	}
+
+	SCIM struct {
+		// BaseURL is the public address of the SCIM endpoints used in resource locations
+		BaseURL string `mapstructure:"base_url" env:"SCIM_BASE_URL"`
+		// MaxResults caps the page size of the listings
+		MaxResults int `mapstructure:"max_results" env:"SCIM_MAX_RESULTS"`
+	}
+
+	Outbox struct {
+		// Sinks receive every event besides the webhook subscriptions, the
+		// supported ones are webhook, stdout and file
+		Sinks      []string `mapstructure:"sinks" env:"OUTBOX_SINKS"`
+		WebhookURL string   `mapstructure:"webhook_url" env:"OUTBOX_WEBHOOK_URL"`
+		// File is where the file sink appends the events, one json per line
+		File string `mapstructure:"file" env:"OUTBOX_FILE"`
+		// PollInterval is how often the relay looks for pending events
+		PollInterval time.Duration `mapstructure:"poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+		BatchSize    int           `mapstructure:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+		// MaxAttempts is how many times an event is tried before the relay
+		// gives up on it, the wait between attempts starts at Backoff and
+		// doubles after every failure
+		MaxAttempts int           `mapstructure:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS"`
+		Backoff     time.Duration `mapstructure:"backoff" env:"OUTBOX_BACKOFF"`
+	}
+
+	Webhooks struct {
+		// PollInterval is how often the dispatcher looks for due deliveries
+		PollInterval time.Duration `mapstructure:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL"`
+		BatchSize    int           `mapstructure:"batch_size" env:"WEBHOOKS_BATCH_SIZE"`
+		// MaxAttempts is how many times a delivery is tried before it is
+		// dead, the wait between attempts starts at Backoff and doubles after
+		// every failure
+		MaxAttempts int           `mapstructure:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+		Backoff     time.Duration `mapstructure:"backoff" env:"WEBHOOKS_BACKOFF"`
+		// Timeout bounds the request of a delivery
+		Timeout time.Duration `mapstructure:"timeout" env:"WEBHOOKS_TIMEOUT"`
+		// AllowedNetworks are the CIDRs of the loopback, link-local and
+		// private addresses the webhooks may reach, the others are refused
+		AllowedNetworks []string `mapstructure:"allowed_networks" env:"WEBHOOKS_ALLOWED_NETWORKS"`
+	}
 )
 
 func NewConfig() (*Config, error) {
@@ -5,6 +5,7 @@ import (
 	"path"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/Pedrommb91/go-auth/config"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,39 @@ func TestNewConfig(t *testing.T) {
 
 		assert.Equal(t, ":8080", cfg.Address)
 		assert.Equal(t, make([]string, 0), cfg.CORSAllowOrigins)
+
+		assert.Equal(t, "postgres", cfg.Database.Driver)
+		assert.Equal(t, 5*time.Second, cfg.Database.QueryTimeout)
+		assert.False(t, cfg.Database.AutoMigrate)
+		assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+		assert.Equal(t, 30*time.Minute, cfg.Database.ConnMaxLifetime)
+		assert.Equal(t, 5, cfg.Database.PingRetries)
+		assert.Equal(t, time.Second, cfg.Database.PingBackoff)
+		assert.Empty(t, cfg.Database.Replicas)
+		assert.Equal(t, 10*time.Second, cfg.Database.ReplicaCheckInterval)
+		assert.True(t, cfg.Database.ReadYourWrites)
+		assert.Equal(t, 200*time.Millisecond, cfg.Database.SlowQueryThreshold)
+		assert.False(t, cfg.Database.Tracing)
+
+		assert.Equal(t, []string{"database"}, cfg.Auth.Backends)
+		assert.Equal(t, 5*time.Minute, cfg.Auth.CacheTTL)
+		assert.Equal(t, "memberOf", cfg.Auth.LDAP.GroupAttribute)
+		assert.Equal(t, "http://localhost:8080", cfg.SAML.BaseURL)
+		assert.Empty(t, cfg.SAML.Providers)
+
+		assert.Equal(t, []string{"path", "header", "host"}, cfg.Tenancy.Resolvers)
+		assert.Equal(t, "X-Tenant-ID", cfg.Tenancy.Header)
+		assert.Equal(t, "default", cfg.Tenancy.DefaultOrganization)
+
+		assert.Equal(t, "log", cfg.Mailer.Backend)
+		assert.Equal(t, 7*24*time.Hour, cfg.Invitations.TTL)
+		assert.Equal(t, 200, cfg.SCIM.MaxResults)
+
+		assert.Empty(t, cfg.Outbox.Sinks)
+		assert.Equal(t, time.Second, cfg.Outbox.PollInterval)
+		assert.Equal(t, 10, cfg.Outbox.MaxAttempts)
+		assert.Equal(t, 8, cfg.Webhooks.MaxAttempts)
+		assert.Equal(t, 10*time.Second, cfg.Webhooks.Timeout)
 	})
 
 	t.Run("Test config replace with environment variables", func(t *testing.T) {
@@ -0,0 +1,95 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/encrypt"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	DatabaseBackend = "database"
+	LDAPBackend     = "ldap"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error)
+}
+
+// Dependencies are what the backends need to resolve the stored user of an
+// identity, the users of the directory logging in for the first time are
+// provisioned.
+type Dependencies struct {
+	Users       models.UserReaderInterface
+	Provisioner models.UserProvisionerInterface
+	Transactor  models.TransactorInterface
+	Outbox      models.OutboxInterface
+	Encryptor   encrypt.Encryptor
+}
+
+// New builds every known backend and selects them per organization, falling
+// back to the configured backends. Results are cached when a cache ttl is
+// configured.
+func New(cfg *config.Config, deps Dependencies) (Authenticator, error) {
+	const op errors.Op = "authenticators.New"
+
+	backends := make(map[string]Authenticator)
+	for _, name := range []string{DatabaseBackend, LDAPBackend} {
+		backend, err := NewBackend(name, cfg, deps)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithNestedErrorCopy(err),
+			)
+		}
+		backends[name] = backend
+	}
+
+	selector, err := NewSelector(backends, cfg.Auth.Backends)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	var auth Authenticator = selector
+	if cfg.Auth.CacheTTL > 0 {
+		auth = NewCached(auth, cfg.Auth.CacheTTL, &clock.RealClock{})
+	}
+
+	return auth, nil
+}
+
+// NewBackend creates a single authentication backend by name.
+func NewBackend(name string, cfg *config.Config, deps Dependencies) (Authenticator, error) {
+	const op errors.Op = "authenticators.NewBackend"
+
+	switch name {
+	case DatabaseBackend:
+		return NewDatabaseAuthenticator(deps.Users, cfg.Encrypt, deps.Encryptor), nil
+	case LDAPBackend:
+		return NewLDAPAuthenticator(cfg.Auth.LDAP, deps), nil
+	default:
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("unknown authentication backend %q", name)),
+			errors.WithMessage("Unknown authentication backend"),
+		)
+	}
+}
+
+func invalidCredentials(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Invalid username or password"),
+		errors.KindUnauthorized(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
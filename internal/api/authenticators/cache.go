@@ -0,0 +1,88 @@
+package authenticators
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+)
+
+type cacheEntry struct {
+	identity  models.Identity
+	expiresAt time.Time
+}
+
+// Cached keeps successful authentications for a fixed time so that slow
+// backends, like a remote directory, are not hit on every request. Failures
+// are never cached.
+type Cached struct {
+	next    Authenticator
+	ttl     time.Duration
+	clock   clock.Clock
+	key     []byte
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCached(next Authenticator, ttl time.Duration, clock clock.Clock) *Cached {
+	// passwords are only kept as a keyed hash that is useless outside this process
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+
+	return &Cached{
+		next:    next,
+		ttl:     ttl,
+		clock:   clock,
+		key:     key,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *Cached) Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	key := c.cacheKey(org.ID, username, password)
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.identity, nil
+	}
+
+	identity, err := c.next.Authenticate(ctx, org, username, password)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range c.entries {
+		if !now.Before(v.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{
+		identity:  identity,
+		expiresAt: now.Add(c.ttl),
+	}
+
+	return identity, nil
+}
+
+func (c *Cached) cacheKey(organizationID int32, username, password string) string {
+	mac := hmac.New(sha256.New, c.key)
+	_ = binary.Write(mac, binary.BigEndian, organizationID)
+	mac.Write([]byte(username))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
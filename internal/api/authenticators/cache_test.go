@@ -0,0 +1,76 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCached_Authenticate(t *testing.T) {
+	now := time.Now()
+	org := models.Organizations{ID: 1, Slug: "default"}
+	identity := models.Identity{User: models.Users{ID: 1, Username: "alice"}, Backend: LDAPBackend}
+
+	t.Run("Successful authentication is cached until it expires", func(t *testing.T) {
+		next := mocks.NewAuthenticator(t)
+		next.On("Authenticate", mock.Anything, org, "alice", "password").Return(identity, nil).Twice()
+
+		clk := mocks.NewClock(t)
+		clk.On("Now").Return(now).Twice()
+		clk.On("Now").Return(now.Add(time.Minute)).Once()
+
+		c := NewCached(next, time.Minute, clk)
+		for i := 0; i < 3; i++ {
+			got, err := c.Authenticate(context.Background(), org, "alice", "password")
+			assert.NoError(t, err)
+			assert.Equal(t, identity, got)
+		}
+	})
+
+	t.Run("Different password is not served from the cache", func(t *testing.T) {
+		next := mocks.NewAuthenticator(t)
+		next.On("Authenticate", mock.Anything, org, "alice", "password").Return(identity, nil).Once()
+		next.On("Authenticate", mock.Anything, org, "alice", "wrong").Return(models.Identity{}, errors.Build(
+			errors.KindUnauthorized(),
+			errors.WithError(fmt.Errorf("invalid credentials")),
+		)).Twice()
+
+		clk := mocks.NewClock(t)
+		clk.On("Now").Return(now)
+
+		c := NewCached(next, time.Minute, clk)
+		_, err := c.Authenticate(context.Background(), org, "alice", "password")
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = c.Authenticate(context.Background(), org, "alice", "wrong")
+			assert.True(t, errors.IsKind(err, errors.Unauthorized))
+		}
+	})
+
+	t.Run("Cached authentication is not shared between organizations", func(t *testing.T) {
+		other := models.Organizations{ID: 2, Slug: "acme"}
+
+		next := mocks.NewAuthenticator(t)
+		next.On("Authenticate", mock.Anything, org, "alice", "password").Return(identity, nil).Once()
+		next.On("Authenticate", mock.Anything, other, "alice", "password").Return(models.Identity{}, errors.Build(
+			errors.KindUnauthorized(),
+			errors.WithError(fmt.Errorf("invalid credentials")),
+		)).Once()
+
+		clk := mocks.NewClock(t)
+		clk.On("Now").Return(now)
+
+		c := NewCached(next, time.Minute, clk)
+		_, err := c.Authenticate(context.Background(), org, "alice", "password")
+		assert.NoError(t, err)
+		_, err = c.Authenticate(context.Background(), other, "alice", "password")
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+}
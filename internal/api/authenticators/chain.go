@@ -0,0 +1,47 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// Chain tries each authenticator in order and returns the first identity
+// that is accepted.
+type Chain struct {
+	backends []Authenticator
+}
+
+func NewChain(backends ...Authenticator) *Chain {
+	return &Chain{
+		backends: backends,
+	}
+}
+
+func (c *Chain) Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	const op errors.Op = "authenticators.Chain.Authenticate"
+
+	// A backend failure is only reported when no other backend accepts the
+	// credentials, otherwise an unavailable directory would lock every user out.
+	var failure error
+	for _, backend := range c.backends {
+		identity, err := backend.Authenticate(ctx, org, username, password)
+		if err == nil {
+			return identity, nil
+		}
+		if failure == nil && !errors.IsKind(err, errors.Unauthorized) {
+			failure = err
+		}
+	}
+
+	if failure != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(failure),
+		)
+	}
+
+	return models.Identity{}, invalidCredentials(op, fmt.Errorf("credentials rejected by all backends"))
+}
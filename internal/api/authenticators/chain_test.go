@@ -0,0 +1,81 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_Authenticate(t *testing.T) {
+	org := models.Organizations{ID: 1, Slug: "default"}
+	identity := models.Identity{User: models.Users{ID: 1, Username: "alice"}, Backend: LDAPBackend}
+	unauthorized := errors.Build(errors.KindUnauthorized(), errors.WithError(fmt.Errorf("invalid credentials")))
+	unavailable := errors.Build(errors.KindBadGateway(), errors.WithError(fmt.Errorf("connection refused")))
+
+	type response struct {
+		identity models.Identity
+		err      error
+	}
+	tests := []struct {
+		name         string
+		responses    []response
+		wantIdentity models.Identity
+		wantKind     errors.Kind
+		wantErr      bool
+	}{
+		{
+			name:         "First backend accepts",
+			responses:    []response{{identity: identity}, {err: unauthorized}},
+			wantIdentity: identity,
+		},
+		{
+			name:         "Falls through to the next backend",
+			responses:    []response{{err: unauthorized}, {identity: identity}},
+			wantIdentity: identity,
+		},
+		{
+			name:         "Unavailable backend does not block the others",
+			responses:    []response{{err: unavailable}, {identity: identity}},
+			wantIdentity: identity,
+		},
+		{
+			name:      "All backends reject",
+			responses: []response{{err: unauthorized}, {err: unauthorized}},
+			wantKind:  errors.Unauthorized,
+			wantErr:   true,
+		},
+		{
+			name:      "Backend failure is reported when nobody accepts",
+			responses: []response{{err: unauthorized}, {err: unavailable}},
+			wantKind:  errors.BadGateway,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backends := make([]Authenticator, 0, len(tt.responses))
+			for _, r := range tt.responses {
+				m := mocks.NewAuthenticator(t)
+				m.On("Authenticate", mock.Anything, org, "alice", "password").Return(r.identity, r.err).Maybe()
+				backends = append(backends, m)
+			}
+
+			got, err := NewChain(backends...).Authenticate(context.Background(), org, "alice", "password")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, tt.wantKind))
+				return
+			}
+			assert.Equal(t, tt.wantIdentity, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package authenticators
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/encrypt"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// DatabaseAuthenticator checks the credentials stored with the user.
+type DatabaseAuthenticator struct {
+	r         models.UserReaderInterface
+	encrypt   config.Encrypt
+	encryptor encrypt.Encryptor
+}
+
+func NewDatabaseAuthenticator(r models.UserReaderInterface, encrypt config.Encrypt, encryptor encrypt.Encryptor) DatabaseAuthenticator {
+	return DatabaseAuthenticator{
+		r:         r,
+		encrypt:   encrypt,
+		encryptor: encryptor,
+	}
+}
+
+func (a DatabaseAuthenticator) Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	const op errors.Op = "authenticators.DatabaseAuthenticator.Authenticate"
+
+	user, err := a.r.GetUserByUsername(ctx, org.ID, username)
+	if errors.IsKind(err, errors.NotFound) {
+		// the innermost kind is what gets reported, so the not found is not nested
+		return models.Identity{}, invalidCredentials(op, fmt.Errorf("user %s not found", username))
+	}
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate user"),
+		)
+	}
+
+	// users provisioned without a password can only log in through SSO
+	if !user.Active || user.Credentials.ID == 0 {
+		return models.Identity{}, invalidCredentials(op, fmt.Errorf("user %s cannot log in with a password", username))
+	}
+
+	stored, err := a.encryptor.Decrypt(user.Credentials.PassHash, user.Credentials.Salt, a.encrypt.Password)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate user"),
+		)
+	}
+
+	if password == "" || subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+		return models.Identity{}, invalidCredentials(op, fmt.Errorf("password mismatch for user %s", username))
+	}
+
+	roles, err := a.r.GetUserRoles(ctx, org.ID, user.ID)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate user"),
+		)
+	}
+
+	user.Credentials = models.Credentials{}
+	return models.Identity{
+		User:    user,
+		Roles:   roles,
+		Backend: DatabaseBackend,
+	}, nil
+}
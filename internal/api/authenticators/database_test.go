@@ -0,0 +1,137 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseAuthenticator_Authenticate(t *testing.T) {
+	org := models.Organizations{ID: 2, Slug: "acme"}
+	user := models.Users{
+		ID:             1,
+		OrganizationID: org.ID,
+		Username:       faker.Username(),
+		Email:          faker.Email(),
+		Active:         true,
+		Credentials: models.Credentials{
+			ID:       1,
+			Salt:     faker.Password(),
+			PassHash: faker.Password(),
+		},
+	}
+	encryptCfg := config.Encrypt{Password: faker.Password()}
+
+	type getUserMockResponse struct {
+		user models.Users
+		err  error
+	}
+	type args struct {
+		password string
+	}
+	tests := []struct {
+		name                string
+		getUserMockResponse getUserMockResponse
+		decrypted           string
+		args                args
+		wantUser            models.Users
+		wantRoles           []string
+		wantKind            errors.Kind
+		wantErr             bool
+	}{
+		{
+			name:                "Success",
+			getUserMockResponse: getUserMockResponse{user: user},
+			decrypted:           "#sdjU1kaL!",
+			args:                args{password: "#sdjU1kaL!"},
+			wantUser: models.Users{
+				ID:             user.ID,
+				OrganizationID: org.ID,
+				Username:       user.Username,
+				Email:          user.Email,
+				Active:         true,
+			},
+			wantRoles: []string{"admin"},
+		},
+		{
+			name:                "Wrong password",
+			getUserMockResponse: getUserMockResponse{user: user},
+			decrypted:           "#sdjU1kaL!",
+			args:                args{password: "#sdjU1kaL?"},
+			wantKind:            errors.Unauthorized,
+			wantErr:             true,
+		},
+		{
+			name: "Inactive user",
+			getUserMockResponse: getUserMockResponse{user: func() models.Users {
+				u := user
+				u.Active = false
+				return u
+			}()},
+			args:     args{password: "#sdjU1kaL!"},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name: "User without password",
+			getUserMockResponse: getUserMockResponse{user: func() models.Users {
+				u := user
+				u.Credentials = models.Credentials{}
+				return u
+			}()},
+			args:     args{password: "#sdjU1kaL!"},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name: "Unknown user",
+			getUserMockResponse: getUserMockResponse{
+				err: errors.Build(errors.KindNotFound(), errors.WithError(fmt.Errorf("no rows"))),
+			},
+			args:     args{password: "#sdjU1kaL!"},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name: "Repository failure",
+			getUserMockResponse: getUserMockResponse{
+				err: errors.Build(errors.WithError(fmt.Errorf("connection refused"))),
+			},
+			args:     args{password: "#sdjU1kaL!"},
+			wantKind: errors.Unexpected,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mocks.NewUserReaderInterface(t)
+			r.On("GetUserByUsername", mock.Anything, org.ID, user.Username).Return(tt.getUserMockResponse.user, tt.getUserMockResponse.err)
+			r.On("GetUserRoles", mock.Anything, org.ID, user.ID).Return([]string{"admin"}, nil).Maybe()
+
+			enc := mocks.NewEncryptor(t)
+			enc.On("Decrypt", user.Credentials.PassHash, user.Credentials.Salt, encryptCfg.Password).Return(tt.decrypted, nil).Maybe()
+
+			a := NewDatabaseAuthenticator(r, encryptCfg, enc)
+			got, err := a.Authenticate(context.Background(), org, user.Username, tt.args.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DatabaseAuthenticator.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, tt.wantKind))
+				return
+			}
+			assert.Equal(t, tt.wantUser, got.User)
+			assert.Equal(t, tt.wantRoles, got.Roles)
+			assert.Equal(t, DatabaseBackend, got.Backend)
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package authenticators
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPAuthenticator binds with a service account, searches for the user entry
+// under the base dn of the organization and then binds as that entry to
+// verify the password. The entry is linked to the stored user of the
+// organization, so the identity is the same whatever the backend.
+type LDAPAuthenticator struct {
+	cfg         config.LDAP
+	users       models.UserReaderInterface
+	provisioner models.UserProvisionerInterface
+	tx          models.TransactorInterface
+	outbox      models.OutboxInterface
+}
+
+func NewLDAPAuthenticator(cfg config.LDAP, deps Dependencies) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		cfg:         cfg,
+		users:       deps.Users,
+		provisioner: deps.Provisioner,
+		tx:          deps.Transactor,
+		outbox:      deps.Outbox,
+	}
+}
+
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	const op errors.Op = "authenticators.LDAPAuthenticator.Authenticate"
+
+	// An empty password would be accepted by most servers as an unauthenticated bind
+	if username == "" || password == "" {
+		return models.Identity{}, invalidCredentials(op, fmt.Errorf("empty username or password"))
+	}
+
+	// the directory is shared, a tenant without a base dn would search the
+	// users of every other tenant
+	settings := org.Settings.LDAP
+	if settings == nil || settings.BaseDN == "" {
+		return models.Identity{}, invalidCredentials(op, fmt.Errorf("no ldap base dn for organization %s", org.Slug))
+	}
+
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to connect to the directory"),
+			errors.KindBadGateway(),
+		)
+	}
+	defer conn.Close()
+
+	// closing the connection aborts the pending request once ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if a.cfg.BindDN != "" {
+		if err := conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+			return models.Identity{}, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to bind to the directory"),
+				errors.KindBadGateway(),
+			)
+		}
+	}
+
+	entry, err := a.findUser(conn, *settings, username)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return models.Identity{}, invalidCredentials(op, err)
+		}
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to bind to the directory"),
+			errors.KindBadGateway(),
+		)
+	}
+
+	identity := a.toIdentity(org, *settings, entry, username)
+	user, err := a.link(ctx, identity.User)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+	identity.User = user
+
+	// the roles of the directory groups add to the ones stored for the user
+	stored, err := a.users.GetUserRoles(ctx, org.ID, user.ID)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate user"),
+		)
+	}
+	identity.Roles = mergeRoles(stored, identity.Roles)
+
+	return identity, nil
+}
+
+// link returns the stored user of the directory entry, looked up by
+// username and else by email within the organization. Unknown users are
+// provisioned without credentials, they keep logging in through the
+// directory.
+func (a *LDAPAuthenticator) link(ctx context.Context, entry models.Users) (models.Users, error) {
+	const op errors.Op = "authenticators.LDAPAuthenticator.link"
+
+	user, err := a.users.GetUserByUsername(ctx, entry.OrganizationID, entry.Username)
+	if errors.IsKind(err, errors.NotFound) && entry.Email != "" {
+		user, err = a.users.GetUserByEmail(ctx, entry.OrganizationID, entry.Email)
+	}
+	if err == nil {
+		if !user.Active {
+			return models.Users{}, invalidCredentials(op, fmt.Errorf("user %s is inactive", user.Username))
+		}
+		return user, nil
+	}
+	if !errors.IsKind(err, errors.NotFound) {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user"),
+		)
+	}
+
+	if entry.Email == "" {
+		return models.Users{}, invalidCredentials(op, fmt.Errorf("unknown user %s has no email to be provisioned with", entry.Username))
+	}
+	entry.Active = true
+
+	var created models.Users
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = a.provisioner.ProvisionUser(ctx, entry); err != nil {
+			return err
+		}
+		return a.outbox.Record(ctx, models.UserRegistered, models.NewUserEvent(created, int64(created.ID)))
+	})
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to provision user"),
+		)
+	}
+
+	return created, nil
+}
+
+// mergeRoles returns the stored roles followed by the other ones not among
+// them.
+func mergeRoles(stored, other []string) []string {
+	roles := append(make([]string, 0, len(stored)+len(other)), stored...)
+	for _, role := range other {
+		known := false
+		for _, r := range roles {
+			known = known || r == role
+		}
+		if !known {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func (a *LDAPAuthenticator) findUser(conn *ldap.Conn, settings models.LDAPSettings, username string) (*ldap.Entry, error) {
+	const op errors.Op = "authenticators.LDAPAuthenticator.findUser"
+
+	filter := a.cfg.UserFilter
+	if settings.UserFilter != "" {
+		filter = settings.UserFilter
+	}
+
+	req := ldap.NewSearchRequest(
+		settings.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		0,
+		false,
+		fmt.Sprintf(filter, ldap.EscapeFilter(username)),
+		[]string{a.cfg.UsernameAttribute, a.cfg.EmailAttribute, a.cfg.GroupAttribute},
+		nil,
+	)
+
+	res, err := conn.Search(req)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, invalidCredentials(op, err)
+	}
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to search the directory"),
+			errors.KindBadGateway(),
+		)
+	}
+
+	if len(res.Entries) != 1 {
+		return nil, invalidCredentials(op, fmt.Errorf("expected one entry for user %s, found %d", username, len(res.Entries)))
+	}
+
+	return res.Entries[0], nil
+}
+
+func (a *LDAPAuthenticator) toIdentity(org models.Organizations, settings models.LDAPSettings, entry *ldap.Entry, username string) models.Identity {
+	user := models.Users{
+		OrganizationID: org.ID,
+		Username:       entry.GetAttributeValue(a.cfg.UsernameAttribute),
+		Email:          entry.GetAttributeValue(a.cfg.EmailAttribute),
+	}
+	if user.Username == "" {
+		user.Username = username
+	}
+
+	roles := make([]string, 0)
+	for _, group := range entry.GetAttributeValues(a.cfg.GroupAttribute) {
+		// group DNs are case insensitive
+		for dn, role := range settings.GroupRoles {
+			if strings.EqualFold(dn, group) {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	return models.Identity{
+		User:    user,
+		Roles:   roles,
+		Backend: LDAPBackend,
+	}
+}
+
+// dial connects to the directory, the configured timeout is shortened to
+// the deadline of ctx.
+func (a *LDAPAuthenticator) dial(ctx context.Context) (*ldap.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	timeout := a.cfg.Timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		if timeout <= 0 || left < timeout {
+			timeout = left
+		}
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: a.cfg.InsecureSkipVerify, // nolint: gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := ldap.DialURL(a.cfg.URL,
+		ldap.DialWithTLSConfig(tlsConfig),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		conn.SetTimeout(timeout)
+	}
+
+	if a.cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,189 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/jimlambrt/gldap/testdirectory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLDAPAuthenticator_Authenticate(t *testing.T) {
+	td := testdirectory.Start(t, testdirectory.WithNoTLS(t))
+
+	admins := testdirectory.NewMemberOf(t, []string{"admins"})
+	users := testdirectory.NewUsers(t, []string{"alice"}, testdirectory.WithMembersOf(t, admins...))
+	users = append(users, testdirectory.NewUsers(t, []string{"bob"})...)
+	td.SetUsers(users...)
+
+	org := models.Organizations{ID: 1, Slug: "default", Settings: models.OrganizationSettings{
+		LDAP: &models.LDAPSettings{
+			BaseDN: testdirectory.DefaultUserDN,
+			GroupRoles: map[string]string{
+				"CN=admins,ou=groups,dc=example,dc=org": "admin",
+			},
+		},
+	}}
+	cfg := config.LDAP{
+		URL:               fmt.Sprintf("ldap://%s:%d", td.Host(), td.Port()),
+		UserFilter:        "(cn=%s)",
+		UsernameAttribute: "name",
+		EmailAttribute:    "email",
+		GroupAttribute:    "memberOf",
+		Timeout:           5 * time.Second,
+	}
+
+	notFound := errors.Build(errors.KindNotFound())
+	alice := models.Users{ID: 3, OrganizationID: org.ID, Username: "alice", Email: "alice@example.com", Active: true}
+	bob := models.Users{ID: 9, OrganizationID: org.ID, Username: "bob", Email: "bob@example.com", Active: true}
+
+	type args struct {
+		username string
+		password string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(users *mocks.UserReaderInterface, provisioner *mocks.UserProvisionerInterface, outbox *mocks.OutboxInterface)
+		wantID    int32
+		wantEmail string
+		wantRoles []string
+		wantKind  errors.Kind
+		wantErr   bool
+	}{
+		{
+			name: "Stored user with group mapped to role",
+			args: args{username: "alice", password: "password"},
+			mock: func(users *mocks.UserReaderInterface, _ *mocks.UserProvisionerInterface, _ *mocks.OutboxInterface) {
+				users.On("GetUserByUsername", mock.Anything, org.ID, "alice").Return(alice, nil)
+				users.On("GetUserRoles", mock.Anything, org.ID, alice.ID).Return([]string{"member"}, nil)
+			},
+			wantID:    alice.ID,
+			wantEmail: "alice@example.com",
+			wantRoles: []string{"member", "admin"},
+		},
+		{
+			name: "Stored user linked by email",
+			args: args{username: "alice", password: "password"},
+			mock: func(users *mocks.UserReaderInterface, _ *mocks.UserProvisionerInterface, _ *mocks.OutboxInterface) {
+				users.On("GetUserByUsername", mock.Anything, org.ID, "alice").Return(models.Users{}, notFound)
+				users.On("GetUserByEmail", mock.Anything, org.ID, "alice@example.com").Return(alice, nil)
+				users.On("GetUserRoles", mock.Anything, org.ID, alice.ID).Return([]string{"admin"}, nil)
+			},
+			wantID:    alice.ID,
+			wantEmail: "alice@example.com",
+			wantRoles: []string{"admin"},
+		},
+		{
+			name: "Unknown user is provisioned",
+			args: args{username: "bob", password: "password"},
+			mock: func(users *mocks.UserReaderInterface, provisioner *mocks.UserProvisionerInterface, outbox *mocks.OutboxInterface) {
+				users.On("GetUserByUsername", mock.Anything, org.ID, "bob").Return(models.Users{}, notFound)
+				users.On("GetUserByEmail", mock.Anything, org.ID, "bob@example.com").Return(models.Users{}, notFound)
+				provisioner.On("ProvisionUser", mock.Anything, models.Users{
+					OrganizationID: org.ID,
+					Username:       "bob",
+					Email:          "bob@example.com",
+					Active:         true,
+				}).Return(bob, nil)
+				outbox.On("Record", mock.Anything, models.UserRegistered, models.NewUserEvent(bob, int64(bob.ID))).Return(nil)
+				users.On("GetUserRoles", mock.Anything, org.ID, bob.ID).Return([]string{}, nil)
+			},
+			wantID:    bob.ID,
+			wantEmail: "bob@example.com",
+			wantRoles: []string{},
+		},
+		{
+			name: "Inactive stored user",
+			args: args{username: "alice", password: "password"},
+			mock: func(users *mocks.UserReaderInterface, _ *mocks.UserProvisionerInterface, _ *mocks.OutboxInterface) {
+				inactive := alice
+				inactive.Active = false
+				users.On("GetUserByUsername", mock.Anything, org.ID, "alice").Return(inactive, nil)
+			},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name:     "Wrong password",
+			args:     args{username: "alice", password: "wrong"},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown user",
+			args:     args{username: "carol", password: "password"},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+		{
+			name:     "Empty password is never sent to the directory",
+			args:     args{username: "alice", password: ""},
+			wantKind: errors.Unauthorized,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mocks.NewUserReaderInterface(t)
+			provisioner := mocks.NewUserProvisionerInterface(t)
+			outbox := mocks.NewOutboxInterface(t)
+			if tt.mock != nil {
+				tt.mock(users, provisioner, outbox)
+			}
+			tx := mocks.NewTransactorInterface(t)
+			tx.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).Maybe()
+
+			a := NewLDAPAuthenticator(cfg, Dependencies{
+				Users:       users,
+				Provisioner: provisioner,
+				Transactor:  tx,
+				Outbox:      outbox,
+			})
+			got, err := a.Authenticate(context.Background(), org, tt.args.username, tt.args.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LDAPAuthenticator.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, tt.wantKind))
+				return
+			}
+			assert.Equal(t, tt.wantID, got.User.ID)
+			assert.Equal(t, tt.args.username, got.User.Username)
+			assert.Equal(t, org.ID, got.User.OrganizationID)
+			assert.Equal(t, tt.wantEmail, got.User.Email)
+			assert.Equal(t, tt.wantRoles, got.Roles)
+			assert.Equal(t, LDAPBackend, got.Backend)
+		})
+	}
+
+	t.Run("Organization without a base dn", func(t *testing.T) {
+		a := NewLDAPAuthenticator(cfg, Dependencies{})
+		_, err := a.Authenticate(context.Background(), models.Organizations{ID: 2, Slug: "acme"}, "alice", "password")
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Expired context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+		defer cancel()
+		a := NewLDAPAuthenticator(cfg, Dependencies{})
+		_, err := a.Authenticate(ctx, org, "alice", "password")
+		assert.True(t, errors.IsKind(err, errors.BadGateway))
+	})
+
+	t.Run("Directory unavailable", func(t *testing.T) {
+		a := NewLDAPAuthenticator(config.LDAP{URL: "ldap://127.0.0.1:1", Timeout: time.Second}, Dependencies{})
+		_, err := a.Authenticate(context.Background(), org, "alice", "password")
+		assert.True(t, errors.IsKind(err, errors.BadGateway))
+	})
+}
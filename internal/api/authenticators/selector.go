@@ -0,0 +1,88 @@
+package authenticators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// Selector chains the backends enabled for the organization being
+// authenticated against, or the default ones when it has no preference.
+type Selector struct {
+	backends map[string]Authenticator
+	defaults []string
+}
+
+func NewSelector(backends map[string]Authenticator, defaults []string) (*Selector, error) {
+	const op errors.Op = "authenticators.NewSelector"
+
+	s := &Selector{
+		backends: backends,
+		defaults: defaults,
+	}
+
+	if len(defaults) == 0 {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("no authentication backends configured")),
+			errors.WithMessage("No authentication backends configured"),
+		)
+	}
+
+	if _, err := s.chain(defaults); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	return s, nil
+}
+
+func (s *Selector) Authenticate(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	const op errors.Op = "authenticators.Selector.Authenticate"
+
+	names := s.defaults
+	if len(org.Settings.AuthBackends) > 0 {
+		names = org.Settings.AuthBackends
+	}
+
+	chain, err := s.chain(names)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	identity, err := chain.Authenticate(ctx, org, username, password)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	return identity, nil
+}
+
+func (s *Selector) chain(names []string) (*Chain, error) {
+	const op errors.Op = "authenticators.Selector.chain"
+
+	backends := make([]Authenticator, 0, len(names))
+	for _, name := range names {
+		backend, ok := s.backends[name]
+		if !ok {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("unknown authentication backend %q", name)),
+				errors.WithMessage("Unknown authentication backend"),
+			)
+		}
+		backends = append(backends, backend)
+	}
+
+	return NewChain(backends...), nil
+}
@@ -0,0 +1,74 @@
+package authenticators
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Authenticate(t *testing.T) {
+	database := models.Identity{User: models.Users{ID: 1, Username: "alice"}, Backend: DatabaseBackend}
+	directory := models.Identity{User: models.Users{Username: "alice"}, Backend: LDAPBackend}
+
+	newBackends := func(t *testing.T) map[string]Authenticator {
+		db := mocks.NewAuthenticator(t)
+		db.On("Authenticate", mock.Anything, models.Organizations{ID: 1, Slug: "default"}, "alice", "password").Return(database, nil).Maybe()
+
+		dir := mocks.NewAuthenticator(t)
+		dir.On("Authenticate", mock.Anything, models.Organizations{
+			ID:       2,
+			Slug:     "acme",
+			Settings: models.OrganizationSettings{AuthBackends: []string{LDAPBackend}},
+		}, "alice", "password").Return(directory, nil).Maybe()
+
+		return map[string]Authenticator{DatabaseBackend: db, LDAPBackend: dir}
+	}
+
+	t.Run("Organization without settings uses the default backends", func(t *testing.T) {
+		s, err := NewSelector(newBackends(t), []string{DatabaseBackend})
+		assert.NoError(t, err)
+
+		got, err := s.Authenticate(context.Background(), models.Organizations{ID: 1, Slug: "default"}, "alice", "password")
+		assert.NoError(t, err)
+		assert.Equal(t, database, got)
+	})
+
+	t.Run("Organization settings select the backends", func(t *testing.T) {
+		s, err := NewSelector(newBackends(t), []string{DatabaseBackend})
+		assert.NoError(t, err)
+
+		got, err := s.Authenticate(context.Background(), models.Organizations{
+			ID:       2,
+			Slug:     "acme",
+			Settings: models.OrganizationSettings{AuthBackends: []string{LDAPBackend}},
+		}, "alice", "password")
+		assert.NoError(t, err)
+		assert.Equal(t, directory, got)
+	})
+
+	t.Run("Organization with unknown backend", func(t *testing.T) {
+		s, err := NewSelector(newBackends(t), []string{DatabaseBackend})
+		assert.NoError(t, err)
+
+		_, err = s.Authenticate(context.Background(), models.Organizations{
+			ID:       3,
+			Settings: models.OrganizationSettings{AuthBackends: []string{"kerberos"}},
+		}, "alice", "password")
+		assert.True(t, errors.IsKind(err, errors.Unexpected))
+	})
+
+	t.Run("Unknown default backend", func(t *testing.T) {
+		_, err := NewSelector(newBackends(t), []string{"kerberos"})
+		assert.Error(t, err)
+	})
+
+	t.Run("No default backends", func(t *testing.T) {
+		_, err := NewSelector(newBackends(t), nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// authorizeAdmin guards the administration endpoints with the static bearer
+// token configured in api.admin_token. Without a token the admin API is off.
+func (cli *client) authorizeAdmin(c *gin.Context) error {
+	const op errors.Op = "handlers.authorizeAdmin"
+
+	if cli.cfg.API.AdminToken == "" {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("admin token is not configured")),
+			errors.WithMessage("Admin API is disabled"),
+			errors.KindForbidden(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(cli.cfg.API.AdminToken)) != 1 {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("invalid admin token")),
+			errors.WithMessage("Invalid admin token"),
+			errors.KindUnauthorized(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	return nil
+}
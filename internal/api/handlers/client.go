@@ -1,20 +1,55 @@
 package handlers
 
 import (
+	"fmt"
+
 	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
 	"github.com/Pedrommb91/go-auth/internal/api/openapi"
 	"github.com/Pedrommb91/go-auth/internal/api/services"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
 	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/gin-gonic/gin"
 )
 
 type client struct {
 	cfg      *config.Config
 	log      logger.Interface
 	services *Services
+	cursors  pagination.Signer
 }
 
 type Services struct {
-	User services.UserServiceInterface
+	User         services.UserServiceInterface
+	Auth         services.AuthServiceInterface
+	SSO          services.SSOServiceInterface
+	Organization services.OrganizationServiceInterface
+	Invitation   services.InvitationServiceInterface
+	Membership   services.MembershipServiceInterface
+	SCIMToken    services.SCIMTokenServiceInterface
+	Provisioning services.ProvisioningServiceInterface
+	Webhook      services.WebhookServiceInterface
+	Health       *database.HealthChecker
+}
+
+// tenant returns the organization resolved by the tenant middleware, every
+// route is registered behind it so a missing tenant is a wiring bug.
+func tenant(c *gin.Context) (models.Organizations, error) {
+	const op errors.Op = "handlers.tenant"
+
+	org, ok := middlewares.GetTenant(c)
+	if !ok {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("tenant missing from request context")),
+			errors.WithMessage("Tenant could not be resolved"),
+		)
+	}
+
+	return org, nil
 }
 
 func NewClient(cfg *config.Config, l logger.Interface, services *Services) openapi.ServerInterface {
@@ -22,5 +57,6 @@ func NewClient(cfg *config.Config, l logger.Interface, services *Services) opena
 		cfg:      cfg,
 		log:      l,
 		services: services,
+		cursors:  pagination.NewSigner([]byte(cfg.API.CursorSecret)),
 	}
 }
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type healthResponse struct {
+	Status   string                `json:"status"`
+	Database database.PoolStats    `json:"database"`
+	Replicas database.ReplicaStats `json:"replicas"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// Health answers 200 while the primary is reachable and 503 otherwise, the
+// statistics of the connection pool are reported for metrics either way. The
+// status is degraded while some replicas are out of rotation, their reads
+// then go to the others or to the primary.
+func Health(checker *database.HealthChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res := healthResponse{
+			Status:   "ok",
+			Database: checker.Stats(),
+			Replicas: checker.Replicas(),
+		}
+		status := http.StatusOK
+
+		if res.Replicas.Healthy < res.Replicas.Total {
+			res.Status = "degraded"
+			res.Error = fmt.Sprintf("%d of %d replicas are out of rotation", res.Replicas.Total-res.Replicas.Healthy, res.Replicas.Total)
+		}
+		if err := checker.Check(c.Request.Context()); err != nil {
+			res.Status = "unavailable"
+			res.Error = err.Error()
+			status = http.StatusServiceUnavailable
+		}
+
+		c.JSON(status, res)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := gin.Default()
+	r.GET("/health", Health(database.NewHealthChecker(database.NewDB(db), time.Second)))
+
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "Database answers",
+			wantStatus: http.StatusOK,
+			want:       "ok",
+		},
+		{
+			name:       "Database is down",
+			pingErr:    fmt.Errorf("connection refused"),
+			wantStatus: http.StatusServiceUnavailable,
+			want:       "unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectPing().WillReturnError(tt.pingErr)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var res healthResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.want, res.Status)
+			assert.Equal(t, 1, res.Database.OpenConnections)
+		})
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealth_ReplicaOutOfRotation(t *testing.T) {
+	primary, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer replica.Close()
+
+	db := database.NewDB(primary, replica)
+	replicaMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+	db.CheckReplicas(context.Background())
+
+	r := gin.Default()
+	r.GET("/health", Health(database.NewHealthChecker(db, time.Second)))
+
+	mock.ExpectPing()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// the reads fall back to the primary so the service is still up
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res healthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "degraded", res.Status)
+	assert.Equal(t, database.ReplicaStats{Total: 1, Healthy: 0}, res.Replicas)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
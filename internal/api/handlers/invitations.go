@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// CreateInvitationHandler implements openapi.ServerInterface.
+func (cli *client) CreateInvitationHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.CreateInvitationHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body *models.CreateInvitationRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid invitation"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid fields"),
+		))
+		return
+	}
+
+	invitation, err := cli.services.Invitation.Invite(c.Request.Context(), organizationID, body.Email, body.Role)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusCreated, toInvitation(invitation))
+}
+
+// ListInvitationsHandler implements openapi.ServerInterface.
+func (cli *client) ListInvitationsHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.ListInvitationsHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	invitations, err := cli.services.Invitation.GetInvitations(c.Request.Context(), organizationID)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get invitations"),
+		))
+		return
+	}
+
+	response := make([]openapi.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, toInvitation(invitation))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AcceptInvitationHandler implements openapi.ServerInterface.
+func (cli *client) AcceptInvitationHandler(c *gin.Context) {
+	const op errors.Op = "handlers.AcceptInvitationHandler"
+
+	var body *openapi.AcceptInvitationRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid invitation"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	if body.Token == "" {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("invitation token is required")),
+			errors.WithMessage("Invitation token is required"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	var username, password string
+	if body.Username != nil {
+		username = *body.Username
+	}
+	if body.Password != nil {
+		password = *body.Password
+	}
+
+	member, err := cli.services.Invitation.Accept(c.Request.Context(), body.Token, username, password)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, toMembership(member))
+}
+
+func toInvitation(invitation models.Invitations) openapi.Invitation {
+	response := openapi.Invitation{
+		Id:             invitation.ID,
+		OrganizationId: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		ExpiresAt:      invitation.ExpiresAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+	if !invitation.AcceptedAt.IsZero() {
+		response.AcceptedAt = &invitation.AcceptedAt
+	}
+	if invitation.AcceptedUserID != 0 {
+		response.AcceptedUserId = &invitation.AcceptedUserID
+	}
+	return response
+}
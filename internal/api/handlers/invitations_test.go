@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAdminToken = "admin-secret"
+
+func Test_client_CreateInvitationHandler(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	route := "/api/v1/orgs/:organization_id/invitations"
+	path := "/api/v1/orgs/2/invitations"
+
+	invitation := models.Invitations{
+		ID:             7,
+		OrganizationID: 2,
+		Email:          faker.Email(),
+		Role:           "admin",
+		ExpiresAt:      now.Add(time.Hour),
+		CreatedAt:      now,
+	}
+
+	type inviteMockResponse struct {
+		invitation models.Invitations
+		err        error
+	}
+	tests := []struct {
+		name                  string
+		adminToken            string
+		authorization         string
+		requestBody           *openapi.CreateInvitationRequestBody
+		inviteMockResponse    *inviteMockResponse
+		expectedResponse      *openapi.Invitation
+		expectedErrorResponse *openapi.Error
+		expectedCode          int
+	}{
+		{
+			name:          "Success",
+			adminToken:    testAdminToken,
+			authorization: "Bearer " + testAdminToken,
+			requestBody: &openapi.CreateInvitationRequestBody{
+				Email: invitation.Email,
+				Role:  invitation.Role,
+			},
+			inviteMockResponse: &inviteMockResponse{
+				invitation: invitation,
+			},
+			expectedResponse: &openapi.Invitation{
+				Id:             7,
+				OrganizationId: 2,
+				Email:          invitation.Email,
+				Role:           "admin",
+				ExpiresAt:      invitation.ExpiresAt,
+				CreatedAt:      now,
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Admin API disabled",
+			authorization: "Bearer " + testAdminToken,
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Forbidden",
+				Id:        dummyID,
+				Message:   "Admin API is disabled",
+				Path:      route,
+				Status:    http.StatusForbidden,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:          "Invalid admin token",
+			adminToken:    testAdminToken,
+			authorization: "Bearer other",
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Unauthorized",
+				Id:        dummyID,
+				Message:   "Invalid admin token",
+				Path:      route,
+				Status:    http.StatusUnauthorized,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "Invalid email",
+			adminToken:    testAdminToken,
+			authorization: "Bearer " + testAdminToken,
+			requestBody: &openapi.CreateInvitationRequestBody{
+				Email: "invalid",
+				Role:  "admin",
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Bad Request",
+				Id:        dummyID,
+				Message:   "Invalid email",
+				Path:      route,
+				Status:    http.StatusBadRequest,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:          "Organization not found",
+			adminToken:    testAdminToken,
+			authorization: "Bearer " + testAdminToken,
+			requestBody: &openapi.CreateInvitationRequestBody{
+				Email: invitation.Email,
+				Role:  invitation.Role,
+			},
+			inviteMockResponse: &inviteMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("no rows")),
+					errors.WithMessage("Organization not found"),
+					errors.KindNotFound(),
+				),
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Not Found",
+				Id:        dummyID,
+				Message:   "Organization not found",
+				Path:      route,
+				Status:    http.StatusNotFound,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.Default()
+
+			invitationServiceMock := mocks.NewInvitationServiceInterface(t)
+			if tt.inviteMockResponse != nil {
+				invitationServiceMock.On("Invite", mock.Anything, int32(2), tt.requestBody.Email, tt.requestBody.Role).
+					Return(tt.inviteMockResponse.invitation, tt.inviteMockResponse.err)
+			}
+
+			services := &Services{
+				Invitation: invitationServiceMock,
+			}
+
+			clockMock := mocks.NewClock(t)
+			clockMock.On("Now").Return(now).Maybe()
+
+			l := logger.New("info")
+			r.Use(middlewares.ErrorHandler(clockMock, l))
+
+			cfg := &config.Config{API: config.API{AdminToken: tt.adminToken}}
+			openapi.RegisterHandlersWithOptions(r, NewClient(cfg, l, services), openapi.GinServerOptions{
+				BaseURL: "/api/v1",
+			})
+
+			w := httptest.NewRecorder()
+			data, err := json.Marshal(tt.requestBody)
+			if err != nil {
+				t.Errorf("Failed to marshal request body")
+			}
+			req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+			req.Header.Set("Authorization", tt.authorization)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.expectedCode != http.StatusCreated {
+				var got *openapi.Error
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Errorf("Failed to unmarshal body: %s", err)
+				}
+				assert.Equal(t, tt.expectedErrorResponse, got)
+				return
+			}
+
+			var got *openapi.Invitation
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Errorf("Failed to unmarshal body: %s", err)
+			}
+			assert.Equal(t, tt.expectedResponse, got)
+		})
+	}
+}
+
+func Test_client_AcceptInvitationHandler(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	path := "/api/v1/invitations/accept"
+
+	member := models.Memberships{
+		OrganizationID: 2,
+		UserID:         3,
+		Username:       faker.Username(),
+		Email:          faker.Email(),
+		Roles:          []string{"admin"},
+	}
+	username := member.Username
+	password := "#sdjU1kaL!"
+
+	type acceptMockResponse struct {
+		member models.Memberships
+		err    error
+	}
+	tests := []struct {
+		name                  string
+		requestBody           *openapi.AcceptInvitationRequestBody
+		acceptMockResponse    *acceptMockResponse
+		expectedResponse      *openapi.Membership
+		expectedErrorResponse *openapi.Error
+		expectedCode          int
+	}{
+		{
+			name: "Success",
+			requestBody: &openapi.AcceptInvitationRequestBody{
+				Token:    "token",
+				Username: &username,
+				Password: &password,
+			},
+			acceptMockResponse: &acceptMockResponse{
+				member: member,
+			},
+			expectedResponse: &openapi.Membership{
+				UserId:         3,
+				OrganizationId: 2,
+				Username:       member.Username,
+				Email:          member.Email,
+				Roles:          []string{"admin"},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "Missing token",
+			requestBody: &openapi.AcceptInvitationRequestBody{},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Bad Request",
+				Id:        dummyID,
+				Message:   "Invitation token is required",
+				Path:      path,
+				Status:    http.StatusBadRequest,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Already used",
+			requestBody: &openapi.AcceptInvitationRequestBody{
+				Token: "token",
+			},
+			acceptMockResponse: &acceptMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("invitation accepted")),
+					errors.WithMessage("Invitation was already used"),
+					errors.KindConflict(),
+				),
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Conflict",
+				Id:        dummyID,
+				Message:   "Invitation was already used",
+				Path:      path,
+				Status:    http.StatusConflict,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.Default()
+
+			invitationServiceMock := mocks.NewInvitationServiceInterface(t)
+			if tt.acceptMockResponse != nil {
+				var username, password string
+				if tt.requestBody.Username != nil {
+					username = *tt.requestBody.Username
+				}
+				if tt.requestBody.Password != nil {
+					password = *tt.requestBody.Password
+				}
+				invitationServiceMock.On("Accept", mock.Anything, tt.requestBody.Token, username, password).
+					Return(tt.acceptMockResponse.member, tt.acceptMockResponse.err)
+			}
+
+			services := &Services{
+				Invitation: invitationServiceMock,
+			}
+
+			clockMock := mocks.NewClock(t)
+			clockMock.On("Now").Return(now).Maybe()
+
+			l := logger.New("info")
+			r.Use(middlewares.ErrorHandler(clockMock, l))
+
+			g := NewClient(&config.Config{}, l, services)
+			r.POST(path, func(c *gin.Context) {
+				g.AcceptInvitationHandler(c)
+			})
+
+			w := httptest.NewRecorder()
+			data, err := json.Marshal(tt.requestBody)
+			if err != nil {
+				t.Errorf("Failed to marshal request body")
+			}
+			req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.expectedCode != http.StatusOK {
+				var got *openapi.Error
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Errorf("Failed to unmarshal body: %s", err)
+				}
+				assert.Equal(t, tt.expectedErrorResponse, got)
+				return
+			}
+
+			var got *openapi.Membership
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Errorf("Failed to unmarshal body: %s", err)
+			}
+			assert.Equal(t, tt.expectedResponse, got)
+		})
+	}
+}
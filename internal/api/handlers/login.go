@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// LoginHandler implements openapi.ServerInterface.
+func (cli *client) LoginHandler(c *gin.Context) {
+	const op errors.Op = "handlers.LoginHandler"
+
+	var body *openapi.LoginRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid login request"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	if body.Username == "" || body.Password == "" {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("username and password are required")),
+			errors.WithMessage("Username and password are required"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	org, err := tenant(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	identity, err := cli.services.Auth.Login(c.Request.Context(), org, body.Username, body.Password)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to login"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, &openapi.LoginResponse{
+		Id:             int64(identity.User.ID),
+		OrganizationId: org.ID,
+		Username:       identity.User.Username,
+		Email:          identity.User.Email,
+		Roles:          identity.Roles,
+	})
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_client_LoginHandler(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	path := "/api/v1/login"
+
+	identity := models.Identity{
+		User: models.Users{
+			ID:       1,
+			Username: faker.Username(),
+			Email:    faker.Email(),
+		},
+		Roles: []string{"admin"},
+	}
+
+	type loginMockResponse struct {
+		identity models.Identity
+		err      error
+	}
+	type args struct {
+		requestBody *openapi.LoginRequestBody
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		loginMockResponse     loginMockResponse
+		expectedResponse      *openapi.LoginResponse
+		expectedErrorResponse *openapi.Error
+		expectedCode          int
+	}{
+		{
+			name: "Success",
+			args: args{
+				requestBody: &openapi.LoginRequestBody{
+					Username: identity.User.Username,
+					Password: "#sdjU1kaL!",
+				},
+			},
+			loginMockResponse: loginMockResponse{
+				identity: identity,
+			},
+			expectedResponse: &openapi.LoginResponse{
+				Id:             1,
+				OrganizationId: testOrganization.ID,
+				Username:       identity.User.Username,
+				Email:          identity.User.Email,
+				Roles:          []string{"admin"},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Missing password",
+			args: args{
+				requestBody: &openapi.LoginRequestBody{
+					Username: identity.User.Username,
+				},
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Bad Request",
+				Id:        dummyID,
+				Message:   "Username and password are required",
+				Path:      path,
+				Status:    http.StatusBadRequest,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Empty request body",
+			args: args{
+				requestBody: nil,
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Bad Request",
+				Id:        dummyID,
+				Message:   "Invalid login request",
+				Path:      path,
+				Status:    http.StatusBadRequest,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid credentials",
+			args: args{
+				requestBody: &openapi.LoginRequestBody{
+					Username: identity.User.Username,
+					Password: "#sdjU1kaL!",
+				},
+			},
+			loginMockResponse: loginMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("invalid credentials")),
+					errors.WithMessage("Invalid username or password"),
+					errors.KindUnauthorized(),
+				),
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Unauthorized",
+				Id:        dummyID,
+				Message:   "Invalid username or password",
+				Path:      path,
+				Status:    http.StatusUnauthorized,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.Default()
+
+			authServiceMock := mocks.NewAuthServiceInterface(t)
+			if tt.args.requestBody != nil {
+				authServiceMock.On(
+					"Login",
+					mock.Anything,
+					testOrganization,
+					tt.args.requestBody.Username,
+					tt.args.requestBody.Password).
+					Return(tt.loginMockResponse.identity, tt.loginMockResponse.err).Maybe()
+			}
+
+			services := &Services{
+				Auth: authServiceMock,
+			}
+
+			clockMock := mocks.NewClock(t)
+			clockMock.On("Now").Return(now).Maybe()
+
+			l := logger.New("info")
+			r.Use(middlewares.ErrorHandler(clockMock, l))
+			r.Use(func(c *gin.Context) {
+				middlewares.SetTenant(c, testOrganization)
+			})
+
+			g := NewClient(&config.Config{}, l, services)
+			r.POST(path, func(c *gin.Context) {
+				g.LoginHandler(c)
+			})
+
+			w := httptest.NewRecorder()
+			var req *http.Request
+			if tt.args.requestBody != nil {
+				data, err := json.Marshal(tt.args.requestBody)
+				if err != nil {
+					t.Errorf("Failed to marshal request body")
+				}
+				req, _ = http.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+			} else {
+				req, _ = http.NewRequest(http.MethodPost, path, nil)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.expectedCode != http.StatusOK {
+				var got *openapi.Error
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Errorf("Failed to unmarshal body: %s", err)
+				}
+				assert.Equal(t, tt.expectedErrorResponse, got)
+				return
+			}
+
+			var got *openapi.LoginResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Errorf("Failed to unmarshal body: %s", err)
+			}
+			assert.Equal(t, tt.expectedResponse, got)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// ListMembersHandler implements openapi.ServerInterface.
+func (cli *client) ListMembersHandler(c *gin.Context, organizationID openapi.OrganizationID, params openapi.ListMembersHandlerParams) {
+	const op errors.Op = "handlers.ListMembersHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	cursor, size, err := cli.pageParams(organizationID, params.Cursor, params.PageSize)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		))
+		return
+	}
+
+	page, err := cli.services.Membership.GetMembers(c.Request.Context(), organizationID, cursor, size)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get members"),
+		))
+		return
+	}
+
+	response := openapi.MembershipPage{
+		Members:    make([]openapi.Membership, 0, len(page.Items)),
+		NextCursor: cli.signCursor(organizationID, page.Next),
+		PrevCursor: cli.signCursor(organizationID, page.Prev),
+	}
+	for _, member := range page.Items {
+		response.Members = append(response.Members, toMembership(member))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateMemberHandler implements openapi.ServerInterface.
+func (cli *client) UpdateMemberHandler(c *gin.Context, organizationID openapi.OrganizationID, userID openapi.UserID) {
+	const op errors.Op = "handlers.UpdateMemberHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body *models.UpdateMemberRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid member"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid fields"),
+		))
+		return
+	}
+
+	member, err := cli.services.Membership.UpdateMemberRole(c.Request.Context(), organizationID, userID, body.Role)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, toMembership(member))
+}
+
+// RemoveMemberHandler implements openapi.ServerInterface.
+func (cli *client) RemoveMemberHandler(c *gin.Context, organizationID openapi.OrganizationID, userID openapi.UserID) {
+	const op errors.Op = "handlers.RemoveMemberHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := cli.services.Membership.RemoveMember(c.Request.Context(), organizationID, userID); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to remove member"),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toMembership(member models.Memberships) openapi.Membership {
+	return openapi.Membership{
+		UserId:         member.UserID,
+		OrganizationId: member.OrganizationID,
+		Username:       member.Username,
+		Email:          member.Email,
+		Roles:          member.Roles,
+	}
+}
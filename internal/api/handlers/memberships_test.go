@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_client_MembershipHandlers(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	member := models.Memberships{
+		OrganizationID: 2,
+		UserID:         3,
+		Username:       faker.Username(),
+		Email:          faker.Email(),
+		Roles:          []string{"viewer"},
+	}
+	expectedMember := openapi.Membership{
+		UserId:         3,
+		OrganizationId: 2,
+		Username:       member.Username,
+		Email:          member.Email,
+		Roles:          []string{"viewer"},
+	}
+
+	membershipServiceMock := mocks.NewMembershipServiceInterface(t)
+	membershipServiceMock.On("GetMembers", mock.Anything, int32(2), pagination.Cursor{}, 50).Return(pagination.Page[models.Memberships]{
+		Items: []models.Memberships{member},
+		Next:  &pagination.Cursor{ID: 3},
+	}, nil)
+	membershipServiceMock.On("GetMembers", mock.Anything, int32(2), pagination.Cursor{ID: 3}, 1).Return(pagination.Page[models.Memberships]{
+		Items: []models.Memberships{},
+		Prev:  &pagination.Cursor{ID: 4, Backward: true},
+	}, nil)
+	membershipServiceMock.On("UpdateMemberRole", mock.Anything, int32(2), int32(3), "viewer").Return(member, nil)
+	membershipServiceMock.On("RemoveMember", mock.Anything, int32(2), int32(3)).Return(nil)
+	membershipServiceMock.On("RemoveMember", mock.Anything, int32(2), int32(4)).Return(errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.WithMessage("Member not found"),
+		errors.KindNotFound(),
+	))
+
+	clockMock := mocks.NewClock(t)
+	clockMock.On("Now").Return(now).Maybe()
+
+	l := logger.New("info")
+	r := gin.Default()
+	r.Use(middlewares.ErrorHandler(clockMock, l))
+
+	cfg := &config.Config{API: config.API{AdminToken: testAdminToken, CursorSecret: "secret"}}
+	cursors := pagination.NewSigner([]byte("secret"))
+	openapi.RegisterHandlersWithOptions(r, NewClient(cfg, l, &Services{Membership: membershipServiceMock}), openapi.GinServerOptions{
+		BaseURL: "/api/v1",
+	})
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("List members", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/members", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var got openapi.MembershipPage
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, []openapi.Membership{expectedMember}, got.Members)
+		assert.Nil(t, got.PrevCursor)
+		if assert.NotNil(t, got.NextCursor) {
+			cursor, err := cursors.Parse(*got.NextCursor, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, pagination.Cursor{ID: 3}, cursor)
+		}
+	})
+
+	t.Run("List members after a cursor", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/members?page_size=1&cursor="+cursors.Sign(pagination.Cursor{ID: 3}, 2), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var got openapi.MembershipPage
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Empty(t, got.Members)
+		assert.Nil(t, got.NextCursor)
+		if assert.NotNil(t, got.PrevCursor) {
+			cursor, err := cursors.Parse(*got.PrevCursor, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, pagination.Cursor{ID: 4, Backward: true}, cursor)
+		}
+	})
+
+	t.Run("List members with a forged cursor", func(t *testing.T) {
+		forged := pagination.NewSigner([]byte("other")).Sign(pagination.Cursor{ID: 3}, 2)
+		w := do(http.MethodGet, "/api/v1/orgs/2/members?cursor="+forged, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List members with the cursor of another organization", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/members?cursor="+cursors.Sign(pagination.Cursor{ID: 3}, 5), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List members with an invalid page size", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/members?page_size=101", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Update member role", func(t *testing.T) {
+		w := do(http.MethodPatch, "/api/v1/orgs/2/members/3", &openapi.UpdateMemberRequestBody{Role: "viewer"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var got openapi.Membership
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, expectedMember, got)
+	})
+
+	t.Run("Update member with empty role", func(t *testing.T) {
+		w := do(http.MethodPatch, "/api/v1/orgs/2/members/3", &openapi.UpdateMemberRequestBody{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Remove member", func(t *testing.T) {
+		w := do(http.MethodDelete, "/api/v1/orgs/2/members/3", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Remove unknown member", func(t *testing.T) {
+		w := do(http.MethodDelete, "/api/v1/orgs/2/members/4", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var got *openapi.Error
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, &openapi.Error{
+			Error:     "Not Found",
+			Id:        dummyID,
+			Message:   "Member not found",
+			Path:      "/api/v1/orgs/:organization_id/members/:user_id",
+			Status:    http.StatusNotFound,
+			Timestamp: now,
+		}, got)
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// pageParams decodes the cursor and page size query parameters of a keyset
+// paginated listing of the organization, a missing cursor starts at the
+// first page.
+func (cli *client) pageParams(organizationID int32, token *openapi.Cursor, size *openapi.PageSize) (pagination.Cursor, int, error) {
+	const op errors.Op = "handlers.pageParams"
+
+	pageSize := defaultPageSize
+	if size != nil {
+		pageSize = int(*size)
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		return pagination.Cursor{}, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("page size %d out of range", pageSize)),
+			errors.WithMessage(fmt.Sprintf("Page size must be between 1 and %d", maxPageSize)),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	if token == nil || *token == "" {
+		return pagination.Cursor{}, pageSize, nil
+	}
+
+	cursor, err := cli.cursors.Parse(*token, organizationID)
+	if err != nil {
+		return pagination.Cursor{}, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+
+	return cursor, pageSize, nil
+}
+
+// signCursor returns the token of the cursor of a listing of the
+// organization, or nil at the end of the listing.
+func (cli *client) signCursor(organizationID int32, cursor *pagination.Cursor) *string {
+	if cursor == nil {
+		return nil
+	}
+	token := cli.cursors.Sign(*cursor, organizationID)
+	return &token
+}
@@ -35,7 +35,13 @@ func (cli *client) RegisterUserHandler(c *gin.Context) {
 		return
 	}
 
-	id, err := cli.services.User.AddUser(user.Username, user.Email, user.Password)
+	org, err := tenant(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, err := cli.services.User.AddUser(c.Request.Context(), org.ID, user.Username, user.Email, user.Password)
 	if err != nil {
 		c.Error(errors.Build(
 			errors.WithOp(op),
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -159,6 +160,8 @@ func Test_client_RegisterUserHandler(t *testing.T) {
 			if tt.args.requestBody != nil {
 				userServiceMock.On(
 					"AddUser",
+					mock.Anything,
+					testOrganization.ID,
 					tt.args.requestBody.Username,
 					tt.args.requestBody.Email,
 					tt.args.requestBody.Password).
@@ -173,6 +176,9 @@ func Test_client_RegisterUserHandler(t *testing.T) {
 			clockMock.On("Now").Return(now).Maybe()
 
 			r.Use(middlewares.ErrorHandler(clockMock, tt.fields.log))
+			r.Use(func(c *gin.Context) {
+				middlewares.SetTenant(c, testOrganization)
+			})
 
 			g := NewClient(tt.fields.cfg, tt.fields.log, services)
 			r.POST(path, func(c *gin.Context) {
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const samlMetadataContentType = "application/samlmetadata+xml"
+
+// SAMLMetadataHandler implements openapi.ServerInterface.
+func (cli *client) SAMLMetadataHandler(c *gin.Context, _ openapi.Tenant) {
+	const op errors.Op = "handlers.SAMLMetadataHandler"
+
+	// the tenant path parameter is resolved by the tenant middleware
+	org, err := tenant(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	metadata, err := cli.services.SSO.Metadata(org)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SAML metadata"),
+		))
+		return
+	}
+
+	c.Data(http.StatusOK, samlMetadataContentType, metadata)
+}
+
+// SAMLLoginHandler implements openapi.ServerInterface.
+func (cli *client) SAMLLoginHandler(c *gin.Context, _ openapi.Tenant) {
+	const op errors.Op = "handlers.SAMLLoginHandler"
+
+	org, err := tenant(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	redirect, err := cli.services.SSO.LoginURL(org)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to start SAML login"),
+		))
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirect)
+}
+
+// SAMLAssertionConsumerHandler implements openapi.ServerInterface.
+func (cli *client) SAMLAssertionConsumerHandler(c *gin.Context, _ openapi.Tenant) {
+	const op errors.Op = "handlers.SAMLAssertionConsumerHandler"
+
+	var body openapi.SAMLResponseBody
+	if err := c.ShouldBind(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid SAML response"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	if body.SAMLResponse == "" {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("SAMLResponse is required")),
+			errors.WithMessage("Invalid SAML response"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	org, err := tenant(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	relayState := ""
+	if body.RelayState != nil {
+		relayState = *body.RelayState
+	}
+
+	identity, err := cli.services.SSO.ConsumeAssertion(c.Request.Context(), org, body.SAMLResponse, relayState)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to login with SAML"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, &openapi.LoginResponse{
+		Id:             int64(identity.User.ID),
+		OrganizationId: org.ID,
+		Username:       identity.User.Username,
+		Email:          identity.User.Email,
+		Roles:          identity.Roles,
+	})
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testOrganization = models.Organizations{ID: 1, Slug: "acme"}
+
+func newSAMLTestRouter(t *testing.T, ssoServiceMock *mocks.SSOServiceInterface, now time.Time) *gin.Engine {
+	r := gin.Default()
+
+	clockMock := mocks.NewClock(t)
+	clockMock.On("Now").Return(now).Maybe()
+
+	l := logger.New("info")
+	r.Use(middlewares.ErrorHandler(clockMock, l))
+	r.Use(func(c *gin.Context) {
+		middlewares.SetTenant(c, testOrganization)
+	})
+
+	g := NewClient(&config.Config{}, l, &Services{SSO: ssoServiceMock})
+	r.GET("/api/v1/saml/:tenant/metadata", func(c *gin.Context) {
+		g.SAMLMetadataHandler(c, c.Param("tenant"))
+	})
+	r.GET("/api/v1/saml/:tenant/login", func(c *gin.Context) {
+		g.SAMLLoginHandler(c, c.Param("tenant"))
+	})
+	r.POST("/api/v1/saml/:tenant/acs", func(c *gin.Context) {
+		g.SAMLAssertionConsumerHandler(c, c.Param("tenant"))
+	})
+
+	return r
+}
+
+func notConfiguredError() error {
+	return errors.Build(
+		errors.WithError(fmt.Errorf("no SAML provider configured")),
+		errors.WithMessage("SAML is not configured for this tenant"),
+		errors.KindNotFound(),
+	)
+}
+
+func Test_client_SAMLMetadataHandler(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	t.Run("Success", func(t *testing.T) {
+		ssoServiceMock := mocks.NewSSOServiceInterface(t)
+		ssoServiceMock.On("Metadata", testOrganization).Return([]byte("<EntityDescriptor/>"), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/saml/acme/metadata", nil)
+		newSAMLTestRouter(t, ssoServiceMock, now).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, samlMetadataContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "<EntityDescriptor/>", w.Body.String())
+	})
+
+	t.Run("Tenant without SAML", func(t *testing.T) {
+		ssoServiceMock := mocks.NewSSOServiceInterface(t)
+		ssoServiceMock.On("Metadata", testOrganization).Return(nil, notConfiguredError())
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/saml/other/metadata", nil)
+		newSAMLTestRouter(t, ssoServiceMock, now).ServeHTTP(w, req)
+
+		var got *openapi.Error
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, &openapi.Error{
+			Error:     "Not Found",
+			Id:        dummyID,
+			Message:   "SAML is not configured for this tenant",
+			Path:      "/api/v1/saml/:tenant/metadata",
+			Status:    http.StatusNotFound,
+			Timestamp: now,
+		}, got)
+	})
+}
+
+func Test_client_SAMLLoginHandler(t *testing.T) {
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	ssoServiceMock := mocks.NewSSOServiceInterface(t)
+	ssoServiceMock.On("LoginURL", testOrganization).Return("https://idp.example.com/sso?SAMLRequest=abc", nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/saml/acme/login", nil)
+	newSAMLTestRouter(t, ssoServiceMock, now).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://idp.example.com/sso?SAMLRequest=abc", w.Header().Get("Location"))
+}
+
+func Test_client_SAMLAssertionConsumerHandler(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	path := "/api/v1/saml/:tenant/acs"
+
+	identity := models.Identity{
+		User: models.Users{
+			ID:       7,
+			Username: faker.Username(),
+			Email:    faker.Email(),
+		},
+		Roles:   []string{"admin"},
+		Backend: "saml",
+	}
+
+	type consumeMockResponse struct {
+		identity models.Identity
+		err      error
+	}
+	tests := []struct {
+		name                  string
+		form                  url.Values
+		consumeMockResponse   *consumeMockResponse
+		expectedResponse      *openapi.LoginResponse
+		expectedErrorResponse *openapi.Error
+		expectedCode          int
+	}{
+		{
+			name: "Success",
+			form: url.Values{"SAMLResponse": {"response"}, "RelayState": {"state"}},
+			consumeMockResponse: &consumeMockResponse{
+				identity: identity,
+			},
+			expectedResponse: &openapi.LoginResponse{
+				Id:             7,
+				OrganizationId: testOrganization.ID,
+				Username:       identity.User.Username,
+				Email:          identity.User.Email,
+				Roles:          []string{"admin"},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Missing SAML response",
+			form: url.Values{"RelayState": {"state"}},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Bad Request",
+				Id:        dummyID,
+				Message:   "Invalid SAML response",
+				Path:      path,
+				Status:    http.StatusBadRequest,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid assertion",
+			form: url.Values{"SAMLResponse": {"response"}, "RelayState": {"state"}},
+			consumeMockResponse: &consumeMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("signature did not match")),
+					errors.WithMessage("Invalid SAML assertion"),
+					errors.KindUnauthorized(),
+				),
+			},
+			expectedErrorResponse: &openapi.Error{
+				Error:     "Unauthorized",
+				Id:        dummyID,
+				Message:   "Invalid SAML assertion",
+				Path:      path,
+				Status:    http.StatusUnauthorized,
+				Timestamp: now,
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssoServiceMock := mocks.NewSSOServiceInterface(t)
+			if tt.consumeMockResponse != nil {
+				ssoServiceMock.On("ConsumeAssertion", mock.Anything, testOrganization, tt.form.Get("SAMLResponse"), tt.form.Get("RelayState")).
+					Return(tt.consumeMockResponse.identity, tt.consumeMockResponse.err)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/saml/acme/acs", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			newSAMLTestRouter(t, ssoServiceMock, now).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.expectedCode != http.StatusOK {
+				var got *openapi.Error
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Errorf("Failed to unmarshal body: %s", err)
+				}
+				assert.Equal(t, tt.expectedErrorResponse, got)
+				return
+			}
+
+			var got *openapi.LoginResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Errorf("Failed to unmarshal body: %s", err)
+			}
+			assert.Equal(t, tt.expectedResponse, got)
+		})
+	}
+}
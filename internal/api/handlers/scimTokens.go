@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// CreateSCIMTokenHandler implements openapi.ServerInterface.
+func (cli *client) CreateSCIMTokenHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.CreateSCIMTokenHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body *openapi.CreateSCIMTokenRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid SCIM token"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	var description string
+	if body.Description != nil {
+		description = *body.Description
+	}
+	if len(description) > 255 {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("the length of the description should be less than 256")),
+			errors.WithMessage("The size of the description must be less than 256"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		))
+		return
+	}
+
+	token, secret, err := cli.services.SCIMToken.CreateToken(c.Request.Context(), organizationID, description)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SCIM token"),
+		))
+		return
+	}
+
+	response := toSCIMToken(token)
+	c.JSON(http.StatusCreated, openapi.SCIMTokenSecret{
+		Id:             response.Id,
+		OrganizationId: response.OrganizationId,
+		Description:    response.Description,
+		LastUsedAt:     response.LastUsedAt,
+		CreatedAt:      response.CreatedAt,
+		Token:          secret,
+	})
+}
+
+// ListSCIMTokensHandler implements openapi.ServerInterface.
+func (cli *client) ListSCIMTokensHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.ListSCIMTokensHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	tokens, err := cli.services.SCIMToken.GetTokens(c.Request.Context(), organizationID)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SCIM tokens"),
+		))
+		return
+	}
+
+	response := make([]openapi.SCIMToken, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, toSCIMToken(token))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeSCIMTokenHandler implements openapi.ServerInterface.
+func (cli *client) RevokeSCIMTokenHandler(c *gin.Context, organizationID openapi.OrganizationID, tokenID openapi.TokenID) {
+	const op errors.Op = "handlers.RevokeSCIMTokenHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := cli.services.SCIMToken.RevokeToken(c.Request.Context(), organizationID, tokenID); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to revoke SCIM token"),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toSCIMToken(token models.SCIMTokens) openapi.SCIMToken {
+	response := openapi.SCIMToken{
+		Id:             token.ID,
+		OrganizationId: token.OrganizationID,
+		Description:    token.Description,
+		CreatedAt:      token.CreatedAt,
+	}
+	if !token.LastUsedAt.IsZero() {
+		response.LastUsedAt = &token.LastUsedAt
+	}
+	return response
+}
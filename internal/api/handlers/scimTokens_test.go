@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_client_SCIMTokenHandlers(t *testing.T) {
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	token := models.SCIMTokens{
+		ID:             5,
+		OrganizationID: 2,
+		Description:    "okta",
+		CreatedAt:      now,
+	}
+	description := "okta"
+
+	scimTokenServiceMock := mocks.NewSCIMTokenServiceInterface(t)
+	scimTokenServiceMock.On("CreateToken", mock.Anything, int32(2), "okta").Return(token, "secret", nil)
+	scimTokenServiceMock.On("GetTokens", mock.Anything, int32(2)).Return([]models.SCIMTokens{token}, nil)
+	scimTokenServiceMock.On("RevokeToken", mock.Anything, int32(2), int32(5)).Return(nil)
+	scimTokenServiceMock.On("RevokeToken", mock.Anything, int32(2), int32(6)).Return(errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.WithMessage("SCIM token not found"),
+		errors.KindNotFound(),
+	))
+
+	clockMock := mocks.NewClock(t)
+	clockMock.On("Now").Return(now).Maybe()
+
+	l := logger.New("info")
+	r := gin.Default()
+	r.Use(middlewares.ErrorHandler(clockMock, l))
+
+	cfg := &config.Config{API: config.API{AdminToken: testAdminToken}}
+	openapi.RegisterHandlersWithOptions(r, NewClient(cfg, l, &Services{SCIMToken: scimTokenServiceMock}), openapi.GinServerOptions{
+		BaseURL: "/api/v1",
+	})
+
+	do := func(method, path, adminToken string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Create token", func(t *testing.T) {
+		w := do(http.MethodPost, "/api/v1/orgs/2/scim/tokens", testAdminToken, &openapi.CreateSCIMTokenRequestBody{Description: &description})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var got openapi.SCIMTokenSecret
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, openapi.SCIMTokenSecret{
+			Id:             5,
+			OrganizationId: 2,
+			Description:    "okta",
+			CreatedAt:      now,
+			Token:          "secret",
+		}, got)
+	})
+
+	t.Run("Create token without admin token", func(t *testing.T) {
+		w := do(http.MethodPost, "/api/v1/orgs/2/scim/tokens", "wrong", &openapi.CreateSCIMTokenRequestBody{Description: &description})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("List tokens does not expose secrets", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/scim/tokens", testAdminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+
+		var got []openapi.SCIMToken
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, []openapi.SCIMToken{{
+			Id:             5,
+			OrganizationId: 2,
+			Description:    "okta",
+			CreatedAt:      now,
+		}}, got)
+	})
+
+	t.Run("Revoke token", func(t *testing.T) {
+		w := do(http.MethodDelete, "/api/v1/orgs/2/scim/tokens/5", testAdminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Revoke unknown token", func(t *testing.T) {
+		w := do(http.MethodDelete, "/api/v1/orgs/2/scim/tokens/6", testAdminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
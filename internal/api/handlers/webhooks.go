@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// CreateWebhookHandler implements openapi.ServerInterface.
+func (cli *client) CreateWebhookHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.CreateWebhookHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body *openapi.CreateWebhookRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil || body == nil {
+		c.Error(invalidWebhookBody(op, err))
+		return
+	}
+
+	webhook := models.Webhooks{
+		OrganizationID: organizationID,
+		URL:            body.Url,
+		EventTypes:     body.EventTypes,
+		Active:         true,
+	}
+	if body.Secret != nil {
+		webhook.Secret = *body.Secret
+	}
+	if body.Active != nil {
+		webhook.Active = *body.Active
+	}
+
+	webhook, err := cli.services.Webhook.CreateWebhook(c.Request.Context(), webhook)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create webhook"),
+		))
+		return
+	}
+
+	response := toWebhook(webhook)
+	c.JSON(http.StatusCreated, openapi.WebhookSecret{
+		Id:             response.Id,
+		OrganizationId: response.OrganizationId,
+		Url:            response.Url,
+		EventTypes:     response.EventTypes,
+		Active:         response.Active,
+		CreatedAt:      response.CreatedAt,
+		Secret:         webhook.Secret,
+	})
+}
+
+// ListWebhooksHandler implements openapi.ServerInterface.
+func (cli *client) ListWebhooksHandler(c *gin.Context, organizationID openapi.OrganizationID) {
+	const op errors.Op = "handlers.ListWebhooksHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhooks, err := cli.services.Webhook.GetWebhooks(c.Request.Context(), organizationID)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhooks"),
+		))
+		return
+	}
+
+	response := make([]openapi.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, toWebhook(webhook))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateWebhookHandler implements openapi.ServerInterface.
+func (cli *client) UpdateWebhookHandler(c *gin.Context, organizationID openapi.OrganizationID, webhookID openapi.WebhookID) {
+	const op errors.Op = "handlers.UpdateWebhookHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body *openapi.UpdateWebhookRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil || body == nil {
+		c.Error(invalidWebhookBody(op, err))
+		return
+	}
+
+	webhook := models.Webhooks{
+		ID:             webhookID,
+		OrganizationID: organizationID,
+		URL:            body.Url,
+		EventTypes:     body.EventTypes,
+		Active:         body.Active,
+	}
+	if body.Secret != nil {
+		webhook.Secret = *body.Secret
+	}
+
+	webhook, err := cli.services.Webhook.UpdateWebhook(c.Request.Context(), webhook)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update webhook"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhook(webhook))
+}
+
+// DeleteWebhookHandler implements openapi.ServerInterface.
+func (cli *client) DeleteWebhookHandler(c *gin.Context, organizationID openapi.OrganizationID, webhookID openapi.WebhookID) {
+	const op errors.Op = "handlers.DeleteWebhookHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := cli.services.Webhook.DeleteWebhook(c.Request.Context(), organizationID, webhookID); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete webhook"),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveriesHandler implements openapi.ServerInterface.
+func (cli *client) ListWebhookDeliveriesHandler(c *gin.Context, organizationID openapi.OrganizationID, webhookID openapi.WebhookID, params openapi.ListWebhookDeliveriesHandlerParams) {
+	const op errors.Op = "handlers.ListWebhookDeliveriesHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	cursor, size, err := cli.pageParams(organizationID, params.Cursor, params.PageSize)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		))
+		return
+	}
+
+	page, err := cli.services.Webhook.GetDeliveries(c.Request.Context(), organizationID, webhookID, cursor, size)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook deliveries"),
+		))
+		return
+	}
+
+	response := openapi.WebhookDeliveryPage{
+		Deliveries: make([]openapi.WebhookDelivery, 0, len(page.Items)),
+		NextCursor: cli.signCursor(organizationID, page.Next),
+		PrevCursor: cli.signCursor(organizationID, page.Prev),
+	}
+	for _, delivery := range page.Items {
+		response.Deliveries = append(response.Deliveries, toWebhookDelivery(delivery))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RedeliverWebhookDeliveryHandler implements openapi.ServerInterface.
+func (cli *client) RedeliverWebhookDeliveryHandler(c *gin.Context, organizationID openapi.OrganizationID, webhookID openapi.WebhookID, deliveryID openapi.DeliveryID) {
+	const op errors.Op = "handlers.RedeliverWebhookDeliveryHandler"
+
+	if err := cli.authorizeAdmin(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := cli.services.Webhook.Redeliver(c.Request.Context(), organizationID, webhookID, deliveryID)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to redeliver webhook delivery"),
+		))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toWebhookDelivery(delivery))
+}
+
+func invalidWebhookBody(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Invalid webhook"),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func toWebhook(webhook models.Webhooks) openapi.Webhook {
+	return openapi.Webhook{
+		Id:             webhook.ID,
+		OrganizationId: webhook.OrganizationID,
+		Url:            webhook.URL,
+		EventTypes:     webhook.EventTypes,
+		Active:         webhook.Active,
+		CreatedAt:      webhook.CreatedAt,
+	}
+}
+
+func toWebhookDelivery(delivery models.WebhookDeliveries) openapi.WebhookDelivery {
+	response := openapi.WebhookDelivery{
+		Id:            delivery.ID,
+		WebhookId:     delivery.WebhookID,
+		EventId:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        openapi.WebhookDeliveryStatus(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+	}
+	if delivery.LastStatusCode != 0 {
+		response.LastStatusCode = &delivery.LastStatusCode
+	}
+	if delivery.LastError != "" {
+		response.LastError = &delivery.LastError
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		response.DeliveredAt = &delivery.DeliveredAt
+	}
+	return response
+}
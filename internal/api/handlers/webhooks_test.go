@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_client_WebhookHandlers(t *testing.T) {
+	now := time.Unix(faker.UnixTime(), 0).UTC()
+
+	webhook := models.Webhooks{
+		ID:             5,
+		OrganizationID: 2,
+		URL:            "https://example.com/hooks",
+		Secret:         "secret",
+		EventTypes:     []string{models.UserRegistered},
+		Active:         true,
+		CreatedAt:      now,
+	}
+	delivery := models.WebhookDeliveries{
+		ID:             7,
+		WebhookID:      5,
+		EventID:        11,
+		EventType:      models.UserRegistered,
+		Payload:        `{"id":11}`,
+		Status:         models.DeliveryDead,
+		Attempts:       8,
+		LastStatusCode: 500,
+		LastError:      "webhook answered 500 Internal Server Error",
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.WithMessage("Webhook not found"),
+		errors.KindNotFound(),
+	)
+
+	webhookServiceMock := mocks.NewWebhookServiceInterface(t)
+	webhookServiceMock.On("CreateWebhook", mock.Anything, models.Webhooks{
+		OrganizationID: 2,
+		URL:            "https://example.com/hooks",
+		EventTypes:     []string{models.UserRegistered},
+		Active:         true,
+	}).Return(webhook, nil)
+	webhookServiceMock.On("GetWebhooks", mock.Anything, int32(2)).Return([]models.Webhooks{webhook}, nil)
+	webhookServiceMock.On("UpdateWebhook", mock.Anything, models.Webhooks{
+		ID:             5,
+		OrganizationID: 2,
+		URL:            "https://example.com/hooks",
+		EventTypes:     []string{models.UserRegistered},
+		Active:         false,
+	}).Return(webhook, nil)
+	webhookServiceMock.On("DeleteWebhook", mock.Anything, int32(2), int32(6)).Return(notFound)
+	webhookServiceMock.On("GetDeliveries", mock.Anything, int32(2), int32(5), pagination.Cursor{}, 50).
+		Return(pagination.Page[models.WebhookDeliveries]{Items: []models.WebhookDeliveries{delivery}}, nil)
+	redelivered := delivery
+	redelivered.Status = models.DeliveryPending
+	redelivered.Attempts = 0
+	webhookServiceMock.On("Redeliver", mock.Anything, int32(2), int32(5), int64(7)).Return(redelivered, nil)
+
+	clockMock := mocks.NewClock(t)
+	clockMock.On("Now").Return(now).Maybe()
+
+	l := logger.New("info")
+	r := gin.Default()
+	r.Use(middlewares.ErrorHandler(clockMock, l))
+
+	cfg := &config.Config{API: config.API{AdminToken: testAdminToken}}
+	openapi.RegisterHandlersWithOptions(r, NewClient(cfg, l, &Services{Webhook: webhookServiceMock}), openapi.GinServerOptions{
+		BaseURL: "/api/v1",
+	})
+
+	do := func(method, path, adminToken string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Create webhook", func(t *testing.T) {
+		w := do(http.MethodPost, "/api/v1/orgs/2/webhooks", testAdminToken, &openapi.CreateWebhookRequestBody{
+			Url:        "https://example.com/hooks",
+			EventTypes: []string{models.UserRegistered},
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var got openapi.WebhookSecret
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, openapi.WebhookSecret{
+			Id:             5,
+			OrganizationId: 2,
+			Url:            "https://example.com/hooks",
+			EventTypes:     []string{models.UserRegistered},
+			Active:         true,
+			CreatedAt:      now,
+			Secret:         "secret",
+		}, got)
+	})
+
+	t.Run("Create webhook without admin token", func(t *testing.T) {
+		w := do(http.MethodPost, "/api/v1/orgs/2/webhooks", "wrong", &openapi.CreateWebhookRequestBody{Url: "https://example.com/hooks"})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("List webhooks does not expose secrets", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/webhooks", testAdminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("Deactivate webhook", func(t *testing.T) {
+		w := do(http.MethodPut, "/api/v1/orgs/2/webhooks/5", testAdminToken, &openapi.UpdateWebhookRequestBody{
+			Url:        "https://example.com/hooks",
+			EventTypes: []string{models.UserRegistered},
+			Active:     false,
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("Delete unknown webhook", func(t *testing.T) {
+		w := do(http.MethodDelete, "/api/v1/orgs/2/webhooks/6", testAdminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("List deliveries", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/orgs/2/webhooks/5/deliveries", testAdminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var got openapi.WebhookDeliveryPage
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		code := int32(500)
+		lastError := "webhook answered 500 Internal Server Error"
+		assert.Equal(t, openapi.WebhookDeliveryPage{Deliveries: []openapi.WebhookDelivery{{
+			Id:             7,
+			WebhookId:      5,
+			EventId:        11,
+			EventType:      models.UserRegistered,
+			Payload:        `{"id":11}`,
+			Status:         openapi.Dead,
+			Attempts:       8,
+			LastStatusCode: &code,
+			LastError:      &lastError,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}}}, got)
+	})
+
+	t.Run("Redeliver", func(t *testing.T) {
+		w := do(http.MethodPost, "/api/v1/orgs/2/webhooks/5/deliveries/7/redeliver", testAdminToken, nil)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		var got openapi.WebhookDelivery
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+		assert.Equal(t, openapi.Pending, got.Status)
+		assert.Zero(t, got.Attempts)
+	})
+}
@@ -0,0 +1,15 @@
+package middlewares
+
+import (
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWrites scopes read your writes to the request, once it writes to
+// the primary database its following reads skip the replicas.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithReadYourWrites(c.Request.Context()))
+		c.Next()
+	}
+}
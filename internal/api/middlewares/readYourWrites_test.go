@@ -0,0 +1,37 @@
+package middlewares
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	primary, replica := &sql.DB{}, &sql.DB{}
+	db := database.NewDB(primary, replica)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ReadYourWrites())
+
+	var before, after *sql.DB
+	r.POST("/", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		before = db.Reader(ctx)
+		db.Writer(ctx)
+		after = db.Reader(ctx)
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Same(t, replica, before)
+	assert.Same(t, primary, after)
+}
@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/services"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	PathResolver   = "path"
+	HeaderResolver = "header"
+	HostResolver   = "host"
+
+	// TenantParam is the path parameter read by the path resolver
+	TenantParam = "tenant"
+
+	tenantKey = "tenant"
+)
+
+// TenantResolver finds the organization of the request with the configured
+// resolvers and stores it in the context. An explicit tenant in the path or
+// header that does not exist is rejected, an unknown host is not.
+func TenantResolver(s services.OrganizationServiceInterface, cfg config.Tenancy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op errors.Op = "middlewares.TenantResolver"
+
+		// unmatched routes have nothing to scope
+		if c.FullPath() == "" {
+			return
+		}
+
+		org, err := resolveTenant(c, s, cfg)
+		if err != nil {
+			c.Error(errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to resolve tenant"),
+			))
+			c.Abort()
+			return
+		}
+
+		SetTenant(c, org)
+	}
+}
+
+// SetTenant stores the organization the request is scoped to.
+func SetTenant(c *gin.Context, org models.Organizations) {
+	c.Set(tenantKey, org)
+}
+
+// GetTenant returns the organization resolved for the request.
+func GetTenant(c *gin.Context) (models.Organizations, bool) {
+	v, ok := c.Get(tenantKey)
+	if !ok {
+		return models.Organizations{}, false
+	}
+	org, ok := v.(models.Organizations)
+	return org, ok
+}
+
+func resolveTenant(c *gin.Context, s services.OrganizationServiceInterface, cfg config.Tenancy) (models.Organizations, error) {
+	const op errors.Op = "middlewares.resolveTenant"
+
+	for _, resolver := range cfg.Resolvers {
+		switch resolver {
+		case PathResolver:
+			if slug := c.Param(TenantParam); slug != "" {
+				return s.GetOrganizationBySlug(c.Request.Context(), strings.ToLower(slug))
+			}
+		case HeaderResolver:
+			if slug := c.GetHeader(cfg.Header); cfg.Header != "" && slug != "" {
+				return s.GetOrganizationBySlug(c.Request.Context(), strings.ToLower(slug))
+			}
+		case HostResolver:
+			org, err := s.GetOrganizationByHost(c.Request.Context(), requestHost(c))
+			if err == nil {
+				return org, nil
+			}
+			if !errors.IsKind(err, errors.NotFound) {
+				return models.Organizations{}, err
+			}
+		default:
+			return models.Organizations{}, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("unknown tenant resolver %q", resolver)),
+				errors.WithMessage("Unknown tenant resolver"),
+			)
+		}
+	}
+
+	if cfg.DefaultOrganization != "" {
+		return s.GetOrganizationBySlug(c.Request.Context(), cfg.DefaultOrganization)
+	}
+
+	return models.Organizations{}, errors.Build(
+		errors.WithOp(op),
+		errors.WithError(fmt.Errorf("no tenant found in the request")),
+		errors.WithMessage("Tenant could not be resolved"),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func requestHost(c *gin.Context) string {
+	host, _, err := net.SplitHostPort(c.Request.Host)
+	if err != nil {
+		host = c.Request.Host
+	}
+	return strings.ToLower(host)
+}
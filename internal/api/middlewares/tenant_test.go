@@ -0,0 +1,126 @@
+package middlewares
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantResolver(t *testing.T) {
+	defaultOrg := models.Organizations{ID: 1, Slug: "default"}
+	acme := models.Organizations{ID: 2, Slug: "acme", Host: "auth.acme.com"}
+
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.WithMessage("Organization not found"),
+		errors.KindNotFound(),
+	)
+
+	cfg := config.Tenancy{
+		Resolvers:           []string{PathResolver, HeaderResolver, HostResolver},
+		Header:              "X-Tenant-ID",
+		DefaultOrganization: "default",
+	}
+
+	tests := []struct {
+		name         string
+		cfg          config.Tenancy
+		path         string
+		header       string
+		host         string
+		expectedOrg  models.Organizations
+		expectedCode int
+	}{
+		{
+			name:         "Path parameter",
+			cfg:          cfg,
+			path:         "/saml/acme/metadata",
+			header:       "other",
+			expectedOrg:  acme,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Header",
+			cfg:          cfg,
+			path:         "/login",
+			header:       "ACME",
+			expectedOrg:  acme,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Host",
+			cfg:          cfg,
+			path:         "/login",
+			host:         "auth.acme.com:8080",
+			expectedOrg:  acme,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Default organization when nothing matches",
+			cfg:          cfg,
+			path:         "/login",
+			host:         "localhost",
+			expectedOrg:  defaultOrg,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unknown tenant in header",
+			cfg:          cfg,
+			path:         "/login",
+			header:       "unknown",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "No tenant and no default organization",
+			cfg:          config.Tenancy{Resolvers: []string{HeaderResolver}, Header: "X-Tenant-ID"},
+			path:         "/login",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mocks.NewOrganizationServiceInterface(t)
+			s.On("GetOrganizationBySlug", mock.Anything, "default").Return(defaultOrg, nil).Maybe()
+			s.On("GetOrganizationBySlug", mock.Anything, "acme").Return(acme, nil).Maybe()
+			s.On("GetOrganizationBySlug", mock.Anything, "unknown").Return(models.Organizations{}, notFound).Maybe()
+			s.On("GetOrganizationByHost", mock.Anything, "auth.acme.com").Return(acme, nil).Maybe()
+			s.On("GetOrganizationByHost", mock.Anything, "localhost").Return(models.Organizations{}, notFound).Maybe()
+
+			clockMock := mocks.NewClock(t)
+			clockMock.On("Now").Return(time.Now()).Maybe()
+
+			var got models.Organizations
+			r := gin.New()
+			r.Use(ErrorHandler(clockMock, logger.New("info")))
+			r.Use(TenantResolver(s, tt.cfg))
+			handler := func(c *gin.Context) {
+				got, _ = GetTenant(c)
+				c.Status(http.StatusOK)
+			}
+			r.GET("/login", handler)
+			r.GET("/saml/:tenant/metadata", handler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			req.Host = tt.host
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedOrg, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{
+			name:         "Request context gets a deadline",
+			timeout:      time.Second,
+			wantDeadline: true,
+		},
+		{
+			name:         "Zero timeout leaves the request context untouched",
+			timeout:      0,
+			wantDeadline: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(QueryTimeout(tt.timeout))
+
+			var hasDeadline bool
+			r.GET("/", func(c *gin.Context) {
+				deadline, ok := c.Request.Context().Deadline()
+				hasDeadline = ok
+				if ok {
+					assert.WithinDuration(t, time.Now().Add(tt.timeout), deadline, tt.timeout)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+		})
+	}
+}
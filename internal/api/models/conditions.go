@@ -0,0 +1,25 @@
+package models
+
+// Operators supported in a Condition.
+const (
+	OperatorEqual      = "eq"
+	OperatorNotEqual   = "ne"
+	OperatorContains   = "co"
+	OperatorStartsWith = "sw"
+	OperatorEndsWith   = "ew"
+	OperatorPresent    = "pr"
+)
+
+// Condition restricts a listing to the rows whose field, the name tag of the
+// model, matches the value. Conditions of a listing are combined with AND.
+type Condition struct {
+	Field    string
+	Operator string
+	Value    any
+}
+
+// Page selects a window of a listing.
+type Page struct {
+	Offset int
+	Limit  int
+}
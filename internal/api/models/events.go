@@ -0,0 +1,40 @@
+package models
+
+import "context"
+
+// The domain events published through the outbox.
+const (
+	UserRegistered      = "user.registered"
+	UserPasswordChanged = "user.password_changed"
+)
+
+// UserEvent is the payload of the user events.
+type UserEvent struct {
+	OrganizationID int32  `json:"organization_id"`
+	UserID         int64  `json:"user_id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+}
+
+func NewUserEvent(user Users, id int64) UserEvent {
+	return UserEvent{
+		OrganizationID: user.OrganizationID,
+		UserID:         id,
+		Username:       user.Username,
+		Email:          user.Email,
+	}
+}
+
+type OutboxInterface interface {
+	// Record adds the event to the outbox in the transaction of ctx
+	Record(ctx context.Context, eventType string, payload any) error
+}
+
+// TransactorInterface runs fn in a transaction carried by the context given
+// to it, the repositories called with that context join the transaction.
+type TransactorInterface interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventTypes are the events the webhooks can subscribe to.
+var EventTypes = []string{UserRegistered, UserPasswordChanged}
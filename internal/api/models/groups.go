@@ -0,0 +1,33 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type GroupMembers struct {
+	UserID   int32
+	Username string
+}
+
+// Groups are the roles of an organization as seen by an identity provider.
+type Groups struct {
+	ID             int32  `name:"id"`
+	OrganizationID int32  `name:"organization_id"`
+	Name           string `name:"name"`
+	ExternalID     string `name:"external_id"`
+	Members        []GroupMembers
+	CreatedAt      time.Time `name:"created_at"`
+	UpdatedAt      time.Time `name:"updated_at"`
+}
+
+type GroupRepositoryInterface interface {
+	GetGroups(ctx context.Context, organizationID int32, conditions []Condition, page Page) ([]Groups, int, error)
+	GetGroup(ctx context.Context, organizationID, id int32) (Groups, error)
+	AddGroup(ctx context.Context, group Groups) (Groups, error)
+	// UpdateGroup replaces the name, external id and members of the group
+	UpdateGroup(ctx context.Context, group Groups) (Groups, error)
+	DeleteGroup(ctx context.Context, organizationID, id int32) error
+	// GetUserGroups returns the groups of each of the users, keyed by user id
+	GetUserGroups(ctx context.Context, organizationID int32, userIDs []int32) (map[int32][]Groups, error)
+}
@@ -0,0 +1,9 @@
+package models
+
+// Identity is the result of a successful authentication against one of the
+// configured backends.
+type Identity struct {
+	User    Users
+	Roles   []string
+	Backend string
+}
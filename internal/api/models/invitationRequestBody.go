@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+
+	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type CreateInvitationRequestBody openapi.CreateInvitationRequestBody
+
+type UpdateMemberRequestBody openapi.UpdateMemberRequestBody
+
+func (b CreateInvitationRequestBody) Validate() error {
+	const op errors.Op = "models.Validate"
+	if _, err := mail.ParseAddress(b.Email); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid email"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	return validateRole(op, b.Role)
+}
+
+func (b UpdateMemberRequestBody) Validate() error {
+	const op errors.Op = "models.Validate"
+	return validateRole(op, b.Role)
+}
+
+func validateRole(op errors.Op, role string) error {
+	if len(role) < 1 || len(role) > 63 {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("the length of the role should be more than 0 and less than 64")),
+			errors.WithMessage("The size of the role must be more than 0 and less than 64"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/rs/zerolog"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestCreateInvitationRequestBody_Validate(t *testing.T) {
+	const op errors.Op = "models.Validate"
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	tests := []struct {
+		name        string
+		b           CreateInvitationRequestBody
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			b: CreateInvitationRequestBody{
+				Email: faker.Email(),
+				Role:  "admin",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Invalid email",
+			b: CreateInvitationRequestBody{
+				Email: "invalid",
+				Role:  "admin",
+			},
+			expectedErr: errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("mail: missing '@' or angle-addr")),
+				errors.WithMessage("Invalid email"),
+				errors.KindBadRequest(),
+				errors.WithSeverity(zerolog.WarnLevel),
+			),
+		},
+		{
+			name: "Empty role",
+			b: CreateInvitationRequestBody{
+				Email: faker.Email(),
+			},
+			expectedErr: errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("the length of the role should be more than 0 and less than 64")),
+				errors.WithMessage("The size of the role must be more than 0 and less than 64"),
+				errors.KindBadRequest(),
+				errors.WithSeverity(zerolog.WarnLevel),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.b.Validate()
+			if !errors.Equal(errors.GetFirstNestedError(err), tt.expectedErr) {
+				t.Errorf("CreateInvitationRequestBody.Validate() error = %v, wantErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
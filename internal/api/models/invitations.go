@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type Invitations struct {
+	ID             int32     `name:"id"`
+	OrganizationID int32     `name:"organization_id"`
+	Email          string    `name:"email"`
+	Role           string    `name:"role"`
+	TokenHash      string    `name:"token_hash"`
+	ExpiresAt      time.Time `name:"expires_at"`
+	AcceptedAt     time.Time `name:"accepted_at"`
+	AcceptedUserID int32     `name:"accepted_user_id"`
+	CreatedAt      time.Time `name:"created_at"`
+	UpdatedAt      time.Time `name:"updated_at"`
+}
+
+type InvitationRepositoryInterface interface {
+	AddInvitation(ctx context.Context, invitation Invitations) (Invitations, error)
+	GetInvitations(ctx context.Context, organizationID int32) ([]Invitations, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (Invitations, error)
+	// AcceptInvitation marks the invitation as used and grants its role to the
+	// user, creating the user first when it has no id.
+	AcceptInvitation(ctx context.Context, invitation Invitations, user Users) (int32, error)
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"context"
+
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// Memberships is a user of an organization together with its roles.
+type Memberships struct {
+	OrganizationID int32
+	UserID         int32
+	Username       string
+	Email          string
+	Roles          []string
+}
+
+type MembershipRepositoryInterface interface {
+	// GetMembers returns the page of the members at the cursor, sorted by
+	// user id
+	GetMembers(ctx context.Context, organizationID int32, cursor pagination.Cursor, size int) (pagination.Page[Memberships], error)
+	GetMember(ctx context.Context, organizationID, userID int32) (Memberships, error)
+	// SetMemberRole replaces the roles of the member with the given one
+	SetMemberRole(ctx context.Context, organizationID, userID int32, role string) error
+	RemoveMember(ctx context.Context, organizationID, userID int32) error
+}
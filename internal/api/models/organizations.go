@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Organizations struct {
+	ID        int32                `name:"id"`
+	Slug      string               `name:"slug"`
+	Name      string               `name:"name"`
+	Host      string               `name:"host"`
+	Settings  OrganizationSettings `name:"settings"`
+	CreatedAt time.Time            `name:"created_at"`
+	UpdatedAt time.Time            `name:"updated_at"`
+}
+
+// OrganizationSettings overrides the global configuration for a single tenant.
+type OrganizationSettings struct {
+	// AuthBackends replaces auth.backends when not empty
+	AuthBackends []string `json:"auth_backends,omitempty"`
+	// LDAP is the part of the directory of the tenant, the ldap backend
+	// refuses the tenants without one
+	LDAP *LDAPSettings `json:"ldap,omitempty"`
+}
+
+// LDAPSettings scopes the configured directory to a tenant.
+type LDAPSettings struct {
+	// BaseDN is where the users of the tenant are searched
+	BaseDN string `json:"base_dn"`
+	// UserFilter replaces auth.ldap.user_filter when not empty
+	UserFilter string `json:"user_filter,omitempty"`
+	// GroupRoles maps the group DNs of the tenant to roles
+	GroupRoles map[string]string `json:"group_roles,omitempty"`
+}
+
+// Value implements driver.Valuer.
+func (s OrganizationSettings) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner.
+func (s *OrganizationSettings) Scan(src any) error {
+	*s = OrganizationSettings{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported organization settings type %T", src)
+	}
+}
+
+type OrganizationReaderInterface interface {
+	GetOrganizationByID(ctx context.Context, id int32) (Organizations, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (Organizations, error)
+	GetOrganizationByHost(ctx context.Context, host string) (Organizations, error)
+}
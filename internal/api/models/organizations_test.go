@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationSettings_Scan(t *testing.T) {
+	settings := OrganizationSettings{AuthBackends: []string{"ldap", "database"}}
+
+	value, err := settings.Value()
+	assert.NoError(t, err)
+
+	var got OrganizationSettings
+	assert.NoError(t, got.Scan(value))
+	assert.Equal(t, settings, got)
+
+	assert.NoError(t, got.Scan(`{}`))
+	assert.Equal(t, OrganizationSettings{}, got)
+
+	assert.NoError(t, got.Scan(nil))
+	assert.Equal(t, OrganizationSettings{}, got)
+
+	assert.Error(t, got.Scan(1))
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// SCIMTokens are the bearer tokens an identity provider uses to provision an
+// organization. Only the hash of the token is stored.
+type SCIMTokens struct {
+	ID             int32     `name:"id"`
+	OrganizationID int32     `name:"organization_id"`
+	Description    string    `name:"description"`
+	TokenHash      string    `name:"token_hash"`
+	LastUsedAt     time.Time `name:"last_used_at"`
+	CreatedAt      time.Time `name:"created_at"`
+	UpdatedAt      time.Time `name:"updated_at"`
+}
+
+type SCIMTokenRepositoryInterface interface {
+	AddSCIMToken(ctx context.Context, token SCIMTokens) (SCIMTokens, error)
+	GetSCIMTokens(ctx context.Context, organizationID int32) ([]SCIMTokens, error)
+	// UseSCIMToken returns the token with the hash and records its use
+	UseSCIMToken(ctx context.Context, tokenHash string) (SCIMTokens, error)
+	DeleteSCIMToken(ctx context.Context, organizationID, id int32) error
+}
@@ -1,31 +1,63 @@
 package models
 
 import (
+	"context"
 	"time"
 )
 
+// The timestamps of users and credentials are set when they are written, and
+// deleting them through the query builder only sets DeletedAt.
 type Credentials struct {
 	ID        int32     `name:"id"`
 	Salt      string    `name:"salt"`
 	PassHash  string    `name:"passhash"`
-	CreatedAt time.Time `name:"created_at"`
-	UpdatedAt time.Time `name:"updated_at"`
+	CreatedAt time.Time `name:"created_at" timestamp:"created"`
+	UpdatedAt time.Time `name:"updated_at" timestamp:"updated"`
+	DeletedAt time.Time `name:"deleted_at" timestamp:"deleted"`
 }
 
+// The version of users is incremented by every update, updates based on a
+// stale version are refused.
 type Users struct {
-	ID          int32       `name:"id"`
-	Username    string      `name:"username"`
-	Email       string      `name:"email"`
-	Credentials Credentials `name:"credentials_id" reference:"credentials"`
-	CreatedAt   time.Time   `name:"created_at"`
-	UpdatedAt   time.Time   `name:"updated_at"`
+	ID             int32       `name:"id"`
+	OrganizationID int32       `name:"organization_id"`
+	Username       string      `name:"username"`
+	Email          string      `name:"email"`
+	ExternalID     string      `name:"external_id"`
+	GivenName      string      `name:"given_name"`
+	FamilyName     string      `name:"family_name"`
+	DisplayName    string      `name:"display_name"`
+	Active         bool        `name:"active"`
+	Version        int32       `name:"version" version:"true"`
+	Credentials    Credentials `name:"credentials_id" reference:"credentials"`
+	CreatedAt      time.Time   `name:"created_at" timestamp:"created"`
+	UpdatedAt      time.Time   `name:"updated_at" timestamp:"updated"`
+	DeletedAt      time.Time   `name:"deleted_at" timestamp:"deleted"`
 }
 
+// Every read is scoped by the organization so that a tenant can never see
+// the users of another one.
 type UserReaderInterface interface {
+	GetUserByUsername(ctx context.Context, organizationID int32, username string) (Users, error)
+	GetUserByEmail(ctx context.Context, organizationID int32, email string) (Users, error)
+	GetUserRoles(ctx context.Context, organizationID, userID int32) ([]string, error)
 }
 
 type UserWriterInterface interface {
-	AddUser(user Users) (int64, error)
+	AddUser(ctx context.Context, user Users) (int64, error)
+}
+
+// UserProvisionerInterface manages users on behalf of an identity provider.
+// Provisioned users may have no credentials and only log in through SSO.
+type UserProvisionerInterface interface {
+	GetUsers(ctx context.Context, organizationID int32, conditions []Condition, page Page) ([]Users, int, error)
+	GetUserByID(ctx context.Context, organizationID, id int32) (Users, error)
+	ProvisionUser(ctx context.Context, user Users) (Users, error)
+	// UpdateUser replaces the attributes of the user, the credentials are
+	// only changed when a password hash is given. A non zero version must
+	// be the current version of the user.
+	UpdateUser(ctx context.Context, user Users) (Users, error)
+	DeleteUser(ctx context.Context, organizationID, id int32) error
 }
 
 type UserRepositoryInterface interface {
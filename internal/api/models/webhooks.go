@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// Webhooks are the subscriptions of an organization to its events, the
+// events of the types subscribed are posted to the url signed with the
+// secret.
+type Webhooks struct {
+	ID             int32
+	OrganizationID int32
+	URL            string
+	Secret         string
+	EventTypes     []string
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Subscribed tells whether the webhook receives the events of the type.
+func (w Webhooks) Subscribed(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// The states of a delivery. Pending deliveries are retried until they are
+// delivered or their attempts run out, dead deliveries are only tried again
+// when redelivered.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookDeliveries are the deliveries of an event to a webhook together
+// with the outcome of their last attempt.
+type WebhookDeliveries struct {
+	ID             int64     `name:"id"`
+	WebhookID      int32     `name:"webhook_id"`
+	EventID        int64     `name:"event_id"`
+	EventType      string    `name:"event_type"`
+	Payload        string    `name:"payload"`
+	Status         string    `name:"status"`
+	Attempts       int32     `name:"attempts"`
+	LastStatusCode int32     `name:"last_status_code"`
+	LastError      string    `name:"last_error"`
+	NextAttemptAt  time.Time `name:"next_attempt_at"`
+	DeliveredAt    time.Time `name:"delivered_at"`
+	CreatedAt      time.Time `name:"created_at"`
+	UpdatedAt      time.Time `name:"updated_at"`
+	// Webhook is where the delivery goes, it is only read with the claimed
+	// deliveries
+	Webhook Webhooks
+}
+
+type WebhookRepositoryInterface interface {
+	AddWebhook(ctx context.Context, webhook Webhooks) (Webhooks, error)
+	GetWebhooks(ctx context.Context, organizationID int32) ([]Webhooks, error)
+	GetWebhook(ctx context.Context, organizationID, id int32) (Webhooks, error)
+	// UpdateWebhook replaces the url, event types and state of the webhook,
+	// the secret is only changed when one is given
+	UpdateWebhook(ctx context.Context, webhook Webhooks) (Webhooks, error)
+	DeleteWebhook(ctx context.Context, organizationID, id int32) error
+	// AddDelivery queues the delivery, an event is only queued once per
+	// webhook so adding it again is a no-op
+	AddDelivery(ctx context.Context, delivery WebhookDeliveries) error
+	// GetDeliveries returns the page of the deliveries of the webhook at the
+	// cursor, sorted by id
+	GetDeliveries(ctx context.Context, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[WebhookDeliveries], error)
+	// ClaimDeliveries returns the pending deliveries due at now with their
+	// webhook, counting their attempt. They are hidden from the other
+	// dispatchers until the lease ends.
+	ClaimDeliveries(ctx context.Context, now, lease time.Time, limit int) ([]WebhookDeliveries, error)
+	// SaveAttempt records the outcome of the last attempt of the delivery
+	SaveAttempt(ctx context.Context, delivery WebhookDeliveries) error
+	// Redeliver queues the delivery again with its attempts reset
+	Redeliver(ctx context.Context, webhookID int32, id int64, at time.Time) (WebhookDeliveries, error)
+}
@@ -4,14 +4,75 @@
 package openapi
 
 import (
+	"fmt"
+	"net/http"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/gin-gonic/gin"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /invitations/accept)
+	AcceptInvitationHandler(c *gin.Context)
+
+	// (POST /login)
+	LoginHandler(c *gin.Context)
+
+	// (GET /orgs/{organization_id}/invitations)
+	ListInvitationsHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (POST /orgs/{organization_id}/invitations)
+	CreateInvitationHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (GET /orgs/{organization_id}/members)
+	ListMembersHandler(c *gin.Context, organizationId OrganizationID, params ListMembersHandlerParams)
+
+	// (DELETE /orgs/{organization_id}/members/{user_id})
+	RemoveMemberHandler(c *gin.Context, organizationId OrganizationID, userId UserID)
+
+	// (PATCH /orgs/{organization_id}/members/{user_id})
+	UpdateMemberHandler(c *gin.Context, organizationId OrganizationID, userId UserID)
+
+	// (GET /orgs/{organization_id}/scim/tokens)
+	ListSCIMTokensHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (POST /orgs/{organization_id}/scim/tokens)
+	CreateSCIMTokenHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (DELETE /orgs/{organization_id}/scim/tokens/{token_id})
+	RevokeSCIMTokenHandler(c *gin.Context, organizationId OrganizationID, tokenId TokenID)
+
+	// (GET /orgs/{organization_id}/webhooks)
+	ListWebhooksHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (POST /orgs/{organization_id}/webhooks)
+	CreateWebhookHandler(c *gin.Context, organizationId OrganizationID)
+
+	// (DELETE /orgs/{organization_id}/webhooks/{webhook_id})
+	DeleteWebhookHandler(c *gin.Context, organizationId OrganizationID, webhookId WebhookID)
+
+	// (PUT /orgs/{organization_id}/webhooks/{webhook_id})
+	UpdateWebhookHandler(c *gin.Context, organizationId OrganizationID, webhookId WebhookID)
+
+	// (GET /orgs/{organization_id}/webhooks/{webhook_id}/deliveries)
+	ListWebhookDeliveriesHandler(c *gin.Context, organizationId OrganizationID, webhookId WebhookID, params ListWebhookDeliveriesHandlerParams)
+
+	// (POST /orgs/{organization_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	RedeliverWebhookDeliveryHandler(c *gin.Context, organizationId OrganizationID, webhookId WebhookID, deliveryId DeliveryID)
+
 	// (POST /register)
 	RegisterUserHandler(c *gin.Context)
+
+	// (POST /saml/{tenant}/acs)
+	SAMLAssertionConsumerHandler(c *gin.Context, tenant Tenant)
+
+	// (GET /saml/{tenant}/login)
+	SAMLLoginHandler(c *gin.Context, tenant Tenant)
+
+	// (GET /saml/{tenant}/metadata)
+	SAMLMetadataHandler(c *gin.Context, tenant Tenant)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -23,6 +84,458 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// AcceptInvitationHandler operation middleware
+func (siw *ServerInterfaceWrapper) AcceptInvitationHandler(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.AcceptInvitationHandler(c)
+}
+
+// LoginHandler operation middleware
+func (siw *ServerInterfaceWrapper) LoginHandler(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.LoginHandler(c)
+}
+
+// ListInvitationsHandler operation middleware
+func (siw *ServerInterfaceWrapper) ListInvitationsHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListInvitationsHandler(c, organizationId)
+}
+
+// CreateInvitationHandler operation middleware
+func (siw *ServerInterfaceWrapper) CreateInvitationHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateInvitationHandler(c, organizationId)
+}
+
+// ListMembersHandler operation middleware
+func (siw *ServerInterfaceWrapper) ListMembersHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMembersHandlerParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListMembersHandler(c, organizationId, params)
+}
+
+// RemoveMemberHandler operation middleware
+func (siw *ServerInterfaceWrapper) RemoveMemberHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserID
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RemoveMemberHandler(c, organizationId, userId)
+}
+
+// UpdateMemberHandler operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMemberHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserID
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UpdateMemberHandler(c, organizationId, userId)
+}
+
+// ListSCIMTokensHandler operation middleware
+func (siw *ServerInterfaceWrapper) ListSCIMTokensHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListSCIMTokensHandler(c, organizationId)
+}
+
+// CreateSCIMTokenHandler operation middleware
+func (siw *ServerInterfaceWrapper) CreateSCIMTokenHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateSCIMTokenHandler(c, organizationId)
+}
+
+// RevokeSCIMTokenHandler operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSCIMTokenHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "token_id" -------------
+	var tokenId TokenID
+
+	err = runtime.BindStyledParameter("simple", false, "token_id", c.Param("token_id"), &tokenId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RevokeSCIMTokenHandler(c, organizationId, tokenId)
+}
+
+// ListWebhooksHandler operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooksHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListWebhooksHandler(c, organizationId)
+}
+
+// CreateWebhookHandler operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhookHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateWebhookHandler(c, organizationId)
+}
+
+// DeleteWebhookHandler operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteWebhookHandler(c, organizationId, webhookId)
+}
+
+// UpdateWebhookHandler operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhookHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UpdateWebhookHandler(c, organizationId, webhookId)
+}
+
+// ListWebhookDeliveriesHandler operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveriesHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesHandlerParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListWebhookDeliveriesHandler(c, organizationId, webhookId, params)
+}
+
+// RedeliverWebhookDeliveryHandler operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhookDeliveryHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId OrganizationID
+
+	err = runtime.BindStyledParameter("simple", false, "organization_id", c.Param("organization_id"), &organizationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId DeliveryID
+
+	err = runtime.BindStyledParameter("simple", false, "delivery_id", c.Param("delivery_id"), &deliveryId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delivery_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminTokenScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RedeliverWebhookDeliveryHandler(c, organizationId, webhookId, deliveryId)
+}
+
 // RegisterUserHandler operation middleware
 func (siw *ServerInterfaceWrapper) RegisterUserHandler(c *gin.Context) {
 
@@ -33,6 +546,69 @@ func (siw *ServerInterfaceWrapper) RegisterUserHandler(c *gin.Context) {
 	siw.Handler.RegisterUserHandler(c)
 }
 
+// SAMLAssertionConsumerHandler operation middleware
+func (siw *ServerInterfaceWrapper) SAMLAssertionConsumerHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "tenant" -------------
+	var tenant Tenant
+
+	err = runtime.BindStyledParameter("simple", false, "tenant", c.Param("tenant"), &tenant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SAMLAssertionConsumerHandler(c, tenant)
+}
+
+// SAMLLoginHandler operation middleware
+func (siw *ServerInterfaceWrapper) SAMLLoginHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "tenant" -------------
+	var tenant Tenant
+
+	err = runtime.BindStyledParameter("simple", false, "tenant", c.Param("tenant"), &tenant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SAMLLoginHandler(c, tenant)
+}
+
+// SAMLMetadataHandler operation middleware
+func (siw *ServerInterfaceWrapper) SAMLMetadataHandler(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "tenant" -------------
+	var tenant Tenant
+
+	err = runtime.BindStyledParameter("simple", false, "tenant", c.Param("tenant"), &tenant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.SAMLMetadataHandler(c, tenant)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -62,7 +638,45 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/invitations/accept", wrapper.AcceptInvitationHandler)
+
+	router.POST(options.BaseURL+"/login", wrapper.LoginHandler)
+
+	router.GET(options.BaseURL+"/orgs/:organization_id/invitations", wrapper.ListInvitationsHandler)
+
+	router.POST(options.BaseURL+"/orgs/:organization_id/invitations", wrapper.CreateInvitationHandler)
+
+	router.GET(options.BaseURL+"/orgs/:organization_id/members", wrapper.ListMembersHandler)
+
+	router.DELETE(options.BaseURL+"/orgs/:organization_id/members/:user_id", wrapper.RemoveMemberHandler)
+
+	router.PATCH(options.BaseURL+"/orgs/:organization_id/members/:user_id", wrapper.UpdateMemberHandler)
+
+	router.GET(options.BaseURL+"/orgs/:organization_id/scim/tokens", wrapper.ListSCIMTokensHandler)
+
+	router.POST(options.BaseURL+"/orgs/:organization_id/scim/tokens", wrapper.CreateSCIMTokenHandler)
+
+	router.DELETE(options.BaseURL+"/orgs/:organization_id/scim/tokens/:token_id", wrapper.RevokeSCIMTokenHandler)
+
+	router.GET(options.BaseURL+"/orgs/:organization_id/webhooks", wrapper.ListWebhooksHandler)
+
+	router.POST(options.BaseURL+"/orgs/:organization_id/webhooks", wrapper.CreateWebhookHandler)
+
+	router.DELETE(options.BaseURL+"/orgs/:organization_id/webhooks/:webhook_id", wrapper.DeleteWebhookHandler)
+
+	router.PUT(options.BaseURL+"/orgs/:organization_id/webhooks/:webhook_id", wrapper.UpdateWebhookHandler)
+
+	router.GET(options.BaseURL+"/orgs/:organization_id/webhooks/:webhook_id/deliveries", wrapper.ListWebhookDeliveriesHandler)
+
+	router.POST(options.BaseURL+"/orgs/:organization_id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", wrapper.RedeliverWebhookDeliveryHandler)
+
 	router.POST(options.BaseURL+"/register", wrapper.RegisterUserHandler)
 
+	router.POST(options.BaseURL+"/saml/:tenant/acs", wrapper.SAMLAssertionConsumerHandler)
+
+	router.GET(options.BaseURL+"/saml/:tenant/login", wrapper.SAMLLoginHandler)
+
+	router.GET(options.BaseURL+"/saml/:tenant/metadata", wrapper.SAMLMetadataHandler)
+
 	return router
 }
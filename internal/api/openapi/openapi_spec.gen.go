@@ -18,16 +18,52 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RUb2/TTgz+KpV/+0mMpk26blWJhGBDCCbxAk3whjagI/Ham5K7m+0ORpXvju6Sdusa",
-	"/klIvGnvfH782H4cryG3lbMGjTCka+B8iZUKxxeESvA9I10gO2sYvdWRdUiiMfjowv9eWqqUQArayOQY",
-	"IpBbh80VF0hQ1xEQXq80YQHpzKOyrZP9fIW5QB3BSyJL+xy4MbcAFtJm4QEN+565Qma1wM43p2TZ+cCi",
-	"ZMUPqxkfdVQTgegKWVTldvwLJTjwT3eYTfz9BtwPsmWP2mLvamgz7mrXBS40C1Kj0PUKWc5scdvRwErp",
-	"0h8q9fUNmoXvwNHJOIJKm819HIgEyUAKH2engw9q8C0ZPBl+6v8/yPrP71kGWX82zFpD1j/Yr9bHYv5i",
-	"qXjAOprskE53SB89ezp8vAl7GG7zeXHYWubzIltPo9Gk7iRcMZJRFT4gnOxV+XNhtmGitmv3StnXwKO1",
-	"ubRhoLSU/u2V7b3DypVKsHf69hwiuEFibX2Jo2EyTHy21qFRTkMK42BqVA5ixdTKGnS0LP7fq6lEW3Ne",
-	"QLoj/GtlihL9xNDuCOTWCJqAVs6VOg/4+Ip9Jpvv3J8OCC8hhf/iu0UQt1sg/tGI1XXTuGYthLyPktEf",
-	"0WrBin/F37GC6k4VCuSctJOmz5u0e15OjzhOkr+fXLOufiefM1X02vZ5/5N/m03w7NG2oR6jFuzHv5m9",
-	"ZtQgC1hG8gMM6WwNKyohhaWI4zSOS5urcmlZ0mkyTWLldHwzgjqrvwcAAP//PcDjEFQGAAA=",
+	"H4sIAAAAAAAC/+wc+2/buPlfIbQbsFuV2GnSomdg2HLtsAVrd4ekxYClWcBIn21eJVIlKSdu4P994ENv",
+	"SpYdO6kv/imxRPF7P/jxI++9gMUJo0Cl8Eb3XoI5jkEC17/eplwwrv4LQQScJJIw6o28XxL8NQUk2Reg",
+	"iI2RnAJK8EQ9QRxw6OsnFO7kdaCnQIyjhMMs/zlGmDI5BW6+s3MIHAOKiJCETg7RxymgMeFCmjFE6LnR",
+	"LZFTlkpE5KHne0Th8zUFPvd8j+IYvJFngHi+J4IpxFjhL+eJeiMkJ3TiLRa+9w4iMgM+P3un3utpEiyn",
+	"xSyhHXBNQs/3OHxNCYfQG0meQnnqMeMxlt7II1S+PvH8DBahEibANbBf+ART8g0r/rUCZKVBqwA9fukG",
+	"+iuewAX5Bjm4GpsUV6+FGlCeOYQxTiPpjV4N/SaYGN+ROI290dFw6HsxofaXE4GPQDGVLdRK87KLyKbQ",
+	"PiqNa2Wg1sdNcO6TAN4KJRXANwHkP3AzZexLK5xb8/7hoBbZcG3Tp0EAiTyjMyK1pp3D1xSE/JmFc/U6",
+	"4SwBLglYdyDELeNh0wWcW4TQ7RQoogwptmjb1JZM1PwQIogxiRDcESEFmoP0/LpUfSM2BSEm9D3QiZyW",
+	"FaoYpyAY3mwNmUWZ05cWs6t8GLv5DQKpcHnLAUvoyUUNVxOI7zICX7469ssEHzsI5iyC2mevj/1uNtUo",
+	"MKDtVO2EXLw9+6Btq5OOCtNr1LxyYdICTZnXOYiEUQFNKCTs61XLhJKwgzxrap3E4UCSGVQ8oDE1O+cN",
+	"YxFgqiaFGVB5rZ4bhCXEwuGv8k8x53iufgsIOMim/v4L5nkIJBOKZcpB+GgCFDiWmV7HRAg1sV/l/InL",
+	"VHhD34Ynb5Ypi/qqSp2TpTq6quDSJMS8E+WcQCDMWUpDhPNQj7MY72c0IaziuUBAQ6GielU0pUTCyeZS",
+	"ZuEOGw0a/s65GVsz1OxxAwQJnY9jEMIyoomV8uauF0JimYpevtv3JIlBSBwnlfEhlnCgXi11YTp6FJPk",
+	"0H1LbEGDxdgl8sLPuexGxRMIr7FsRZGmUYRvIqiZVMGSfJIsuLqY0zJLiVmBtvZOVBqgc+fcfHOXEA5i",
+	"pdlaUG+iWk/2+n2VRYQeMm9mk5VYUCGvwjmXArxnE9I/U1ghjq8Sy/Lv/AJaB7Jt8aVd4D0jz0OEt2LA",
+	"KLNqLZmXeFYWv9uzf4D4BriYkmQVrj0qM/oD6M+6IqHfGP+y6Iij6JexN7q8937gMPZG3h8GxXp7YLPy",
+	"QSmiLvw642MzaYVVXZOVhNjgYY3wbOomLVcL3zuHCRESuMnWHp7eJlhK4NQbef+7PD34Lz74Njz46fD6",
+	"xR8Prl78rfTk4OrF5eGVfXD14geXm614mwLq0esK0DcVoH/6618O/5xN+6P+9flz+KN98vlzeHX/xj96",
+	"vXACrPis1nT8eAUXlqlTpyu7OP3wPvNkbuafQ4TnFxJLdxJSnmC5JVRGO/HJ1glNRNaJvbUFxfrRNMJC",
+	"qtzhYVnIOp6snxsuE7o02OZMvsiXC/38SP6hw43ki+z1VrzKIXxKFDeNf+l0CBtYt7auVw0Sqy3oHmEF",
+	"92+4RV/cqzj1O0g5ByoRo7qO+QUS+WRrOj/jjIu9lrGrcXOttHtdCWw5w7ZcXi/Zauf1UqO3jM+q0g4B",
+	"SAlxIvuuG9dzxxr4A72oob93Ll2w62E5uQ4B+QJ+KZZ6uFkLXwcshDVXnLo0YUWzEq8TPI8YdhRWlTPL",
+	"3Ag3/k24JiiqCEBVCf7SS4CGxpfkgtT/43JuUUxQqi+vG+gqJepc7hWZFpSWSg+5LjcZuKqhbDDTtlwj",
+	"0D/Zrlvtsoy7BMIdY+2Eq0Z++5mDqCJgdbs0O86FlQl7KSdyfqHgmYlPw5jQPBfUiOiwAJgDL/RnKmVi",
+	"9iAIHTONB5HKorx/MPQR4iTCEtDpr2ee782AC2MER4fDw6F24glQnBBv5B3rR6ZIpREYkLwqJQamgKQe",
+	"J0zov4oJ+uVZ6I0aux7/xDSMNJ68mkMEjEowm1Y4SSIS6OGD3wQr6MTL1KJrj2WxMKw3Wbam5OVwuDHQ",
+	"5eXfop5il1aoyJoZupkXeyQaoGL7yQYxMrVWBzI/4xBZzqj9YVOPChuYnGwfk0JSiDKJxqpebYD/9KjA",
+	"b7FAOFK73HO1haVxePUYotAvUKaUpmaOJ0I5hnKaIzztDQaRqrC1G5suwG3XwhoFyS2bVbWm6ODgaSqn",
+	"QKWaHUIlPP4UdmRgHj2K0uKIhMqLhIpsHInvQVlxIQU11mgr4xMxuK+l64ty+FCoTMClyUSU3LgodLrc",
+	"J9MSm4shg1r7x+LqgZraKzc5q/jRWlrS4YXy3bsyxx5dsbBKL0yH0aOFgbKYqoHgqfQ6y7u0kpUzrsur",
+	"xVWh9nUf7bc45nrXwkb1efNOvqvJwunvN6efZePpDNlCgXoOnn5vkA8wyI4wVNrfaQ1BNm3flLn6S7+w",
+	"jZ89RuZNhg8Oav1WNWbt3pTVr6U2UstSVxzzkWDcLnt0kxgJH81685ypaIlVSOsOzL01/66seXBvN5UX",
+	"trAEEpqmfQ4xm9kNlcezbdvc6rDXk2Y10iCHuMY0fBZqakne8fwPy2Da1LjyFt4Tadzm08S2fcknrbQZ",
+	"pEIbi/YZ4t5Y14gmIiDxQDO0Oz/MN/53q0JR7ldYWqBQg41y7QsUO5dBJZzNiCCMqq2nZfWJXC12ojzh",
+	"PDqx5epEvUOo01xMB4rZ31PNJ4xGc8RBppxCiKbAYR+d9vbaaa/9QtTgPjuAt2TVM2NfNm/ky9PQ7PBg",
+	"v5VPYUCIa4yfx+qnRPbvU2Ftz0p3QmX7KXYrnSqaQJYmUxl9+0xq1zxzrr7Lsigr4p3IoRztvFvOoKp9",
+	"Vu0W8h0lT5m5pDzS7TKqxw6ZNtO9xe6GxfYIS4P7oqmyM416p59v2MqXJ1HFrQX90ig7HhkyQiTZBOQ0",
+	"O51PpEClnsjnoMcZQ3Y36KSyrbD85Nq4reJy3wA13HSA6iot3xb53j4A7Q13w7FnUG2HX7ZUepePfhLr",
+	"372WBtc5hiV9DYVEsie3WZZadDXsGxr2HmHrHmFwX7odbTHgYH+294SfZ0Nqev+deovS7XAOL/ByW17A",
+	"JevsHfqaQgohwhNMqEmfcRTpFDo/T/WcrI7xzCHOd9kCub1moct0iosYtnuqou3Khw0UY3pVMB3XgzVv",
+	"b2rKIkN77WMXvZCzqtADn9o216unxabjuITRPV4+LCFwHA3uzeWMiwEORLteqtsiToUArp68ZVSk8fpd",
+	"RfauyP7LuLuD29vbA3Vs9SDlEdCAhRCusIVav1nj+ztA5KN8VwNCxKh29eZCVHMA61ls22YK5iuHzygg",
+	"OcUShQyE9vpEHz8azxEuWf/x9vH7pJuHBSLUnvB/tA260w/vETHEB4yOySRVpybHjOsVgbR29PRnsYRg",
+	"Tp+SHx50LikVebXzgw9wJCVjPjZZWz1yhIRDIJFkmntGm+TcWF4IfC/WvmKNQeIQS9wp2Q920KaF2+2p",
+	"FaIZei/u4qjKoPq5+KZogM9IALlOoJzUvXK4lUMnw3yWCVXfqaJvAhCjwSBiAY6mTMjRm+Gb4QAnZDA7",
+	"UvcM/H8AUiQsBIxcAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -7,11 +7,59 @@ import (
 	"time"
 )
 
+const (
+	AdminTokenScopes = "AdminToken.Scopes"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Dead      WebhookDeliveryStatus = "dead"
+	Delivered WebhookDeliveryStatus = "delivered"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// AcceptInvitationRequestBody defines model for AcceptInvitationRequestBody.
+type AcceptInvitationRequestBody struct {
+	// Password Required when no user with the invited email exists yet
+	Password *string `json:"password,omitempty"`
+	Token    string  `json:"token"`
+
+	// Username Required when no user with the invited email exists yet
+	Username *string `json:"username,omitempty"`
+}
+
+// CreateInvitationRequestBody defines model for CreateInvitationRequestBody.
+type CreateInvitationRequestBody struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// CreateSCIMTokenRequestBody defines model for CreateSCIMTokenRequestBody.
+type CreateSCIMTokenRequestBody struct {
+	Description *string `json:"description,omitempty"`
+}
+
 // CreateUserResponse defines model for CreateUserResponse.
 type CreateUserResponse struct {
 	Id int64 `json:"id"`
 }
 
+// CreateWebhookRequestBody defines model for CreateWebhookRequestBody.
+type CreateWebhookRequestBody struct {
+	Active     *bool    `json:"active,omitempty"`
+	EventTypes []string `json:"event_types"`
+
+	// Secret Key of the signatures, generated when missing
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// CursorPage Cursors of the pages around a page of a listing, missing at its ends.
+type CursorPage struct {
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Error     string    `json:"error"`
@@ -22,6 +70,49 @@ type Error struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Invitation defines model for Invitation.
+type Invitation struct {
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	AcceptedUserId *int32     `json:"accepted_user_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Email          string     `json:"email"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	Id             int32      `json:"id"`
+	OrganizationId int32      `json:"organization_id"`
+	Role           string     `json:"role"`
+}
+
+// LoginRequestBody defines model for LoginRequestBody.
+type LoginRequestBody struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	Email          string   `json:"email"`
+	Id             int64    `json:"id"`
+	OrganizationId int32    `json:"organization_id"`
+	Roles          []string `json:"roles"`
+	Username       string   `json:"username"`
+}
+
+// Membership defines model for Membership.
+type Membership struct {
+	Email          string   `json:"email"`
+	OrganizationId int32    `json:"organization_id"`
+	Roles          []string `json:"roles"`
+	UserId         int32    `json:"user_id"`
+	Username       string   `json:"username"`
+}
+
+// MembershipPage defines model for MembershipPage.
+type MembershipPage struct {
+	Members    []Membership `json:"members"`
+	NextCursor *string      `json:"next_cursor,omitempty"`
+	PrevCursor *string      `json:"prev_cursor,omitempty"`
+}
+
 // RegisterUserRequestBody defines model for RegisterUserRequestBody.
 type RegisterUserRequestBody struct {
 	Email    string `json:"email"`
@@ -29,5 +120,156 @@ type RegisterUserRequestBody struct {
 	Username string `json:"username"`
 }
 
+// SAMLResponseBody defines model for SAMLResponseBody.
+type SAMLResponseBody struct {
+	RelayState   *string `json:"RelayState,omitempty"`
+	SAMLResponse string  `json:"SAMLResponse"`
+}
+
+// SCIMToken defines model for SCIMToken.
+type SCIMToken struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	Description    string     `json:"description"`
+	Id             int32      `json:"id"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	OrganizationId int32      `json:"organization_id"`
+}
+
+// SCIMTokenSecret defines model for SCIMTokenSecret.
+type SCIMTokenSecret struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	Description    string     `json:"description"`
+	Id             int32      `json:"id"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	OrganizationId int32      `json:"organization_id"`
+	Token          string     `json:"token"`
+}
+
+// UpdateMemberRequestBody defines model for UpdateMemberRequestBody.
+type UpdateMemberRequestBody struct {
+	Role string `json:"role"`
+}
+
+// UpdateWebhookRequestBody defines model for UpdateWebhookRequestBody.
+type UpdateWebhookRequestBody struct {
+	Active     bool     `json:"active"`
+	EventTypes []string `json:"event_types"`
+
+	// Secret New key of the signatures, the current one is kept when missing
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+	EventTypes     []string  `json:"event_types"`
+	Id             int32     `json:"id"`
+	OrganizationId int32     `json:"organization_id"`
+	Url            string    `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts       int32      `json:"attempts"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	EventId        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Id             int64      `json:"id"`
+	LastError      *string    `json:"last_error"`
+	LastStatusCode *int32     `json:"last_status_code"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+
+	// Payload Body of the requests
+	Payload   string                `json:"payload"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId int32                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryPage defines model for WebhookDeliveryPage.
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor *string           `json:"next_cursor,omitempty"`
+	PrevCursor *string           `json:"prev_cursor,omitempty"`
+}
+
+// WebhookSecret defines model for WebhookSecret.
+type WebhookSecret struct {
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+	EventTypes     []string  `json:"event_types"`
+	Id             int32     `json:"id"`
+	OrganizationId int32     `json:"organization_id"`
+	Secret         string    `json:"secret"`
+	Url            string    `json:"url"`
+}
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// DeliveryID defines model for DeliveryID.
+type DeliveryID = int64
+
+// OrganizationID defines model for OrganizationID.
+type OrganizationID = int32
+
+// PageSize defines model for PageSize.
+type PageSize = int32
+
+// Tenant defines model for Tenant.
+type Tenant = string
+
+// TokenID defines model for TokenID.
+type TokenID = int32
+
+// UserID defines model for UserID.
+type UserID = int32
+
+// WebhookID defines model for WebhookID.
+type WebhookID = int32
+
+// ListMembersHandlerParams defines parameters for ListMembersHandler.
+type ListMembersHandlerParams struct {
+	// Cursor Opaque token of the page to read, the next_cursor or prev_cursor of another page of the same listing. The first page is read without it.
+	Cursor   *Cursor   `form:"cursor,omitempty" json:"cursor,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ListWebhookDeliveriesHandlerParams defines parameters for ListWebhookDeliveriesHandler.
+type ListWebhookDeliveriesHandlerParams struct {
+	// Cursor Opaque token of the page to read, the next_cursor or prev_cursor of another page of the same listing. The first page is read without it.
+	Cursor   *Cursor   `form:"cursor,omitempty" json:"cursor,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// AcceptInvitationHandlerJSONRequestBody defines body for AcceptInvitationHandler for application/json ContentType.
+type AcceptInvitationHandlerJSONRequestBody = AcceptInvitationRequestBody
+
+// LoginHandlerJSONRequestBody defines body for LoginHandler for application/json ContentType.
+type LoginHandlerJSONRequestBody = LoginRequestBody
+
+// CreateInvitationHandlerJSONRequestBody defines body for CreateInvitationHandler for application/json ContentType.
+type CreateInvitationHandlerJSONRequestBody = CreateInvitationRequestBody
+
+// UpdateMemberHandlerJSONRequestBody defines body for UpdateMemberHandler for application/json ContentType.
+type UpdateMemberHandlerJSONRequestBody = UpdateMemberRequestBody
+
+// CreateSCIMTokenHandlerJSONRequestBody defines body for CreateSCIMTokenHandler for application/json ContentType.
+type CreateSCIMTokenHandlerJSONRequestBody = CreateSCIMTokenRequestBody
+
+// CreateWebhookHandlerJSONRequestBody defines body for CreateWebhookHandler for application/json ContentType.
+type CreateWebhookHandlerJSONRequestBody = CreateWebhookRequestBody
+
+// UpdateWebhookHandlerJSONRequestBody defines body for UpdateWebhookHandler for application/json ContentType.
+type UpdateWebhookHandlerJSONRequestBody = UpdateWebhookRequestBody
+
 // RegisterUserHandlerJSONRequestBody defines body for RegisterUserHandler for application/json ContentType.
 type RegisterUserHandlerJSONRequestBody = RegisterUserRequestBody
+
+// SAMLAssertionConsumerHandlerFormdataRequestBody defines body for SAMLAssertionConsumerHandler for application/x-www-form-urlencoded ContentType.
+type SAMLAssertionConsumerHandlerFormdataRequestBody = SAMLResponseBody
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// column is a field that listings can be filtered by.
+type column struct {
+	expr      string
+	caseExact bool
+}
+
+// likeEscaper escapes the LIKE wildcards with !, unlike the backslash it is
+// not special in the string literals of any dialect.
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+// whereConditions appends the conditions to the query arguments and returns
+// the matching SQL. Only the fields in columns can be used, so the field
+// names never reach the query.
+func whereConditions(op errors.Op, conditions []models.Condition, columns map[string]column, args []any) (string, []any, error) {
+	bind := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	clauses := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		col, ok := columns[cond.Field]
+		if !ok {
+			return "", nil, unsupportedCondition(op, fmt.Errorf("field %q cannot be filtered", cond.Field))
+		}
+
+		expr := col.expr
+		if s, isString := cond.Value.(string); isString && !col.caseExact {
+			expr = "LOWER(" + col.expr + ")"
+			cond.Value = strings.ToLower(s)
+		}
+
+		switch cond.Operator {
+		case models.OperatorPresent:
+			clauses = append(clauses, col.expr+" IS NOT NULL")
+			continue
+		case models.OperatorEqual, models.OperatorNotEqual:
+			sign := "="
+			if cond.Operator == models.OperatorNotEqual {
+				sign = "<>"
+			}
+			clauses = append(clauses, fmt.Sprintf("%s %s %s", expr, sign, bind(cond.Value)))
+		case models.OperatorContains, models.OperatorStartsWith, models.OperatorEndsWith:
+			s, ok := cond.Value.(string)
+			if !ok {
+				return "", nil, unsupportedCondition(op, fmt.Errorf("operator %s needs a string value", cond.Operator))
+			}
+			pattern := likeEscaper.Replace(s)
+			switch cond.Operator {
+			case models.OperatorContains:
+				pattern = "%" + pattern + "%"
+			case models.OperatorStartsWith:
+				pattern = pattern + "%"
+			case models.OperatorEndsWith:
+				pattern = "%" + pattern
+			}
+			clauses = append(clauses, fmt.Sprintf("%s LIKE %s ESCAPE '!'", expr, bind(pattern)))
+		default:
+			return "", nil, unsupportedCondition(op, fmt.Errorf("operator %q is not supported", cond.Operator))
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "", args, nil
+	}
+	return " AND " + strings.Join(clauses, " AND "), args, nil
+}
+
+// inList appends the ids to the query arguments and returns their
+// placeholders separated by commas.
+func inList(ids []int32, args []any) (string, []any) {
+	list := make([]string, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		list = append(list, fmt.Sprintf("$%d", len(args)))
+	}
+	return strings.Join(list, ", "), args
+}
+
+func unsupportedCondition(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Unsupported filter"),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
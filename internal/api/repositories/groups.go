@@ -0,0 +1,480 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// GroupRepository exposes the roles of an organization as groups.
+type GroupRepository struct {
+	db      *database.DB
+	dialect database.Dialect
+}
+
+func NewGroupRepository(db *database.DB) *GroupRepository {
+	return &GroupRepository{
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
+	}
+}
+
+const groupQuery = `SELECT r.id, r.organization_id, r.name, COALESCE(r.external_id, ''),
+		r.created_at, r.updated_at
+		FROM roles r`
+
+// groupColumns are the fields groups can be filtered by.
+var groupColumns = map[string]column{
+	"id":          {expr: "r.id", caseExact: true},
+	"name":        {expr: "r.name"},
+	"external_id": {expr: "r.external_id", caseExact: true},
+}
+
+func (r GroupRepository) GetGroups(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Groups, int, error) {
+	const op errors.Op = "repositories.GetGroups"
+
+	if organizationID == 0 {
+		return nil, 0, missingOrganization(op)
+	}
+
+	where, args, err := whereConditions(op, conditions, groupColumns, []any{organizationID})
+	if err != nil {
+		return nil, 0, err
+	}
+	where = "WHERE r.organization_id = $1" + where
+
+	var total int
+	if err := r.db.ReadConn(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM roles r `+where, args...).Scan(&total); err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get groups"),
+		)
+	}
+
+	args = append(args, page.Limit, page.Offset)
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, fmt.Sprintf(groupQuery+`
+		%s
+		ORDER BY r.id
+		LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get groups"),
+		)
+	}
+	defer rows.Close()
+
+	groups := make([]models.Groups, 0)
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, 0, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get groups"),
+			)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get groups"),
+		)
+	}
+
+	if err := r.loadMembers(ctx, groups); err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get groups"),
+		)
+	}
+
+	return groups, total, nil
+}
+
+func (r GroupRepository) GetGroup(ctx context.Context, organizationID, id int32) (models.Groups, error) {
+	const op errors.Op = "repositories.GetGroup"
+
+	if organizationID == 0 {
+		return models.Groups{}, missingOrganization(op)
+	}
+
+	group, err := scanGroup(r.db.ReadConn(ctx).QueryRowContext(ctx, groupQuery+`
+		WHERE r.organization_id = $1 AND r.id = $2`, organizationID, id))
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Groups{}, groupNotFound(op, err)
+	}
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get group"),
+		)
+	}
+
+	groups := []models.Groups{group}
+	if err := r.loadMembers(ctx, groups); err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get group"),
+		)
+	}
+
+	return groups[0], nil
+}
+
+func (r GroupRepository) AddGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	const op errors.Op = "repositories.AddGroup"
+
+	if group.OrganizationID == 0 {
+		return models.Groups{}, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create group"),
+		)
+	}
+	defer tx.Rollback()
+
+	id, err := database.InsertID(ctx, tx, r.dialect, `INSERT INTO roles (organization_id, name, external_id)
+		VALUES ($1, $2, NULLIF($3, ''))`, group.OrganizationID, group.Name, group.ExternalID)
+	if isConstraintViolation(err) {
+		return models.Groups{}, groupConflict(op, err)
+	}
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create group"),
+		)
+	}
+	group.ID = int32(id)
+
+	if err := r.setGroupMembers(ctx, op, tx, group); err != nil {
+		return models.Groups{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create group"),
+		)
+	}
+
+	return r.GetGroup(database.PinPrimary(ctx), group.OrganizationID, group.ID)
+}
+
+func (r GroupRepository) UpdateGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	const op errors.Op = "repositories.UpdateGroup"
+
+	if group.OrganizationID == 0 {
+		return models.Groups{}, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE roles SET
+			name = $3,
+			external_id = NULLIF($4, ''),
+			updated_at = `+r.dialect.Now()+`
+		WHERE organization_id = $1 AND id = $2`,
+		group.OrganizationID, group.ID, group.Name, group.ExternalID)
+	if isConstraintViolation(err) {
+		return models.Groups{}, groupConflict(op, err)
+	}
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return models.Groups{}, groupNotFound(op, sql.ErrNoRows)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE role_id = $1`, group.ID); err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		)
+	}
+
+	if err := r.setGroupMembers(ctx, op, tx, group); err != nil {
+		return models.Groups{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		)
+	}
+
+	return r.GetGroup(database.PinPrimary(ctx), group.OrganizationID, group.ID)
+}
+
+func (r GroupRepository) DeleteGroup(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "repositories.DeleteGroup"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	res, err := r.db.WriteConn(ctx).ExecContext(ctx, `DELETE FROM roles WHERE organization_id = $1 AND id = $2`, organizationID, id)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete group"),
+		)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return groupNotFound(op, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r GroupRepository) GetUserGroups(ctx context.Context, organizationID int32, userIDs []int32) (map[int32][]models.Groups, error) {
+	const op errors.Op = "repositories.GetUserGroups"
+
+	if organizationID == 0 {
+		return nil, missingOrganization(op)
+	}
+
+	groups := make(map[int32][]models.Groups, len(userIDs))
+	if len(userIDs) == 0 {
+		return groups, nil
+	}
+
+	list, args := inList(userIDs, []any{organizationID})
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, `SELECT ur.user_id, r.id, r.organization_id, r.name, COALESCE(r.external_id, ''),
+			r.created_at, r.updated_at
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE r.organization_id = $1 AND ur.user_id IN (`+list+`)
+		ORDER BY r.name`, args...)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user groups"),
+		)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int32
+		var group models.Groups
+		var updatedAt sql.NullTime
+		err := rows.Scan(
+			&userID,
+			&group.ID,
+			&group.OrganizationID,
+			&group.Name,
+			&group.ExternalID,
+			&group.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get user groups"),
+			)
+		}
+		group.UpdatedAt = updatedOrCreated(updatedAt, group.CreatedAt)
+		groups[userID] = append(groups[userID], group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user groups"),
+		)
+	}
+
+	return groups, nil
+}
+
+// loadMembers fills the members of the groups with a single query.
+func (r GroupRepository) loadMembers(ctx context.Context, groups []models.Groups) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	index := make(map[int32]int, len(groups))
+	ids := make([]int32, 0, len(groups))
+	for i, group := range groups {
+		index[group.ID] = i
+		ids = append(ids, group.ID)
+		groups[i].Members = make([]models.GroupMembers, 0)
+	}
+
+	list, args := inList(ids, nil)
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, `SELECT ur.role_id, u.id, u.username
+		FROM user_roles ur
+		JOIN users u ON u.id = ur.user_id AND u.deleted_at IS NULL
+		WHERE ur.role_id IN (`+list+`)
+		ORDER BY u.id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID int32
+		var member models.GroupMembers
+		if err := rows.Scan(&groupID, &member.UserID, &member.Username); err != nil {
+			return err
+		}
+		i := index[groupID]
+		groups[i].Members = append(groups[i].Members, member)
+	}
+
+	return rows.Err()
+}
+
+// setGroupMembers adds the members to the group, they must all be users of
+// the organization of the group.
+func (r GroupRepository) setGroupMembers(ctx context.Context, op errors.Op, tx *database.Tx, group models.Groups) error {
+	if len(group.Members) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, 0, len(group.Members))
+	missing := make(map[int32]bool, len(group.Members))
+	for _, member := range group.Members {
+		if !missing[member.UserID] {
+			missing[member.UserID] = true
+			ids = append(ids, member.UserID)
+		}
+	}
+
+	// the users found in the organization are crossed off the missing ones
+	list, args := inList(ids, []any{group.OrganizationID})
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM users WHERE organization_id = $1 AND deleted_at IS NULL AND id IN (`+list+`)`, args...)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to set group members"),
+		)
+	}
+	for rows.Next() {
+		var id int32
+		if err = rows.Scan(&id); err != nil {
+			break
+		}
+		delete(missing, id)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to set group members"),
+		)
+	}
+
+	if len(missing) > 0 {
+		notFound := make([]int32, 0, len(missing))
+		for _, id := range ids {
+			if missing[id] {
+				notFound = append(notFound, id)
+			}
+		}
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("users %v are not in organization %d", notFound, group.OrganizationID)),
+			errors.WithMessage("Group member not found"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	values := make([]string, 0, len(ids))
+	args = []any{group.ID}
+	for _, id := range ids {
+		args = append(args, id)
+		values = append(values, fmt.Sprintf("($%d, $1)", len(args)))
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO user_roles (user_id, role_id)
+		VALUES `+strings.Join(values, ", ")+r.dialect.Upsert([]string{"user_id", "role_id"}, "", nil), args...)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to set group members"),
+		)
+	}
+
+	return nil
+}
+
+func scanGroup(row rowScanner) (models.Groups, error) {
+	var group models.Groups
+	var updatedAt sql.NullTime
+	err := row.Scan(
+		&group.ID,
+		&group.OrganizationID,
+		&group.Name,
+		&group.ExternalID,
+		&group.CreatedAt,
+		&updatedAt,
+	)
+	group.UpdatedAt = updatedOrCreated(updatedAt, group.CreatedAt)
+	return group, err
+}
+
+func groupNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Group not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func groupConflict(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Group already exists"),
+		errors.KindConflict(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
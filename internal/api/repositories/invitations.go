@@ -0,0 +1,316 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type InvitationRepository struct {
+	db      *database.DB
+	dialect database.Dialect
+}
+
+func NewInvitationRepository(db *database.DB) *InvitationRepository {
+	return &InvitationRepository{
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
+	}
+}
+
+func (r InvitationRepository) AddInvitation(ctx context.Context, invitation models.Invitations) (models.Invitations, error) {
+	const op errors.Op = "repositories.AddInvitation"
+
+	if invitation.OrganizationID == 0 {
+		return models.Invitations{}, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+	defer tx.Rollback()
+
+	roleID, err := ensureRole(ctx, tx, r.dialect, invitation.OrganizationID, invitation.Role)
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+
+	id, err := database.InsertID(ctx, tx, r.dialect, `INSERT INTO invitations (organization_id, email, role_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		invitation.OrganizationID,
+		invitation.Email,
+		roleID,
+		invitation.TokenHash,
+		invitation.ExpiresAt,
+	)
+	if err == nil {
+		invitation.ID = int32(id)
+		err = tx.QueryRowContext(ctx, `SELECT created_at FROM invitations WHERE id = $1`, id).Scan(&invitation.CreatedAt)
+	}
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+
+	return invitation, nil
+}
+
+func (r InvitationRepository) GetInvitations(ctx context.Context, organizationID int32) ([]models.Invitations, error) {
+	const op errors.Op = "repositories.GetInvitations"
+
+	if organizationID == 0 {
+		return nil, missingOrganization(op)
+	}
+
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, invitationQuery+` WHERE i.organization_id = $1 ORDER BY i.id`, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get invitations"),
+		)
+	}
+	defer rows.Close()
+
+	invitations := make([]models.Invitations, 0)
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get invitations"),
+			)
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get invitations"),
+		)
+	}
+
+	return invitations, nil
+}
+
+func (r InvitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (models.Invitations, error) {
+	const op errors.Op = "repositories.GetInvitationByTokenHash"
+
+	invitation, err := scanInvitation(r.db.ReadConn(ctx).QueryRowContext(ctx, invitationQuery+` WHERE i.token_hash = $1`, tokenHash))
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invitation not found"),
+			errors.KindNotFound(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get invitation"),
+		)
+	}
+
+	return invitation, nil
+}
+
+func (r InvitationRepository) AcceptInvitation(ctx context.Context, invitation models.Invitations, user models.Users) (int32, error) {
+	const op errors.Op = "repositories.AcceptInvitation"
+
+	if invitation.OrganizationID == 0 || user.OrganizationID != invitation.OrganizationID {
+		return 0, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+	defer tx.Rollback()
+
+	// the update doubles as a lock so that the token can only be used once
+	res, err := tx.ExecContext(ctx, `UPDATE invitations
+		SET accepted_at = `+r.dialect.Now()+`, updated_at = `+r.dialect.Now()+`
+		WHERE id = $1 AND accepted_at IS NULL`, invitation.ID)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("invitation %d was already accepted", invitation.ID)),
+			errors.WithMessage("Invitation was already used"),
+			errors.KindConflict(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	userID := user.ID
+	if userID == 0 {
+		userID, err = insertUser(ctx, tx, r.dialect, user)
+		if err != nil {
+			return 0, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to accept invitation"),
+			)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, role_id FROM invitations WHERE id = $2`+
+		r.dialect.Upsert([]string{"user_id", "role_id"}, "", nil), userID, invitation.ID)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE invitations SET accepted_user_id = $1 WHERE id = $2`, userID, invitation.ID)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	return userID, nil
+}
+
+const invitationQuery = `SELECT i.id, i.organization_id, i.email, r.name, i.token_hash, i.expires_at,
+		i.accepted_at, i.accepted_user_id, i.created_at
+		FROM invitations i
+		JOIN roles r ON r.id = i.role_id`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// updatedOrCreated returns the update time of a row, rows never updated
+// report their creation time. The fallback is done here rather than with
+// COALESCE as sqlite returns timestamp expressions as text.
+func updatedOrCreated(updatedAt sql.NullTime, createdAt time.Time) time.Time {
+	if updatedAt.Valid {
+		return updatedAt.Time
+	}
+	return createdAt
+}
+
+func scanInvitation(row rowScanner) (models.Invitations, error) {
+	var invitation models.Invitations
+	var acceptedAt sql.NullTime
+	var acceptedUserID sql.NullInt32
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.TokenHash,
+		&invitation.ExpiresAt,
+		&acceptedAt,
+		&acceptedUserID,
+		&invitation.CreatedAt,
+	)
+	invitation.AcceptedAt = acceptedAt.Time
+	invitation.AcceptedUserID = acceptedUserID.Int32
+	return invitation, err
+}
+
+// ensureRole returns the id of the organization role, creating it when needed.
+func ensureRole(ctx context.Context, tx *database.Tx, d database.Dialect, organizationID int32, role string) (int32, error) {
+	_, err := tx.ExecContext(ctx, `INSERT INTO roles (organization_id, name) VALUES ($1, $2)`+
+		d.Upsert([]string{"organization_id", "name"}, "", nil), organizationID, role)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int32
+	err = tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE organization_id = $1 AND name = $2`,
+		organizationID, role).Scan(&id)
+	return id, err
+}
+
+// insertUser creates the user, with its credentials when there is a
+// password hash.
+func insertUser(ctx context.Context, tx *database.Tx, d database.Dialect, user models.Users) (int32, error) {
+	const op errors.Op = "repositories.insertUser"
+
+	var credentialsID sql.NullInt64
+	if user.Credentials.PassHash != "" {
+		id, err := database.InsertID(ctx, tx, d, `INSERT INTO credentials (salt, passhash) VALUES ($1, $2)`,
+			user.Credentials.Salt, user.Credentials.PassHash)
+		if err != nil {
+			return 0, err
+		}
+		credentialsID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	id, err := database.InsertID(ctx, tx, d, `INSERT INTO users (organization_id, username, email, external_id,
+			given_name, family_name, display_name, active, credentials_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9)`,
+		user.OrganizationID,
+		user.Username,
+		user.Email,
+		user.ExternalID,
+		user.GivenName,
+		user.FamilyName,
+		user.DisplayName,
+		user.Active,
+		credentialsID,
+	)
+	if isConstraintViolation(err) {
+		return 0, userConflict(op, err)
+	}
+
+	return int32(id), err
+}
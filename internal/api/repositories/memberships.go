@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/rs/zerolog"
+)
+
+type MembershipRepository struct {
+	db      *database.DB
+	dialect database.Dialect
+}
+
+func NewMembershipRepository(db *database.DB) *MembershipRepository {
+	return &MembershipRepository{
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
+	}
+}
+
+// membershipQuery reads a row per role of the users, users without roles
+// have a single row with a NULL role.
+const membershipQuery = `SELECT u.organization_id, u.id, u.username, u.email, r.name
+		FROM users u
+		LEFT JOIN user_roles ur ON ur.user_id = u.id
+		LEFT JOIN roles r ON r.id = ur.role_id AND r.organization_id = u.organization_id`
+
+func (r MembershipRepository) GetMembers(ctx context.Context, organizationID int32, cursor pagination.Cursor, size int) (pagination.Page[models.Memberships], error) {
+	const op errors.Op = "repositories.GetMembers"
+
+	if organizationID == 0 {
+		return pagination.Page[models.Memberships]{}, missingOrganization(op)
+	}
+
+	// the users are paginated before joining their roles, which would
+	// split a user over several rows
+	users, err := database.On[models.Users](ctx, r.db).
+		Select("id").
+		Where("organization_id", database.Equal, organizationID).
+		After(cursor).
+		PageSize(size).
+		RunPage()
+	if err != nil {
+		return pagination.Page[models.Memberships]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get members"),
+		)
+	}
+
+	page := pagination.Page[models.Memberships]{
+		Items: make([]models.Memberships, 0),
+		Next:  users.Next,
+		Prev:  users.Prev,
+	}
+	if len(users.Items) == 0 {
+		return page, nil
+	}
+
+	ids := make([]int32, 0, len(users.Items))
+	for _, user := range users.Items {
+		ids = append(ids, user.ID)
+	}
+	list, args := inList(ids, []any{organizationID})
+	page.Items, err = r.getMembers(ctx, `WHERE u.organization_id = $1 AND u.id IN (`+list+`)`, args...)
+	if err != nil {
+		return pagination.Page[models.Memberships]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get members"),
+		)
+	}
+
+	return page, nil
+}
+
+func (r MembershipRepository) GetMember(ctx context.Context, organizationID, userID int32) (models.Memberships, error) {
+	const op errors.Op = "repositories.GetMember"
+
+	if organizationID == 0 {
+		return models.Memberships{}, missingOrganization(op)
+	}
+
+	members, err := r.getMembers(ctx, `WHERE u.organization_id = $1 AND u.id = $2 AND u.deleted_at IS NULL`, organizationID, userID)
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get member"),
+		)
+	}
+	if len(members) == 0 {
+		return models.Memberships{}, memberNotFound(op, organizationID, userID)
+	}
+
+	return members[0], nil
+}
+
+// getMembers reads the users matching where, folding the rows of their
+// roles into one membership per user.
+func (r MembershipRepository) getMembers(ctx context.Context, where string, args ...any) ([]models.Memberships, error) {
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, membershipQuery+`
+		`+where+`
+		ORDER BY u.id, r.name`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]models.Memberships, 0)
+	for rows.Next() {
+		var member models.Memberships
+		var role sql.NullString
+		err := rows.Scan(
+			&member.OrganizationID,
+			&member.UserID,
+			&member.Username,
+			&member.Email,
+			&role,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(members); n == 0 || members[n-1].UserID != member.UserID {
+			member.Roles = make([]string, 0)
+			members = append(members, member)
+		}
+		if role.Valid {
+			last := &members[len(members)-1]
+			last.Roles = append(last.Roles, role.String)
+		}
+	}
+
+	return members, rows.Err()
+}
+
+func (r MembershipRepository) SetMemberRole(ctx context.Context, organizationID, userID int32, role string) error {
+	const op errors.Op = "repositories.SetMemberRole"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL)`,
+		organizationID, userID).Scan(&exists)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+	if !exists {
+		return memberNotFound(op, organizationID, userID)
+	}
+
+	roleID, err := ensureRole(ctx, tx, r.dialect, organizationID, role)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM user_roles
+		WHERE user_id = $1 AND role_id IN (SELECT id FROM roles WHERE organization_id = $2)`,
+		userID, organizationID)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)`, userID, roleID)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+
+	return nil
+}
+
+// RemoveMember soft deletes the user from the organization. Users belong to a
+// single organization, so their credentials are deleted as well.
+func (r MembershipRepository) RemoveMember(ctx context.Context, organizationID, userID int32) error {
+	const op errors.Op = "repositories.RemoveMember"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to remove member"),
+		)
+	}
+	defer tx.Rollback()
+
+	err = deleteUser(ctx, tx, r.dialect, organizationID, userID)
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return memberNotFound(op, organizationID, userID)
+	}
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to remove member"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to remove member"),
+		)
+	}
+
+	return nil
+}
+
+func memberNotFound(op errors.Op, organizationID, userID int32) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(fmt.Errorf("user %d is not a member of organization %d", userID, organizationID)),
+		errors.WithMessage("Member not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type OrganizationRepository struct {
+	db *database.DB
+}
+
+func NewOrganizationRepository(db *database.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (r OrganizationRepository) GetOrganizationByID(ctx context.Context, id int32) (models.Organizations, error) {
+	const op errors.Op = "repositories.GetOrganizationByID"
+
+	return r.getOrganization(ctx, op, "id", id)
+}
+
+func (r OrganizationRepository) GetOrganizationBySlug(ctx context.Context, slug string) (models.Organizations, error) {
+	const op errors.Op = "repositories.GetOrganizationBySlug"
+
+	return r.getOrganization(ctx, op, "slug", slug)
+}
+
+func (r OrganizationRepository) GetOrganizationByHost(ctx context.Context, host string) (models.Organizations, error) {
+	const op errors.Op = "repositories.GetOrganizationByHost"
+
+	return r.getOrganization(ctx, op, "host", host)
+}
+
+func (r OrganizationRepository) getOrganization(ctx context.Context, op errors.Op, column string, value any) (models.Organizations, error) {
+	var org models.Organizations
+	var host sql.NullString
+	err := r.db.ReadConn(ctx).QueryRowContext(ctx, fmt.Sprintf(`SELECT id, slug, name, host, settings
+		FROM organizations
+		WHERE %s = $1`, column), value).Scan(
+		&org.ID,
+		&org.Slug,
+		&org.Name,
+		&host,
+		&org.Settings,
+	)
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Organization not found"),
+			errors.KindNotFound(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+	org.Host = host.String
+
+	return org, nil
+}
+
+// missingOrganization guards the repositories against queries that are not
+// scoped by a tenant.
+func missingOrganization(op errors.Op) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(fmt.Errorf("query is not scoped by an organization")),
+		errors.WithMessage("Organization is required"),
+	)
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type SCIMTokenRepository struct {
+	db      *database.DB
+	dialect database.Dialect
+}
+
+func NewSCIMTokenRepository(db *database.DB) *SCIMTokenRepository {
+	return &SCIMTokenRepository{
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
+	}
+}
+
+const scimTokenColumns = `id, organization_id, description, token_hash,
+		last_used_at, created_at, updated_at`
+
+func (r SCIMTokenRepository) AddSCIMToken(ctx context.Context, token models.SCIMTokens) (models.SCIMTokens, error) {
+	const op errors.Op = "repositories.AddSCIMToken"
+
+	if token.OrganizationID == 0 {
+		return models.SCIMTokens{}, missingOrganization(op)
+	}
+
+	conn := r.db.WriteConn(ctx)
+	id, err := database.InsertID(ctx, conn, r.dialect, `INSERT INTO scim_tokens (organization_id, description, token_hash)
+		VALUES ($1, $2, $3)`, token.OrganizationID, token.Description, token.TokenHash)
+	if err == nil {
+		token, err = scanSCIMToken(conn.QueryRowContext(ctx, `SELECT `+scimTokenColumns+`
+			FROM scim_tokens
+			WHERE id = $1`, id))
+	}
+	if err != nil {
+		return models.SCIMTokens{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SCIM token"),
+		)
+	}
+
+	return token, nil
+}
+
+func (r SCIMTokenRepository) GetSCIMTokens(ctx context.Context, organizationID int32) ([]models.SCIMTokens, error) {
+	const op errors.Op = "repositories.GetSCIMTokens"
+
+	if organizationID == 0 {
+		return nil, missingOrganization(op)
+	}
+
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, `SELECT `+scimTokenColumns+`
+		FROM scim_tokens
+		WHERE organization_id = $1
+		ORDER BY id`, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SCIM tokens"),
+		)
+	}
+	defer rows.Close()
+
+	tokens := make([]models.SCIMTokens, 0)
+	for rows.Next() {
+		token, err := scanSCIMToken(rows)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get SCIM tokens"),
+			)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SCIM tokens"),
+		)
+	}
+
+	return tokens, nil
+}
+
+func (r SCIMTokenRepository) UseSCIMToken(ctx context.Context, tokenHash string) (models.SCIMTokens, error) {
+	const op errors.Op = "repositories.UseSCIMToken"
+
+	conn := r.db.WriteConn(ctx)
+	_, err := conn.ExecContext(ctx, `UPDATE scim_tokens
+		SET last_used_at = `+r.dialect.Now()+`
+		WHERE token_hash = $1`, tokenHash)
+	var token models.SCIMTokens
+	if err == nil {
+		token, err = scanSCIMToken(conn.QueryRowContext(ctx, `SELECT `+scimTokenColumns+`
+			FROM scim_tokens
+			WHERE token_hash = $1`, tokenHash))
+	}
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.SCIMTokens{}, scimTokenNotFound(op, err)
+	}
+	if err != nil {
+		return models.SCIMTokens{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SCIM token"),
+		)
+	}
+
+	return token, nil
+}
+
+func (r SCIMTokenRepository) DeleteSCIMToken(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "repositories.DeleteSCIMToken"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	res, err := r.db.WriteConn(ctx).ExecContext(ctx, `DELETE FROM scim_tokens WHERE organization_id = $1 AND id = $2`, organizationID, id)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete SCIM token"),
+		)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return scimTokenNotFound(op, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func scanSCIMToken(row rowScanner) (models.SCIMTokens, error) {
+	var token models.SCIMTokens
+	var lastUsedAt, updatedAt sql.NullTime
+	err := row.Scan(
+		&token.ID,
+		&token.OrganizationID,
+		&token.Description,
+		&token.TokenHash,
+		&lastUsedAt,
+		&token.CreatedAt,
+		&updatedAt,
+	)
+	token.LastUsedAt = lastUsedAt.Time
+	token.UpdatedAt = updatedOrCreated(updatedAt, token.CreatedAt)
+	return token, err
+}
+
+func scimTokenNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("SCIM token not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
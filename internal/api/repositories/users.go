@@ -1,27 +1,58 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	nerrors "errors"
+	"fmt"
 
 	"github.com/Pedrommb91/go-auth/internal/api/models"
 	"github.com/Pedrommb91/go-auth/pkg/database"
 	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
 )
 
 type UserRepository struct {
-	db *sql.DB
+	db      *database.DB
+	dialect database.Dialect
 }
 
-func NewUserRepository(db *sql.DB) *UserRepository {
+// userQuery reads the user with its credentials, users provisioned by an
+// identity provider may not have any.
+const userQuery = `SELECT u.id, u.organization_id, u.username, u.email,
+		COALESCE(u.external_id, ''), COALESCE(u.given_name, ''), COALESCE(u.family_name, ''),
+		COALESCE(u.display_name, ''), u.active, u.version, u.created_at, u.updated_at,
+		COALESCE(c.id, 0), COALESCE(c.salt, ''), COALESCE(c.passhash, '')
+		FROM users u
+		LEFT JOIN credentials c ON c.id = u.credentials_id`
+
+// userColumns are the fields users can be filtered by.
+var userColumns = map[string]column{
+	"id":           {expr: "u.id", caseExact: true},
+	"username":     {expr: "u.username"},
+	"email":        {expr: "u.email"},
+	"external_id":  {expr: "u.external_id", caseExact: true},
+	"given_name":   {expr: "u.given_name"},
+	"family_name":  {expr: "u.family_name"},
+	"display_name": {expr: "u.display_name"},
+	"active":       {expr: "u.active", caseExact: true},
+}
+
+func NewUserRepository(db *database.DB) *UserRepository {
 	return &UserRepository{
-		db: db,
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
 	}
 }
 
-func (r UserRepository) AddUser(user models.Users) (int64, error) {
+func (r UserRepository) AddUser(ctx context.Context, user models.Users) (int64, error) {
 	const op errors.Op = "repositories.AddUser"
 
-	id, err := database.With[models.Users](r.db).Insert(user)
+	if user.OrganizationID == 0 {
+		return 0, missingOrganization(op)
+	}
+
+	id, err := database.On[models.Users](ctx, r.db).Insert(user)
 	if err != nil {
 		return 0, errors.Build(
 			errors.WithOp(op),
@@ -32,3 +63,397 @@ func (r UserRepository) AddUser(user models.Users) (int64, error) {
 
 	return id, nil
 }
+
+func (r UserRepository) GetUserByUsername(ctx context.Context, organizationID int32, username string) (models.Users, error) {
+	const op errors.Op = "repositories.GetUserByUsername"
+
+	return r.getUser(ctx, op, organizationID, "username", username)
+}
+
+func (r UserRepository) GetUserByEmail(ctx context.Context, organizationID int32, email string) (models.Users, error) {
+	const op errors.Op = "repositories.GetUserByEmail"
+
+	return r.getUser(ctx, op, organizationID, "email", email)
+}
+
+func (r UserRepository) GetUserByID(ctx context.Context, organizationID, id int32) (models.Users, error) {
+	const op errors.Op = "repositories.GetUserByID"
+
+	return r.getUser(ctx, op, organizationID, "id", id)
+}
+
+func (r UserRepository) getUser(ctx context.Context, op errors.Op, organizationID int32, column string, value any) (models.Users, error) {
+	if organizationID == 0 {
+		return models.Users{}, missingOrganization(op)
+	}
+
+	user, err := scanUser(r.db.ReadConn(ctx).QueryRowContext(ctx, fmt.Sprintf(userQuery+`
+		WHERE u.organization_id = $1 AND u.%s = $2 AND u.deleted_at IS NULL`, column), organizationID, value))
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Users{}, userNotFound(op, err)
+	}
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user"),
+		)
+	}
+
+	return user, nil
+}
+
+func (r UserRepository) GetUserRoles(ctx context.Context, organizationID, userID int32) ([]string, error) {
+	const op errors.Op = "repositories.GetUserRoles"
+
+	if organizationID == 0 {
+		return nil, missingOrganization(op)
+	}
+
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, `SELECT r.name
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE r.organization_id = $1 AND ur.user_id = $2
+		ORDER BY r.name`, organizationID, userID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user roles"),
+		)
+	}
+	defer rows.Close()
+
+	roles := make([]string, 0)
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get user roles"),
+			)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user roles"),
+		)
+	}
+
+	return roles, nil
+}
+
+func (r UserRepository) GetUsers(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Users, int, error) {
+	const op errors.Op = "repositories.GetUsers"
+
+	if organizationID == 0 {
+		return nil, 0, missingOrganization(op)
+	}
+
+	where, args, err := whereConditions(op, conditions, userColumns, []any{organizationID})
+	if err != nil {
+		return nil, 0, err
+	}
+	where = "WHERE u.organization_id = $1 AND u.deleted_at IS NULL" + where
+
+	var total int
+	if err := r.db.ReadConn(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM users u `+where, args...).Scan(&total); err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get users"),
+		)
+	}
+
+	args = append(args, page.Limit, page.Offset)
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, fmt.Sprintf(userQuery+`
+		%s
+		ORDER BY u.id
+		LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get users"),
+		)
+	}
+	defer rows.Close()
+
+	users := make([]models.Users, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get users"),
+			)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get users"),
+		)
+	}
+
+	return users, total, nil
+}
+
+func (r UserRepository) ProvisionUser(ctx context.Context, user models.Users) (models.Users, error) {
+	const op errors.Op = "repositories.ProvisionUser"
+
+	if user.OrganizationID == 0 {
+		return models.Users{}, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		)
+	}
+	defer tx.Rollback()
+
+	id, err := insertUser(ctx, tx, r.dialect, user)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		)
+	}
+
+	return r.GetUserByID(database.PinPrimary(ctx), user.OrganizationID, id)
+}
+
+func (r UserRepository) UpdateUser(ctx context.Context, user models.Users) (models.Users, error) {
+	const op errors.Op = "repositories.UpdateUser"
+
+	if user.OrganizationID == 0 {
+		return models.Users{}, missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		)
+	}
+	defer tx.Rollback()
+
+	// a user read at another version was changed since, it is only
+	// overwritten when no version is given
+	res, err := tx.ExecContext(ctx, `UPDATE users SET
+			username = $3,
+			email = $4,
+			external_id = NULLIF($5, ''),
+			given_name = NULLIF($6, ''),
+			family_name = NULLIF($7, ''),
+			display_name = NULLIF($8, ''),
+			active = $9,
+			version = version + 1,
+			updated_at = `+r.dialect.Now()+`
+		WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL AND ($10 = 0 OR version = $10)`,
+		user.OrganizationID,
+		user.ID,
+		user.Username,
+		user.Email,
+		user.ExternalID,
+		user.GivenName,
+		user.FamilyName,
+		user.DisplayName,
+		user.Active,
+		user.Version,
+	)
+	if isConstraintViolation(err) {
+		return models.Users{}, userConflict(op, err)
+	}
+
+	var stale bool
+	if err == nil {
+		var affected int64
+		affected, err = res.RowsAffected()
+		stale = affected == 0
+	}
+
+	var credentialsID sql.NullInt64
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `SELECT credentials_id FROM users WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL`,
+			user.OrganizationID, user.ID).Scan(&credentialsID)
+	}
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Users{}, userNotFound(op, err)
+	}
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		)
+	}
+	if stale {
+		return models.Users{}, database.VersionConflict(op)
+	}
+
+	if user.Credentials.PassHash != "" {
+		if credentialsID.Valid {
+			_, err = tx.ExecContext(ctx, `UPDATE credentials
+				SET salt = $2, passhash = $3, updated_at = `+r.dialect.Now()+`
+				WHERE id = $1`, credentialsID.Int64, user.Credentials.Salt, user.Credentials.PassHash)
+		} else {
+			var id int64
+			id, err = database.InsertID(ctx, tx, r.dialect, `INSERT INTO credentials (salt, passhash) VALUES ($1, $2)`,
+				user.Credentials.Salt, user.Credentials.PassHash)
+			if err == nil {
+				_, err = tx.ExecContext(ctx, `UPDATE users SET credentials_id = $1 WHERE id = $2`,
+					id, user.ID)
+			}
+		}
+		if err != nil {
+			return models.Users{}, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to update user"),
+			)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		)
+	}
+
+	return r.GetUserByID(database.PinPrimary(ctx), user.OrganizationID, user.ID)
+}
+
+func (r UserRepository) DeleteUser(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "repositories.DeleteUser"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	tx, err := r.db.Begin(ctx, nil)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete user"),
+		)
+	}
+	defer tx.Rollback()
+
+	err = deleteUser(ctx, tx, r.dialect, organizationID, id)
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return userNotFound(op, err)
+	}
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete user"),
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete user"),
+		)
+	}
+
+	return nil
+}
+
+func scanUser(row rowScanner) (models.Users, error) {
+	var user models.Users
+	var updatedAt sql.NullTime
+	err := row.Scan(
+		&user.ID,
+		&user.OrganizationID,
+		&user.Username,
+		&user.Email,
+		&user.ExternalID,
+		&user.GivenName,
+		&user.FamilyName,
+		&user.DisplayName,
+		&user.Active,
+		&user.Version,
+		&user.CreatedAt,
+		&updatedAt,
+		&user.Credentials.ID,
+		&user.Credentials.Salt,
+		&user.Credentials.PassHash,
+	)
+	user.UpdatedAt = updatedOrCreated(updatedAt, user.CreatedAt)
+	return user, err
+}
+
+// deleteUser soft deletes the user and its credentials, sql.ErrNoRows is
+// returned when the user does not exist in the organization.
+func deleteUser(ctx context.Context, tx *database.Tx, dialect database.Dialect, organizationID, id int32) error {
+	var credentialsID sql.NullInt32
+	err := tx.QueryRowContext(ctx, `SELECT credentials_id FROM users
+		WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL`,
+		organizationID, id).Scan(&credentialsID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users SET deleted_at = `+dialect.Now()+`
+		WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL`, organizationID, id)
+	if err == nil && credentialsID.Valid {
+		_, err = tx.ExecContext(ctx, `UPDATE credentials SET deleted_at = `+dialect.Now()+`
+			WHERE id = $1 AND deleted_at IS NULL`, credentialsID.Int32)
+	}
+	return err
+}
+
+func userNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("User not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func userConflict(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Username or email already in use"),
+		errors.KindConflict(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+// isConstraintViolation reports integrity constraint violations, such as a
+// duplicated username.
+func isConstraintViolation(err error) bool {
+	return database.IsConstraintViolation(err)
+}
@@ -0,0 +1,413 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/rs/zerolog"
+)
+
+type WebhookRepository struct {
+	db      *database.DB
+	dialect database.Dialect
+}
+
+func NewWebhookRepository(db *database.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db:      db,
+		dialect: database.DialectOf(db.Primary()),
+	}
+}
+
+const webhookColumns = `id, organization_id, url, secret, event_types, active,
+		created_at, updated_at`
+
+const deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts,
+		last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at`
+
+func (r WebhookRepository) AddWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	const op errors.Op = "repositories.AddWebhook"
+
+	if webhook.OrganizationID == 0 {
+		return models.Webhooks{}, missingOrganization(op)
+	}
+
+	conn := r.db.WriteConn(ctx)
+	id, err := database.InsertID(ctx, conn, r.dialect, `INSERT INTO webhooks (organization_id, url, secret, event_types, active)
+		VALUES ($1, $2, $3, $4, $5)`, webhook.OrganizationID, webhook.URL, webhook.Secret,
+		strings.Join(webhook.EventTypes, ","), webhook.Active)
+	if err == nil {
+		webhook, err = scanWebhook(conn.QueryRowContext(ctx, `SELECT `+webhookColumns+`
+			FROM webhooks
+			WHERE id = $1`, id))
+	}
+	if err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create webhook"),
+		)
+	}
+
+	return webhook, nil
+}
+
+func (r WebhookRepository) GetWebhooks(ctx context.Context, organizationID int32) ([]models.Webhooks, error) {
+	const op errors.Op = "repositories.GetWebhooks"
+
+	if organizationID == 0 {
+		return nil, missingOrganization(op)
+	}
+
+	webhooks, err := r.getWebhooks(ctx, `WHERE organization_id = $1`, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhooks"),
+		)
+	}
+
+	return webhooks, nil
+}
+
+func (r WebhookRepository) GetWebhook(ctx context.Context, organizationID, id int32) (models.Webhooks, error) {
+	const op errors.Op = "repositories.GetWebhook"
+
+	if organizationID == 0 {
+		return models.Webhooks{}, missingOrganization(op)
+	}
+
+	webhook, err := scanWebhook(r.db.ReadConn(ctx).QueryRowContext(ctx, `SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE organization_id = $1 AND id = $2`, organizationID, id))
+	if nerrors.Is(err, sql.ErrNoRows) {
+		return models.Webhooks{}, webhookNotFound(op, err)
+	}
+	if err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook"),
+		)
+	}
+
+	return webhook, nil
+}
+
+func (r WebhookRepository) UpdateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	const op errors.Op = "repositories.UpdateWebhook"
+
+	if webhook.OrganizationID == 0 {
+		return models.Webhooks{}, missingOrganization(op)
+	}
+
+	conn := r.db.WriteConn(ctx)
+	res, err := conn.ExecContext(ctx, `UPDATE webhooks
+		SET url = $1, event_types = $2, active = $3, secret = COALESCE(NULLIF($4, ''), secret), updated_at = `+r.dialect.Now()+`
+		WHERE organization_id = $5 AND id = $6`, webhook.URL, strings.Join(webhook.EventTypes, ","), webhook.Active,
+		webhook.Secret, webhook.OrganizationID, webhook.ID)
+	if err == nil {
+		if n, rerr := res.RowsAffected(); rerr == nil && n == 0 {
+			return models.Webhooks{}, webhookNotFound(op, sql.ErrNoRows)
+		}
+		webhook, err = scanWebhook(conn.QueryRowContext(ctx, `SELECT `+webhookColumns+`
+			FROM webhooks
+			WHERE organization_id = $1 AND id = $2`, webhook.OrganizationID, webhook.ID))
+	}
+	if err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update webhook"),
+		)
+	}
+
+	return webhook, nil
+}
+
+func (r WebhookRepository) DeleteWebhook(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "repositories.DeleteWebhook"
+
+	if organizationID == 0 {
+		return missingOrganization(op)
+	}
+
+	res, err := r.db.WriteConn(ctx).ExecContext(ctx, `DELETE FROM webhooks WHERE organization_id = $1 AND id = $2`, organizationID, id)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete webhook"),
+		)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return webhookNotFound(op, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r WebhookRepository) AddDelivery(ctx context.Context, delivery models.WebhookDeliveries) error {
+	const op errors.Op = "repositories.AddDelivery"
+
+	// the event may already be queued for the webhook by an earlier attempt
+	// of the relay, it is queued once whatever the attempts
+	_, err := r.db.WriteConn(ctx).ExecContext(ctx, `INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`+r.dialect.Upsert([]string{"webhook_id", "event_id"}, "", nil),
+		delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Payload,
+		models.DeliveryPending, delivery.NextAttemptAt.UTC())
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to queue webhook delivery"),
+		)
+	}
+
+	return nil
+}
+
+func (r WebhookRepository) GetDeliveries(ctx context.Context, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[models.WebhookDeliveries], error) {
+	const op errors.Op = "repositories.GetDeliveries"
+
+	page, err := database.On[models.WebhookDeliveries](ctx, r.db).
+		From("webhook_deliveries").
+		Where("webhook_id", database.Equal, webhookID).
+		After(cursor).
+		PageSize(size).
+		RunPage()
+	if err != nil {
+		return pagination.Page[models.WebhookDeliveries]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook deliveries"),
+		)
+	}
+
+	return page, nil
+}
+
+func (r WebhookRepository) ClaimDeliveries(ctx context.Context, now, lease time.Time, limit int) ([]models.WebhookDeliveries, error) {
+	const op errors.Op = "repositories.ClaimDeliveries"
+
+	conn := r.db.PrimaryConn(ctx)
+	due, err := r.getDeliveries(ctx, conn, fmt.Sprintf(`WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY id
+		LIMIT %d`, limit), models.DeliveryPending, now.UTC())
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get pending webhook deliveries"),
+		)
+	}
+
+	claimed := make([]models.WebhookDeliveries, 0, len(due))
+	for _, delivery := range due {
+		// the attempts tell whether another dispatcher claimed it meanwhile
+		res, err := conn.ExecContext(ctx, `UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = $1
+			WHERE id = $2 AND attempts = $3 AND status = $4`, lease.UTC(), delivery.ID, delivery.Attempts, models.DeliveryPending)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to claim webhook delivery"),
+			)
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+
+		delivery.Attempts++
+		delivery.Webhook, err = scanWebhook(conn.QueryRowContext(ctx, `SELECT `+webhookColumns+`
+			FROM webhooks
+			WHERE id = $1`, delivery.WebhookID))
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get webhook of delivery"),
+			)
+		}
+		claimed = append(claimed, delivery)
+	}
+
+	return claimed, nil
+}
+
+func (r WebhookRepository) SaveAttempt(ctx context.Context, delivery models.WebhookDeliveries) error {
+	const op errors.Op = "repositories.SaveAttempt"
+
+	var deliveredAt, lastError, lastStatusCode any
+	if !delivery.DeliveredAt.IsZero() {
+		deliveredAt = delivery.DeliveredAt.UTC()
+	}
+	if delivery.LastError != "" {
+		lastError = delivery.LastError
+	}
+	if delivery.LastStatusCode != 0 {
+		lastStatusCode = delivery.LastStatusCode
+	}
+
+	_, err := r.db.WriteConn(ctx).ExecContext(ctx, `UPDATE webhook_deliveries
+		SET status = $1, last_status_code = $2, last_error = $3, next_attempt_at = $4, delivered_at = $5, updated_at = `+r.dialect.Now()+`
+		WHERE id = $6`, delivery.Status, lastStatusCode, lastError, delivery.NextAttemptAt.UTC(), deliveredAt, delivery.ID)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to save webhook delivery"),
+		)
+	}
+
+	return nil
+}
+
+func (r WebhookRepository) Redeliver(ctx context.Context, webhookID int32, id int64, at time.Time) (models.WebhookDeliveries, error) {
+	const op errors.Op = "repositories.Redeliver"
+
+	conn := r.db.WriteConn(ctx)
+	res, err := conn.ExecContext(ctx, `UPDATE webhook_deliveries
+		SET status = $1, attempts = 0, next_attempt_at = $2, updated_at = `+r.dialect.Now()+`
+		WHERE webhook_id = $3 AND id = $4`, models.DeliveryPending, at.UTC(), webhookID, id)
+	if err == nil {
+		if n, rerr := res.RowsAffected(); rerr == nil && n == 0 {
+			return models.WebhookDeliveries{}, deliveryNotFound(op, sql.ErrNoRows)
+		}
+	}
+	var deliveries []models.WebhookDeliveries
+	if err == nil {
+		deliveries, err = r.getDeliveries(ctx, conn, `WHERE id = $1`, id)
+	}
+	if err == nil && len(deliveries) == 0 {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return models.WebhookDeliveries{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to redeliver webhook delivery"),
+		)
+	}
+
+	return deliveries[0], nil
+}
+
+func (r WebhookRepository) getWebhooks(ctx context.Context, where string, args ...any) ([]models.Webhooks, error) {
+	rows, err := r.db.ReadConn(ctx).QueryContext(ctx, `SELECT `+webhookColumns+`
+		FROM webhooks
+		`+where+`
+		ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhooks, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r WebhookRepository) getDeliveries(ctx context.Context, conn database.Querier, where string, args ...any) ([]models.WebhookDeliveries, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT `+deliveryColumns+`
+		FROM webhook_deliveries
+		`+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDeliveries, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhook(row rowScanner) (models.Webhooks, error) {
+	var webhook models.Webhooks
+	var eventTypes string
+	var updatedAt sql.NullTime
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.OrganizationID,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventTypes,
+		&webhook.Active,
+		&webhook.CreatedAt,
+		&updatedAt,
+	)
+	webhook.EventTypes = make([]string, 0)
+	if eventTypes != "" {
+		webhook.EventTypes = strings.Split(eventTypes, ",")
+	}
+	webhook.UpdatedAt = updatedOrCreated(updatedAt, webhook.CreatedAt)
+	return webhook, err
+}
+
+func scanDelivery(row rowScanner) (models.WebhookDeliveries, error) {
+	var delivery models.WebhookDeliveries
+	var lastStatusCode sql.NullInt32
+	var lastError sql.NullString
+	var deliveredAt, updatedAt sql.NullTime
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&lastStatusCode,
+		&lastError,
+		&delivery.NextAttemptAt,
+		&deliveredAt,
+		&delivery.CreatedAt,
+		&updatedAt,
+	)
+	delivery.LastStatusCode = lastStatusCode.Int32
+	delivery.LastError = lastError.String
+	delivery.DeliveredAt = deliveredAt.Time
+	delivery.UpdatedAt = updatedOrCreated(updatedAt, delivery.CreatedAt)
+	return delivery, err
+}
+
+func webhookNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Webhook not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func deliveryNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Webhook delivery not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
@@ -0,0 +1,204 @@
+package scim
+
+// The discovery documents of RFC 7644 section 4 describe what this service
+// provider supports. They are static apart from the locations.
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupport            `json:"bulk"`
+	Filter                filterSupport          `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta"`
+}
+
+type ResourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta"`
+}
+
+type SchemaAttribute struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	MultiValued   bool              `json:"multiValued"`
+	Required      bool              `json:"required"`
+	CaseExact     bool              `json:"caseExact"`
+	Mutability    string            `json:"mutability"`
+	Returned      string            `json:"returned"`
+	Uniqueness    string            `json:"uniqueness"`
+	SubAttributes []SchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+type Schema struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Attributes  []SchemaAttribute `json:"attributes"`
+	Meta        *Meta             `json:"meta"`
+}
+
+func newServiceProviderConfig(baseURL string, maxResults int) ServiceProviderConfig {
+	return ServiceProviderConfig{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          supported{Supported: true},
+		Bulk:           bulkSupport{},
+		Filter:         filterSupport{Supported: true, MaxResults: maxResults},
+		ChangePassword: supported{Supported: true},
+		ETag:           supported{Supported: true},
+		AuthenticationSchemes: []authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with a SCIM token issued for the organization",
+			Primary:     true,
+		}},
+		Meta: &Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     baseURL + "/ServiceProviderConfig",
+		},
+	}
+}
+
+func newResourceTypes(baseURL string) []ResourceType {
+	return []ResourceType{
+		{
+			Schemas:     []string{SchemaResourceType},
+			ID:          "User",
+			Name:        "User",
+			Endpoint:    "/Users",
+			Description: "User of the organization",
+			Schema:      SchemaUser,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/User"},
+		},
+		{
+			Schemas:     []string{SchemaResourceType},
+			ID:          "Group",
+			Name:        "Group",
+			Endpoint:    "/Groups",
+			Description: "Role of the organization",
+			Schema:      SchemaGroup,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/Group"},
+		},
+	}
+}
+
+func newSchemas(baseURL string) []Schema {
+	str := func(name string, required, caseExact bool, uniqueness string) SchemaAttribute {
+		return SchemaAttribute{
+			Name:       name,
+			Type:       "string",
+			Required:   required,
+			CaseExact:  caseExact,
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: uniqueness,
+		}
+	}
+	reference := func(name, mutability string, required bool) SchemaAttribute {
+		return SchemaAttribute{
+			Name:        name,
+			Type:        "complex",
+			MultiValued: true,
+			Required:    required,
+			Mutability:  mutability,
+			Returned:    "default",
+			Uniqueness:  "none",
+			SubAttributes: []SchemaAttribute{
+				str("value", true, true, "none"),
+				{Name: "display", Type: "string", Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+				{Name: "$ref", Type: "reference", Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+			},
+		}
+	}
+
+	name := SchemaAttribute{
+		Name:       "name",
+		Type:       "complex",
+		Mutability: "readWrite",
+		Returned:   "default",
+		Uniqueness: "none",
+		SubAttributes: []SchemaAttribute{
+			{Name: "formatted", Type: "string", Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+			str("givenName", false, false, "none"),
+			str("familyName", false, false, "none"),
+		},
+	}
+	emails := SchemaAttribute{
+		Name:        "emails",
+		Type:        "complex",
+		MultiValued: true,
+		Required:    true,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+		SubAttributes: []SchemaAttribute{
+			str("value", true, false, "server"),
+			str("type", false, false, "none"),
+			{Name: "primary", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+		},
+	}
+	active := SchemaAttribute{Name: "active", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"}
+	password := SchemaAttribute{Name: "password", Type: "string", Mutability: "writeOnly", Returned: "never", Uniqueness: "none"}
+
+	return []Schema{
+		{
+			Schemas:     []string{SchemaSchema},
+			ID:          SchemaUser,
+			Name:        "User",
+			Description: "User of the organization",
+			Attributes: []SchemaAttribute{
+				str("userName", true, false, "server"),
+				str("externalId", false, true, "none"),
+				name,
+				str("displayName", false, false, "none"),
+				emails,
+				active,
+				password,
+				reference("groups", "readOnly", false),
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: baseURL + "/Schemas/" + SchemaUser},
+		},
+		{
+			Schemas:     []string{SchemaSchema},
+			ID:          SchemaGroup,
+			Name:        "Group",
+			Description: "Role of the organization",
+			Attributes: []SchemaAttribute{
+				str("displayName", true, false, "server"),
+				str("externalId", false, true, "none"),
+				reference("members", "readWrite", false),
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: baseURL + "/Schemas/" + SchemaGroup},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package scim
+
+import (
+	nerrors "errors"
+	"strconv"
+
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// scimTypes of RFC 7644 section 3.12 used by this package.
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeMutability    = "mutability"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+// typedError carries the scimType reported to the client.
+type typedError struct {
+	scimType string
+	err      error
+}
+
+func (e *typedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *typedError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(op errors.Op, scimType string, err error, message string) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(&typedError{scimType: scimType, err: err}),
+		errors.WithMessage(message),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func invalidValue(op errors.Op, err error, message string) error {
+	return badRequest(op, scimTypeInvalidValue, err, message)
+}
+
+func invalidPath(op errors.Op, err error, message string) error {
+	return badRequest(op, scimTypeInvalidPath, err, message)
+}
+
+func preconditionFailed(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Resource version does not match"),
+		errors.KindPreconditionFailed(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+// ErrorHandler writes the errors of the SCIM routes in the format of
+// RFC 7644 section 3.12 and clears them so that the api error handler does
+// not answer again.
+func ErrorHandler(l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+		defer func() {
+			c.Errors = c.Errors[:0]
+		}()
+
+		for _, v := range c.Errors {
+			err, ok := errors.GetFirstNestedError(v.Err).(*errors.Error)
+			if !ok {
+				l.Error("Unexpected error: %s", v.Err)
+				continue
+			}
+
+			// updates of a stale version fail their precondition, RFC 7644
+			// section 3.14
+			kind := err.Kind
+			if kind == errors.Conflict && nerrors.Is(err.Err, database.ErrVersionConflict) {
+				kind = errors.PreconditionFailed
+			}
+
+			res := Error{
+				Schemas: []string{SchemaError},
+				Status:  strconv.Itoa(kind.Int()),
+				Detail:  err.Message,
+			}
+			var typed *typedError
+			if nerrors.As(err.Err, &typed) {
+				res.ScimType = typed.scimType
+			} else if kind == errors.Conflict {
+				res.ScimType = scimTypeUniqueness
+			}
+
+			c.Header("Content-Type", ContentType)
+			c.JSON(kind.Int(), res)
+			return
+		}
+	}
+}
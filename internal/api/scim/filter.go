@@ -0,0 +1,143 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// attribute maps a filterable SCIM attribute to a model field.
+type attribute struct {
+	field string
+	// id attributes are strings in SCIM and integers in the database
+	id bool
+}
+
+// userAttributes and groupAttributes are keyed by the lower case attribute
+// path, attribute names are case insensitive.
+var userAttributes = map[string]attribute{
+	"id":              {field: "id", id: true},
+	"externalid":      {field: "external_id"},
+	"username":        {field: "username"},
+	"displayname":     {field: "display_name"},
+	"name.givenname":  {field: "given_name"},
+	"name.familyname": {field: "family_name"},
+	"emails":          {field: "email"},
+	"emails.value":    {field: "email"},
+	"active":          {field: "active"},
+}
+
+var groupAttributes = map[string]attribute{
+	"id":          {field: "id", id: true},
+	"externalid":  {field: "external_id"},
+	"displayname": {field: "name"},
+}
+
+var filterOperators = map[string]bool{
+	models.OperatorEqual:      true,
+	models.OperatorNotEqual:   true,
+	models.OperatorContains:   true,
+	models.OperatorStartsWith: true,
+	models.OperatorEndsWith:   true,
+	models.OperatorPresent:    true,
+}
+
+// parseFilter supports the filters identity providers send while
+// provisioning: comparisons such as userName eq "jane" joined with and.
+func parseFilter(filter string, attributes map[string]attribute) ([]models.Condition, error) {
+	const op errors.Op = "scim.parseFilter"
+
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, badRequest(op, scimTypeInvalidFilter, err, "Invalid filter")
+	}
+
+	conditions := make([]models.Condition, 0)
+	for len(tokens) > 0 {
+		if len(conditions) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return nil, badRequest(op, scimTypeInvalidFilter,
+					fmt.Errorf("unsupported logical operator %q", tokens[0]), "Only and is supported in filters")
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 2 {
+			return nil, badRequest(op, scimTypeInvalidFilter, fmt.Errorf("incomplete filter %q", filter), "Invalid filter")
+		}
+
+		attr, ok := attributes[strings.ToLower(tokens[0])]
+		if !ok {
+			return nil, badRequest(op, scimTypeInvalidFilter,
+				fmt.Errorf("attribute %q cannot be filtered", tokens[0]), "Unsupported filter attribute")
+		}
+		operator := strings.ToLower(tokens[1])
+		if !filterOperators[operator] {
+			return nil, badRequest(op, scimTypeInvalidFilter,
+				fmt.Errorf("unsupported operator %q", tokens[1]), "Unsupported filter operator")
+		}
+
+		cond := models.Condition{Field: attr.field, Operator: operator}
+		if operator == models.OperatorPresent {
+			conditions = append(conditions, cond)
+			tokens = tokens[2:]
+			continue
+		}
+		if len(tokens) < 3 {
+			return nil, badRequest(op, scimTypeInvalidFilter, fmt.Errorf("missing value in %q", filter), "Invalid filter")
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(tokens[2]), &value); err != nil {
+			return nil, badRequest(op, scimTypeInvalidFilter, err, "Invalid filter value")
+		}
+		cond.Value = value
+		if attr.id {
+			// an id that cannot be parsed matches no resource
+			s, _ := value.(string)
+			id, _ := parseID(s)
+			cond.Value = id
+		}
+		conditions = append(conditions, cond)
+		tokens = tokens[3:]
+	}
+
+	return conditions, nil
+}
+
+// tokenize splits the filter on spaces, keeping quoted strings together.
+func tokenize(filter string) ([]string, error) {
+	tokens := make([]string, 0)
+	var current strings.Builder
+	quoted, escaped := false, false
+
+	for _, r := range filter {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && r == ' ':
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		case !quoted && (r == '(' || r == ')' || r == '['):
+			return nil, fmt.Errorf("grouping is not supported")
+		}
+		current.WriteRune(r)
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated string in %q", filter)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,102 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    []models.Condition
+		wantErr bool
+	}{
+		{
+			name:   "Empty filter",
+			filter: "",
+			want:   []models.Condition{},
+		},
+		{
+			name:   "User name equals",
+			filter: `userName eq "jane@example.com"`,
+			want: []models.Condition{
+				{Field: "username", Operator: models.OperatorEqual, Value: "jane@example.com"},
+			},
+		},
+		{
+			name:   "Case insensitive attribute and operator",
+			filter: `USERNAME Eq "jane"`,
+			want: []models.Condition{
+				{Field: "username", Operator: models.OperatorEqual, Value: "jane"},
+			},
+		},
+		{
+			name:   "Conditions joined with and",
+			filter: `name.givenName sw "Ja" and active eq true and emails pr`,
+			want: []models.Condition{
+				{Field: "given_name", Operator: models.OperatorStartsWith, Value: "Ja"},
+				{Field: "active", Operator: models.OperatorEqual, Value: true},
+				{Field: "email", Operator: models.OperatorPresent},
+			},
+		},
+		{
+			name:   "Quoted value with spaces and escaped quotes",
+			filter: `displayName eq "Jane \"JD\" Doe"`,
+			want: []models.Condition{
+				{Field: "display_name", Operator: models.OperatorEqual, Value: `Jane "JD" Doe`},
+			},
+		},
+		{
+			name:   "Id is converted",
+			filter: `id eq "12"`,
+			want: []models.Condition{
+				{Field: "id", Operator: models.OperatorEqual, Value: int32(12)},
+			},
+		},
+		{
+			name:    "Or is not supported",
+			filter:  `userName eq "a" or userName eq "b"`,
+			wantErr: true,
+		},
+		{
+			name:    "Grouping is not supported",
+			filter:  `(userName eq "a")`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown attribute",
+			filter:  `nickName eq "a"`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown operator",
+			filter:  `userName gt "a"`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing value",
+			filter:  `userName eq`,
+			wantErr: true,
+		},
+		{
+			name:    "Unterminated string",
+			filter:  `userName eq "a`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter, userAttributes)
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, errors.BadRequest))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
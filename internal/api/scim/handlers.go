@@ -0,0 +1,734 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/services"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	s   services.ProvisioningServiceInterface
+	cfg config.SCIM
+}
+
+// RegisterHandlers adds the SCIM endpoints to the router. Discovery is
+// public, the resources need a SCIM token that also selects the tenant.
+func RegisterHandlers(r gin.IRouter, s services.ProvisioningServiceInterface, tokens services.SCIMTokenServiceInterface, cfg config.SCIM, l logger.Interface) {
+	h := &handler{
+		s:   s,
+		cfg: cfg,
+	}
+
+	r.Use(ErrorHandler(l))
+
+	r.GET("/ServiceProviderConfig", h.serviceProviderConfig)
+	r.GET("/ResourceTypes", h.resourceTypes)
+	r.GET("/ResourceTypes/:id", h.resourceType)
+	r.GET("/Schemas", h.schemas)
+	r.GET("/Schemas/:id", h.schema)
+
+	resources := r.Group("", Authenticate(tokens))
+	resources.GET("/Users", h.listUsers)
+	resources.POST("/Users", h.createUser)
+	resources.GET("/Users/:id", h.getUser)
+	resources.PUT("/Users/:id", h.replaceUser)
+	resources.PATCH("/Users/:id", h.patchUser)
+	resources.DELETE("/Users/:id", h.deleteUser)
+	resources.GET("/Groups", h.listGroups)
+	resources.POST("/Groups", h.createGroup)
+	resources.GET("/Groups/:id", h.getGroup)
+	resources.PUT("/Groups/:id", h.replaceGroup)
+	resources.PATCH("/Groups/:id", h.patchGroup)
+	resources.DELETE("/Groups/:id", h.deleteGroup)
+}
+
+// Authenticate resolves the tenant of the request from its SCIM token.
+func Authenticate(tokens services.SCIMTokenServiceInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op errors.Op = "scim.Authenticate"
+
+		token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		org, err := tokens.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.Error(errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to authenticate SCIM client"),
+			))
+			c.Abort()
+			return
+		}
+
+		middlewares.SetTenant(c, org)
+	}
+}
+
+func (h *handler) serviceProviderConfig(c *gin.Context) {
+	respond(c, http.StatusOK, newServiceProviderConfig(h.cfg.BaseURL, h.cfg.MaxResults))
+}
+
+func (h *handler) resourceTypes(c *gin.Context) {
+	types := newResourceTypes(h.cfg.BaseURL)
+	resources := make([]any, 0, len(types))
+	for _, t := range types {
+		resources = append(resources, t)
+	}
+	respond(c, http.StatusOK, newListResponse(resources, len(resources), 1))
+}
+
+func (h *handler) resourceType(c *gin.Context) {
+	const op errors.Op = "scim.resourceType"
+
+	for _, t := range newResourceTypes(h.cfg.BaseURL) {
+		if t.ID == c.Param("id") {
+			respond(c, http.StatusOK, t)
+			return
+		}
+	}
+	c.Error(notFound(op, "Resource type not found"))
+}
+
+func (h *handler) schemas(c *gin.Context) {
+	schemas := newSchemas(h.cfg.BaseURL)
+	resources := make([]any, 0, len(schemas))
+	for _, s := range schemas {
+		resources = append(resources, s)
+	}
+	respond(c, http.StatusOK, newListResponse(resources, len(resources), 1))
+}
+
+func (h *handler) schema(c *gin.Context) {
+	const op errors.Op = "scim.schema"
+
+	for _, s := range newSchemas(h.cfg.BaseURL) {
+		if s.ID == c.Param("id") {
+			respond(c, http.StatusOK, s)
+			return
+		}
+	}
+	c.Error(notFound(op, "Schema not found"))
+}
+
+func (h *handler) listUsers(c *gin.Context) {
+	const op errors.Op = "scim.listUsers"
+
+	org, _ := middlewares.GetTenant(c)
+
+	conditions, err := parseFilter(c.Query("filter"), userAttributes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, startIndex, err := h.page(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	users, total, err := h.s.GetUsers(c.Request.Context(), org.ID, conditions, page)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to list users"),
+		))
+		return
+	}
+
+	groups := map[int32][]models.Groups{}
+	if len(users) > 0 && !excluded(c, "groups") {
+		ids := make([]int32, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		groups, err = h.s.GetUserGroups(c.Request.Context(), org.ID, ids)
+		if err != nil {
+			c.Error(errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to list users"),
+			))
+			return
+		}
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, newUser(user, groups[user.ID], h.cfg.BaseURL))
+	}
+	respond(c, http.StatusOK, newListResponse(resources, total, startIndex))
+}
+
+func (h *handler) createUser(c *gin.Context) {
+	const op errors.Op = "scim.createUser"
+
+	org, _ := middlewares.GetTenant(c)
+
+	var body User
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := validateUser(body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	user, err := h.s.CreateUser(c.Request.Context(), body.model(org.ID, 0), body.Password)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		))
+		return
+	}
+
+	res := newUser(user, nil, h.cfg.BaseURL)
+	c.Header("Location", res.Meta.Location)
+	respondUser(c, http.StatusCreated, res)
+}
+
+func (h *handler) getUser(c *gin.Context) {
+	const op errors.Op = "scim.getUser"
+
+	res, err := h.user(c)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user"),
+		))
+		return
+	}
+
+	respondUser(c, http.StatusOK, res)
+}
+
+func (h *handler) replaceUser(c *gin.Context) {
+	const op errors.Op = "scim.replaceUser"
+
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "User not found")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body User
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := validateUser(body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	user := body.model(org.ID, id)
+	user.Version = version
+	h.saveUser(c, op, user, body.Password)
+}
+
+func (h *handler) patchUser(c *gin.Context) {
+	const op errors.Op = "scim.patchUser"
+
+	org, _ := middlewares.GetTenant(c)
+
+	var body PatchRequest
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	current, err := h.user(c)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to patch user"),
+		))
+		return
+	}
+
+	// the patch is applied to the version read, so it is refused when the
+	// user changes before it is saved
+	version, _ := parseETag(current.Meta.Version)
+	if err := matchVersion(c, version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := applyUserPatch(&current, body.Operations); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := validateUser(current); err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, _ := parseID(current.ID)
+	user := current.model(org.ID, id)
+	user.Version = version
+	h.saveUser(c, op, user, current.Password)
+}
+
+func (h *handler) deleteUser(c *gin.Context) {
+	const op errors.Op = "scim.deleteUser"
+
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "User not found")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if c.GetHeader("If-Match") != "" {
+		user, err := h.s.GetUser(c.Request.Context(), org.ID, id)
+		if err == nil {
+			err = matchVersion(c, user.Version)
+		}
+		if err != nil {
+			c.Error(errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to delete user"),
+			))
+			return
+		}
+	}
+
+	if err := h.s.DeleteUser(c.Request.Context(), org.ID, id); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete user"),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// user reads the user of the id path parameter as a SCIM resource.
+func (h *handler) user(c *gin.Context) (User, error) {
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "User not found")
+	if err != nil {
+		return User{}, err
+	}
+
+	user, err := h.s.GetUser(c.Request.Context(), org.ID, id)
+	if err != nil {
+		return User{}, err
+	}
+
+	groups, err := h.s.GetUserGroups(c.Request.Context(), org.ID, []int32{id})
+	if err != nil {
+		return User{}, err
+	}
+
+	return newUser(user, groups[id], h.cfg.BaseURL), nil
+}
+
+func (h *handler) saveUser(c *gin.Context, op errors.Op, user models.Users, password string) {
+	user, err := h.s.ReplaceUser(c.Request.Context(), user, password)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		))
+		return
+	}
+
+	groups, err := h.s.GetUserGroups(c.Request.Context(), user.OrganizationID, []int32{user.ID})
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		))
+		return
+	}
+
+	respondUser(c, http.StatusOK, newUser(user, groups[user.ID], h.cfg.BaseURL))
+}
+
+func (h *handler) listGroups(c *gin.Context) {
+	const op errors.Op = "scim.listGroups"
+
+	org, _ := middlewares.GetTenant(c)
+
+	conditions, err := parseFilter(c.Query("filter"), groupAttributes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, startIndex, err := h.page(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	groups, total, err := h.s.GetGroups(c.Request.Context(), org.ID, conditions, page)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to list groups"),
+		))
+		return
+	}
+
+	withoutMembers := excluded(c, "members")
+	resources := make([]any, 0, len(groups))
+	for _, group := range groups {
+		res := newGroup(group, h.cfg.BaseURL)
+		if withoutMembers {
+			res.Members = nil
+		}
+		resources = append(resources, res)
+	}
+	respond(c, http.StatusOK, newListResponse(resources, total, startIndex))
+}
+
+func (h *handler) createGroup(c *gin.Context) {
+	const op errors.Op = "scim.createGroup"
+
+	org, _ := middlewares.GetTenant(c)
+
+	var body Group
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+	group, err := groupModel(body, org.ID, 0)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	group, err = h.s.CreateGroup(c.Request.Context(), group)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create group"),
+		))
+		return
+	}
+
+	res := newGroup(group, h.cfg.BaseURL)
+	c.Header("Location", res.Meta.Location)
+	respond(c, http.StatusCreated, res)
+}
+
+func (h *handler) getGroup(c *gin.Context) {
+	const op errors.Op = "scim.getGroup"
+
+	res, err := h.group(c)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get group"),
+		))
+		return
+	}
+
+	if excluded(c, "members") {
+		res.Members = nil
+	}
+	respond(c, http.StatusOK, res)
+}
+
+func (h *handler) replaceGroup(c *gin.Context) {
+	const op errors.Op = "scim.replaceGroup"
+
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "Group not found")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body Group
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+	group, err := groupModel(body, org.ID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.saveGroup(c, op, group)
+}
+
+func (h *handler) patchGroup(c *gin.Context) {
+	const op errors.Op = "scim.patchGroup"
+
+	org, _ := middlewares.GetTenant(c)
+
+	var body PatchRequest
+	if err := bindResource(c, &body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	current, err := h.group(c)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to patch group"),
+		))
+		return
+	}
+
+	if err := applyGroupPatch(&current, body.Operations); err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, _ := parseID(current.ID)
+	group, err := groupModel(current, org.ID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.saveGroup(c, op, group)
+}
+
+func (h *handler) deleteGroup(c *gin.Context) {
+	const op errors.Op = "scim.deleteGroup"
+
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "Group not found")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.s.DeleteGroup(c.Request.Context(), org.ID, id); err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete group"),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// group reads the group of the id path parameter as a SCIM resource.
+func (h *handler) group(c *gin.Context) (Group, error) {
+	org, _ := middlewares.GetTenant(c)
+
+	id, err := resourceID(c, "Group not found")
+	if err != nil {
+		return Group{}, err
+	}
+
+	group, err := h.s.GetGroup(c.Request.Context(), org.ID, id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	return newGroup(group, h.cfg.BaseURL), nil
+}
+
+func (h *handler) saveGroup(c *gin.Context, op errors.Op, group models.Groups) {
+	group, err := h.s.ReplaceGroup(c.Request.Context(), group)
+	if err != nil {
+		c.Error(errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		))
+		return
+	}
+
+	respond(c, http.StatusOK, newGroup(group, h.cfg.BaseURL))
+}
+
+// page reads the 1-based startIndex and count query parameters, count is
+// capped by the configured maximum.
+func (h *handler) page(c *gin.Context) (models.Page, int, error) {
+	const op errors.Op = "scim.page"
+
+	startIndex, count := 1, h.cfg.MaxResults
+	if v := c.Query("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return models.Page{}, 0, invalidValue(op, err, "Invalid startIndex")
+		}
+		if n > 1 {
+			startIndex = n
+		}
+	}
+	if v := c.Query("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return models.Page{}, 0, invalidValue(op, err, "Invalid count")
+		}
+		if n < 0 {
+			n = 0
+		}
+		if n < count {
+			count = n
+		}
+	}
+
+	return models.Page{Offset: startIndex - 1, Limit: count}, startIndex, nil
+}
+
+func newListResponse(resources []any, total, startIndex int) ListResponse {
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func groupModel(g Group, organizationID, id int32) (models.Groups, error) {
+	const op errors.Op = "scim.groupModel"
+
+	if g.DisplayName == "" {
+		return models.Groups{}, invalidValue(op, fmt.Errorf("missing displayName"), "Attribute displayName is required")
+	}
+
+	return g.model(organizationID, id)
+}
+
+func validateUser(u User) error {
+	const op errors.Op = "scim.validateUser"
+
+	if u.UserName == "" {
+		return invalidValue(op, fmt.Errorf("missing userName"), "Attribute userName is required")
+	}
+	if len(u.Emails) == 0 {
+		return invalidValue(op, fmt.Errorf("missing emails"), "Attribute emails is required")
+	}
+	for _, email := range u.Emails {
+		if _, err := mail.ParseAddress(email.Value); err != nil {
+			return invalidValue(op, err, "Invalid email")
+		}
+	}
+
+	return nil
+}
+
+func bindResource(c *gin.Context, v any) error {
+	const op errors.Op = "scim.bindResource"
+
+	if err := c.ShouldBindJSON(v); err != nil {
+		return badRequest(op, scimTypeInvalidSyntax, err, "Invalid request body")
+	}
+	return nil
+}
+
+// resourceID parses the id path parameter, ids that cannot exist are
+// reported as not found.
+func resourceID(c *gin.Context, message string) (int32, error) {
+	const op errors.Op = "scim.resourceID"
+
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		return 0, notFound(op, message)
+	}
+	return id, nil
+}
+
+func excluded(c *gin.Context, attribute string) bool {
+	for _, v := range strings.Split(c.Query("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+func respond(c *gin.Context, status int, v any) {
+	c.Header("Content-Type", ContentType)
+	c.JSON(status, v)
+}
+
+// respondUser writes the user with its version as ETag.
+func respondUser(c *gin.Context, status int, res User) {
+	if res.Meta != nil && res.Meta.Version != "" {
+		c.Header("ETag", res.Meta.Version)
+	}
+	respond(c, status, res)
+}
+
+// ifMatch returns the version of the If-Match header, zero when the header
+// is missing or matches any version.
+func ifMatch(c *gin.Context) (int32, error) {
+	const op errors.Op = "scim.ifMatch"
+
+	tag := strings.TrimSpace(c.GetHeader("If-Match"))
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+
+	version, err := parseETag(tag)
+	if err != nil {
+		return 0, preconditionFailed(op, err)
+	}
+	return version, nil
+}
+
+// matchVersion refuses the request when its If-Match header does not match
+// the current version of the resource.
+func matchVersion(c *gin.Context, current int32) error {
+	const op errors.Op = "scim.matchVersion"
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != current {
+		return preconditionFailed(op, fmt.Errorf("version %d is not the current version %d", version, current))
+	}
+	return nil
+}
+
+func notFound(op errors.Op, message string) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(fmt.Errorf("%s", strings.ToLower(message))),
+		errors.WithMessage(message),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
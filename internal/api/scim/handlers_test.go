@@ -0,0 +1,481 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testToken   = "scim-token"
+	testBaseURL = "https://idp.example.com/scim/v2"
+)
+
+var testOrganization = models.Organizations{ID: 2, Name: "acme"}
+
+func newTestRouter(t *testing.T, s *mocks.ProvisioningServiceInterface) *gin.Engine {
+	tokens := mocks.NewSCIMTokenServiceInterface(t)
+	tokens.On("Authenticate", mock.Anything, testToken).Return(testOrganization, nil).Maybe()
+	tokens.On("Authenticate", mock.Anything, mock.Anything).Return(models.Organizations{}, errors.Build(
+		errors.WithError(fmt.Errorf("unknown SCIM token")),
+		errors.WithMessage("Invalid SCIM token"),
+		errors.KindUnauthorized(),
+	)).Maybe()
+
+	r := gin.New()
+	RegisterHandlers(r.Group("/scim/v2"), s, tokens, config.SCIM{BaseURL: testBaseURL, MaxResults: 50}, logger.New("info"))
+	return r
+}
+
+func serve(r *gin.Engine, method, path, token, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", ContentType)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRegisterHandlers_Authentication(t *testing.T) {
+	r := newTestRouter(t, mocks.NewProvisioningServiceInterface(t))
+
+	w := serve(r, http.MethodGet, "/scim/v2/Users", "wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+
+	var got Error
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, Error{
+		Schemas: []string{SchemaError},
+		Status:  "401",
+		Detail:  "Invalid SCIM token",
+	}, got)
+
+	// discovery does not need a token
+	w = serve(r, http.MethodGet, "/scim/v2/ServiceProviderConfig", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(r, http.MethodGet, "/scim/v2/ResourceTypes/User", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(r, http.MethodGet, "/scim/v2/Schemas/unknown", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRegisterHandlers_Users(t *testing.T) {
+	jane := models.Users{
+		ID:             7,
+		OrganizationID: testOrganization.ID,
+		Username:       "jane",
+		Email:          "jane@example.com",
+		GivenName:      "Jane",
+		FamilyName:     "Doe",
+		Active:         true,
+	}
+	admins := models.Groups{ID: 3, OrganizationID: testOrganization.ID, Name: "admins"}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		setup        func(s *mocks.ProvisioningServiceInterface)
+		expectedCode int
+		expected     func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "List users with filter and pagination",
+			method: http.MethodGet,
+			path:   `/scim/v2/Users?filter=userName+eq+"jane"&startIndex=3&count=100`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUsers", mock.Anything, testOrganization.ID,
+					[]models.Condition{{Field: "username", Operator: models.OperatorEqual, Value: "jane"}},
+					models.Page{Offset: 2, Limit: 50}).
+					Return([]models.Users{jane}, 3, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).
+					Return(map[int32][]models.Groups{7: {admins}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected: func(t *testing.T, body []byte) {
+				var got struct {
+					ListResponse
+					Resources []User `json:"Resources"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, 3, got.TotalResults)
+				assert.Equal(t, 3, got.StartIndex)
+				assert.Equal(t, 1, got.ItemsPerPage)
+				assert.Equal(t, "7", got.Resources[0].ID)
+				assert.Equal(t, "jane", got.Resources[0].UserName)
+				assert.Equal(t, "admins", got.Resources[0].Groups[0].Display)
+			},
+		},
+		{
+			name:         "Invalid filter",
+			method:       http.MethodGet,
+			path:         `/scim/v2/Users?filter=nickName+eq+"jane"`,
+			setup:        func(s *mocks.ProvisioningServiceInterface) {},
+			expectedCode: http.StatusBadRequest,
+			expected: func(t *testing.T, body []byte) {
+				var got Error
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, scimTypeInvalidFilter, got.ScimType)
+			},
+		},
+		{
+			name:   "Create user without password",
+			method: http.MethodPost,
+			path:   "/scim/v2/Users",
+			body: `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"jane",
+				"name":{"givenName":"Jane","familyName":"Doe"},
+				"emails":[{"value":"jane@example.com","primary":true}]}`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("CreateUser", mock.Anything, models.Users{
+					OrganizationID: testOrganization.ID,
+					Username:       "jane",
+					Email:          "jane@example.com",
+					GivenName:      "Jane",
+					FamilyName:     "Doe",
+					Active:         true,
+				}, "").Return(jane, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expected: func(t *testing.T, body []byte) {
+				var got User
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, "7", got.ID)
+				assert.Equal(t, testBaseURL+"/Users/7", got.Meta.Location)
+			},
+		},
+		{
+			name:         "Create user without email",
+			method:       http.MethodPost,
+			path:         "/scim/v2/Users",
+			body:         `{"userName":"jane"}`,
+			setup:        func(s *mocks.ProvisioningServiceInterface) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Create existing user",
+			method: http.MethodPost,
+			path:   "/scim/v2/Users",
+			body:   `{"userName":"jane","emails":[{"value":"jane@example.com"}]}`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("CreateUser", mock.Anything, mock.Anything, "").Return(models.Users{}, errors.Build(
+					errors.WithError(fmt.Errorf("duplicate key")),
+					errors.WithMessage("Username or email already in use"),
+					errors.KindConflict(),
+				))
+			},
+			expectedCode: http.StatusConflict,
+			expected: func(t *testing.T, body []byte) {
+				var got Error
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, scimTypeUniqueness, got.ScimType)
+			},
+		},
+		{
+			name:         "Get user with invalid id",
+			method:       http.MethodGet,
+			path:         "/scim/v2/Users/abc",
+			setup:        func(s *mocks.ProvisioningServiceInterface) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "Deactivate user",
+			method: http.MethodPatch,
+			path:   "/scim/v2/Users/7",
+			body:   `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				inactive := jane
+				inactive.Active = false
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).Return(map[int32][]models.Groups{}, nil)
+				s.On("ReplaceUser", mock.Anything, inactive, "").Return(inactive, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected: func(t *testing.T, body []byte) {
+				var got User
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.False(t, *got.Active)
+			},
+		},
+		{
+			name:   "Delete unknown user",
+			method: http.MethodDelete,
+			path:   "/scim/v2/Users/8",
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("DeleteUser", mock.Anything, testOrganization.ID, int32(8)).Return(errors.Build(
+					errors.WithError(fmt.Errorf("no rows")),
+					errors.WithMessage("User not found"),
+					errors.KindNotFound(),
+				))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mocks.NewProvisioningServiceInterface(t)
+			tt.setup(s)
+			r := newTestRouter(t, s)
+
+			w := serve(r, tt.method, tt.path, testToken, tt.body)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+			if tt.expected != nil {
+				tt.expected(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestRegisterHandlers_UserVersions(t *testing.T) {
+	jane := models.Users{
+		ID:             7,
+		OrganizationID: testOrganization.ID,
+		Username:       "jane",
+		Email:          "jane@example.com",
+		Active:         true,
+		Version:        3,
+	}
+	replaced := jane
+	replaced.Version = 4
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		ifMatch      string
+		setup        func(s *mocks.ProvisioningServiceInterface)
+		expectedCode int
+		expectedETag string
+	}{
+		{
+			name:   "Get user returns its version",
+			method: http.MethodGet,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).Return(map[int32][]models.Groups{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `W/"3"`,
+		},
+		{
+			name:    "Replace user at its version",
+			method:  http.MethodPut,
+			body:    `{"userName":"jane","emails":[{"value":"jane@example.com"}]}`,
+			ifMatch: `W/"3"`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("ReplaceUser", mock.Anything, mock.MatchedBy(func(u models.Users) bool {
+					return u.Version == 3
+				}), "").Return(replaced, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).Return(map[int32][]models.Groups{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `W/"4"`,
+		},
+		{
+			name:    "Replace user at a stale version",
+			method:  http.MethodPut,
+			body:    `{"userName":"jane","emails":[{"value":"jane@example.com"}]}`,
+			ifMatch: `W/"2"`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("ReplaceUser", mock.Anything, mock.Anything, "").Return(models.Users{}, database.VersionConflict("test"))
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "Replace user with an invalid entity tag",
+			method:       http.MethodPut,
+			body:         `{"userName":"jane","emails":[{"value":"jane@example.com"}]}`,
+			ifMatch:      "3",
+			setup:        func(s *mocks.ProvisioningServiceInterface) {},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Patch user at a stale version",
+			method:  http.MethodPatch,
+			body:    `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			ifMatch: `W/"2"`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).Return(map[int32][]models.Groups{}, nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Patch user keeps the version read",
+			method:  http.MethodPatch,
+			body:    `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			ifMatch: "*",
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+				s.On("GetUserGroups", mock.Anything, testOrganization.ID, []int32{7}).Return(map[int32][]models.Groups{}, nil)
+				s.On("ReplaceUser", mock.Anything, mock.MatchedBy(func(u models.Users) bool {
+					return u.Version == 3 && !u.Active
+				}), "").Return(replaced, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `W/"4"`,
+		},
+		{
+			name:    "Delete user at a stale version",
+			method:  http.MethodDelete,
+			ifMatch: `"2"`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Delete user at its version",
+			method:  http.MethodDelete,
+			ifMatch: `W/"3"`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetUser", mock.Anything, testOrganization.ID, int32(7)).Return(jane, nil)
+				s.On("DeleteUser", mock.Anything, testOrganization.ID, int32(7)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mocks.NewProvisioningServiceInterface(t)
+			tt.setup(s)
+			r := newTestRouter(t, s)
+
+			req, _ := http.NewRequest(tt.method, "/scim/v2/Users/7", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", ContentType)
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			if tt.expectedETag != "" {
+				var got User
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, tt.expectedETag, got.Meta.Version)
+			}
+		})
+	}
+}
+
+func TestRegisterHandlers_Groups(t *testing.T) {
+	admins := models.Groups{
+		ID:             3,
+		OrganizationID: testOrganization.ID,
+		Name:           "admins",
+		Members:        []models.GroupMembers{{UserID: 7, Username: "jane"}, {UserID: 8, Username: "john"}},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		setup        func(s *mocks.ProvisioningServiceInterface)
+		expectedCode int
+		expected     func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "List groups without members",
+			method: http.MethodGet,
+			path:   "/scim/v2/Groups?excludedAttributes=members",
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetGroups", mock.Anything, testOrganization.ID, []models.Condition{}, models.Page{Limit: 50}).
+					Return([]models.Groups{admins}, 1, nil)
+			},
+			expectedCode: http.StatusOK,
+			expected: func(t *testing.T, body []byte) {
+				var got struct {
+					Resources []Group `json:"Resources"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, "admins", got.Resources[0].DisplayName)
+				assert.Nil(t, got.Resources[0].Members)
+			},
+		},
+		{
+			name:   "Create group",
+			method: http.MethodPost,
+			path:   "/scim/v2/Groups",
+			body:   `{"displayName":"admins","members":[{"value":"7"},{"value":"8"}]}`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("CreateGroup", mock.Anything, models.Groups{
+					OrganizationID: testOrganization.ID,
+					Name:           "admins",
+					Members:        []models.GroupMembers{{UserID: 7}, {UserID: 8}},
+				}).Return(admins, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Create group with invalid member",
+			method:       http.MethodPost,
+			path:         "/scim/v2/Groups",
+			body:         `{"displayName":"admins","members":[{"value":"jane"}]}`,
+			setup:        func(s *mocks.ProvisioningServiceInterface) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Remove member",
+			method: http.MethodPatch,
+			path:   "/scim/v2/Groups/3",
+			body:   `{"Operations":[{"op":"remove","path":"members[value eq \"8\"]"}]}`,
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("GetGroup", mock.Anything, testOrganization.ID, int32(3)).Return(admins, nil)
+				s.On("ReplaceGroup", mock.Anything, models.Groups{
+					ID:             3,
+					OrganizationID: testOrganization.ID,
+					Name:           "admins",
+					Members:        []models.GroupMembers{{UserID: 7}},
+				}).Return(admins, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete group",
+			method: http.MethodDelete,
+			path:   "/scim/v2/Groups/3",
+			setup: func(s *mocks.ProvisioningServiceInterface) {
+				s.On("DeleteGroup", mock.Anything, testOrganization.ID, int32(3)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mocks.NewProvisioningServiceInterface(t)
+			tt.setup(s)
+			r := newTestRouter(t, s)
+
+			w := serve(r, tt.method, tt.path, testToken, tt.body)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expected != nil {
+				tt.expected(t, w.Body.Bytes())
+			}
+		})
+	}
+}
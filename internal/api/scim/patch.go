@@ -0,0 +1,345 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+const (
+	patchAdd     = "add"
+	patchReplace = "replace"
+	patchRemove  = "remove"
+)
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// patchPath is an attribute path such as emails[type eq "work"].value split
+// into the attribute, the value filter and the sub attribute.
+type patchPath struct {
+	attribute    string
+	filter       string
+	subAttribute string
+}
+
+// applyPatch runs the operations in order, set applies a single attribute of
+// the resource. Operations without a path carry an object of attributes.
+func applyPatch(ops []PatchOperation, schema string, set func(kind string, path patchPath, value json.RawMessage) error) error {
+	const op errors.Op = "scim.applyPatch"
+
+	if len(ops) == 0 {
+		return invalidValue(op, fmt.Errorf("no patch operations"), "Patch operations are required")
+	}
+
+	for _, operation := range ops {
+		kind := strings.ToLower(operation.Op)
+		if kind != patchAdd && kind != patchReplace && kind != patchRemove {
+			return badRequest(op, scimTypeInvalidSyntax, fmt.Errorf("unknown patch operation %q", operation.Op), "Invalid patch operation")
+		}
+
+		if operation.Path != "" {
+			path, ignored, err := parsePatchPath(operation.Path, schema)
+			if err != nil {
+				return err
+			}
+			if ignored {
+				continue
+			}
+			if err := set(kind, path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if kind == patchRemove {
+			return badRequest(op, "noTarget", fmt.Errorf("remove without path"), "Remove operations need a path")
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return invalidValue(op, err, "Patch value must be an object when there is no path")
+		}
+		for name, value := range attributes {
+			path, ignored, err := parsePatchPath(name, schema)
+			if err != nil {
+				return err
+			}
+			if ignored || path.attribute == "id" {
+				continue
+			}
+			if err := set(kind, path, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parsePatchPath lower cases the attribute names of the path. Attributes of
+// extension schemas are not stored, so they are reported as ignored.
+func parsePatchPath(path, schema string) (patchPath, bool, error) {
+	const op errors.Op = "scim.parsePatchPath"
+
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+		path = path[len(schema)+1:]
+	} else if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		return patchPath{}, true, nil
+	}
+
+	var p patchPath
+	if open := strings.Index(path, "["); open >= 0 {
+		end := strings.LastIndex(path, "]")
+		if end < open {
+			return patchPath{}, false, invalidPath(op, fmt.Errorf("unbalanced filter in %q", path), "Invalid patch path")
+		}
+		p.attribute = path[:open]
+		p.filter = path[open+1 : end]
+		p.subAttribute = strings.TrimPrefix(path[end+1:], ".")
+	} else if attr, sub, found := strings.Cut(path, "."); found {
+		p.attribute, p.subAttribute = attr, sub
+	} else {
+		p.attribute = path
+	}
+	p.attribute = strings.ToLower(p.attribute)
+	p.subAttribute = strings.ToLower(p.subAttribute)
+
+	return p, false, nil
+}
+
+func applyUserPatch(u *User, ops []PatchOperation) error {
+	return applyPatch(ops, SchemaUser, func(kind string, path patchPath, value json.RawMessage) error {
+		const op errors.Op = "scim.applyUserPatch"
+
+		switch path.attribute {
+		case "username":
+			if kind == patchRemove {
+				return required(op, "userName")
+			}
+			return decodeString(op, value, &u.UserName)
+		case "externalid":
+			return setString(op, kind, value, &u.ExternalID)
+		case "displayname":
+			return setString(op, kind, value, &u.DisplayName)
+		case "password":
+			return setString(op, kind, value, &u.Password)
+		case "active":
+			if kind == patchRemove {
+				return required(op, "active")
+			}
+			active, err := decodeBool(op, value)
+			if err != nil {
+				return err
+			}
+			u.Active = &active
+			return nil
+		case "name":
+			if u.Name == nil {
+				u.Name = &Name{}
+			}
+			switch path.subAttribute {
+			case "":
+				if kind == patchRemove {
+					u.Name = nil
+					return nil
+				}
+				var name Name
+				if err := json.Unmarshal(value, &name); err != nil {
+					return invalidValue(op, err, "Invalid name")
+				}
+				if kind == patchReplace {
+					*u.Name = name
+					return nil
+				}
+				if name.GivenName != "" {
+					u.Name.GivenName = name.GivenName
+				}
+				if name.FamilyName != "" {
+					u.Name.FamilyName = name.FamilyName
+				}
+				return nil
+			case "givenname":
+				return setString(op, kind, value, &u.Name.GivenName)
+			case "familyname":
+				return setString(op, kind, value, &u.Name.FamilyName)
+			case "formatted":
+				// derived from the given and family names
+				return nil
+			}
+		case "emails":
+			if kind == patchRemove {
+				return required(op, "emails")
+			}
+			// a single email is stored, so any email filter selects it
+			if path.filter != "" || path.subAttribute == "value" {
+				var email string
+				if err := decodeString(op, value, &email); err != nil {
+					return err
+				}
+				u.Emails = []MultiValued{{Value: email, Type: "work", Primary: true}}
+				return nil
+			}
+			var emails []MultiValued
+			if err := json.Unmarshal(value, &emails); err != nil {
+				return invalidValue(op, err, "Invalid emails")
+			}
+			if kind == patchAdd {
+				emails = append(u.Emails, emails...)
+			}
+			u.Emails = emails
+			return nil
+		case "groups":
+			return badRequest(op, scimTypeMutability, fmt.Errorf("groups are read only"), "Groups are changed through the group resource")
+		}
+
+		return invalidPath(op, fmt.Errorf("unsupported user attribute %q", path.attribute), "Unsupported patch path")
+	})
+}
+
+func applyGroupPatch(g *Group, ops []PatchOperation) error {
+	return applyPatch(ops, SchemaGroup, func(kind string, path patchPath, value json.RawMessage) error {
+		const op errors.Op = "scim.applyGroupPatch"
+
+		switch path.attribute {
+		case "displayname":
+			if kind == patchRemove {
+				return required(op, "displayName")
+			}
+			return decodeString(op, value, &g.DisplayName)
+		case "externalid":
+			return setString(op, kind, value, &g.ExternalID)
+		case "members":
+			if path.filter != "" {
+				if kind != patchRemove {
+					return invalidPath(op, fmt.Errorf("%s with a member filter", kind), "Member filters are only supported to remove members")
+				}
+				values, err := memberFilterValues(path.filter)
+				if err != nil {
+					return err
+				}
+				g.Members = removeMembers(g.Members, values)
+				return nil
+			}
+
+			var members []MultiValued
+			if len(value) > 0 && string(value) != "null" {
+				if err := json.Unmarshal(value, &members); err != nil {
+					return invalidValue(op, err, "Invalid members")
+				}
+			}
+			switch kind {
+			case patchAdd:
+				g.Members = append(g.Members, members...)
+			case patchReplace:
+				g.Members = members
+			case patchRemove:
+				if members == nil {
+					g.Members = nil
+					return nil
+				}
+				values := make([]string, 0, len(members))
+				for _, member := range members {
+					values = append(values, member.Value)
+				}
+				g.Members = removeMembers(g.Members, values)
+			}
+			return nil
+		}
+
+		return invalidPath(op, fmt.Errorf("unsupported group attribute %q", path.attribute), "Unsupported patch path")
+	})
+}
+
+// memberFilterValues reads the member ids of filters such as
+// value eq "12" or value eq "13".
+func memberFilterValues(filter string) ([]string, error) {
+	const op errors.Op = "scim.memberFilterValues"
+
+	tokens, err := tokenize(filter)
+	if err != nil || len(tokens)%4 != 3 {
+		return nil, invalidPath(op, fmt.Errorf("unsupported member filter %q", filter), "Invalid member filter")
+	}
+
+	values := make([]string, 0)
+	for i := 0; i < len(tokens); i += 4 {
+		if i > 0 && !strings.EqualFold(tokens[i-1], "or") {
+			return nil, invalidPath(op, fmt.Errorf("unsupported member filter %q", filter), "Invalid member filter")
+		}
+		if !strings.EqualFold(tokens[i], "value") || !strings.EqualFold(tokens[i+1], "eq") {
+			return nil, invalidPath(op, fmt.Errorf("unsupported member filter %q", filter), "Invalid member filter")
+		}
+		var value string
+		if err := json.Unmarshal([]byte(tokens[i+2]), &value); err != nil {
+			return nil, invalidPath(op, err, "Invalid member filter")
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func removeMembers(members []MultiValued, values []string) []MultiValued {
+	remove := make(map[string]bool, len(values))
+	for _, v := range values {
+		remove[v] = true
+	}
+
+	kept := make([]MultiValued, 0, len(members))
+	for _, member := range members {
+		if !remove[member.Value] {
+			kept = append(kept, member)
+		}
+	}
+	return kept
+}
+
+func setString(op errors.Op, kind string, value json.RawMessage, target *string) error {
+	if kind == patchRemove {
+		*target = ""
+		return nil
+	}
+	return decodeString(op, value, target)
+}
+
+func decodeString(op errors.Op, value json.RawMessage, target *string) error {
+	if err := json.Unmarshal(value, target); err != nil {
+		return invalidValue(op, err, "Expected a string value")
+	}
+	return nil
+}
+
+// decodeBool also accepts "True" and "False" strings, as sent by some
+// identity providers.
+func decodeBool(op errors.Op, value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+
+	return false, invalidValue(op, fmt.Errorf("invalid boolean %s", value), "Expected a boolean value")
+}
+
+func required(op errors.Op, attribute string) error {
+	return badRequest(op, scimTypeMutability, fmt.Errorf("%s cannot be removed", attribute), fmt.Sprintf("Attribute %s is required", attribute))
+}
@@ -0,0 +1,184 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyUserPatch(t *testing.T) {
+	active := true
+	inactive := false
+	current := func() User {
+		return User{
+			ID:       "1",
+			UserName: "jane",
+			Name:     &Name{GivenName: "Jane", FamilyName: "Doe"},
+			Emails:   []MultiValued{{Value: "jane@example.com", Type: "work", Primary: true}},
+			Active:   &active,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ops     string
+		want    func(u User) User
+		wantErr bool
+	}{
+		{
+			name: "Deactivate with path",
+			ops:  `[{"op":"Replace","path":"active","value":false}]`,
+			want: func(u User) User {
+				u.Active = &inactive
+				return u
+			},
+		},
+		{
+			name: "Deactivate with string boolean",
+			ops:  `[{"op":"replace","path":"active","value":"False"}]`,
+			want: func(u User) User {
+				u.Active = &inactive
+				return u
+			},
+		},
+		{
+			name: "Replace without path",
+			ops:  `[{"op":"replace","value":{"id":"9","userName":"janed","name.familyName":"Smith"}}]`,
+			want: func(u User) User {
+				u.UserName = "janed"
+				u.Name.FamilyName = "Smith"
+				return u
+			},
+		},
+		{
+			name: "Replace email through filter",
+			ops:  `[{"op":"replace","path":"emails[type eq \"work\"].value","value":"jd@example.com"}]`,
+			want: func(u User) User {
+				u.Emails = []MultiValued{{Value: "jd@example.com", Type: "work", Primary: true}}
+				return u
+			},
+		},
+		{
+			name: "Schema prefixed path",
+			ops:  `[{"op":"add","path":"urn:ietf:params:scim:schemas:core:2.0:User:displayName","value":"Jane Doe"}]`,
+			want: func(u User) User {
+				u.DisplayName = "Jane Doe"
+				return u
+			},
+		},
+		{
+			name: "Extension attributes are ignored",
+			ops:  `[{"op":"add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department","value":"IT"}]`,
+			want: func(u User) User { return u },
+		},
+		{
+			name:    "User name cannot be removed",
+			ops:     `[{"op":"remove","path":"userName"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Groups are read only",
+			ops:     `[{"op":"add","path":"groups","value":[{"value":"2"}]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown operation",
+			ops:     `[{"op":"move","path":"userName","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown attribute",
+			ops:     `[{"op":"add","path":"nickName","value":"x"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(tt.ops), &ops); err != nil {
+				t.Fatalf("Failed to unmarshal operations: %s", err)
+			}
+
+			got := current()
+			err := applyUserPatch(&got, ops)
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, errors.BadRequest))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want(current()), got)
+		})
+	}
+}
+
+func Test_applyGroupPatch(t *testing.T) {
+	current := func() Group {
+		return Group{
+			ID:          "3",
+			DisplayName: "admins",
+			Members:     []MultiValued{{Value: "1"}, {Value: "2"}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ops     string
+		want    []MultiValued
+		wantErr bool
+	}{
+		{
+			name: "Add members",
+			ops:  `[{"op":"add","path":"members","value":[{"value":"5"}]}]`,
+			want: []MultiValued{{Value: "1"}, {Value: "2"}, {Value: "5"}},
+		},
+		{
+			name: "Remove member by filter",
+			ops:  `[{"op":"remove","path":"members[value eq \"1\"]"}]`,
+			want: []MultiValued{{Value: "2"}},
+		},
+		{
+			name: "Remove members by value",
+			ops:  `[{"op":"remove","path":"members","value":[{"value":"2"}]}]`,
+			want: []MultiValued{{Value: "1"}},
+		},
+		{
+			name: "Remove all members",
+			ops:  `[{"op":"remove","path":"members"}]`,
+			want: nil,
+		},
+		{
+			name: "Replace members",
+			ops:  `[{"op":"replace","path":"members","value":[{"value":"7"}]}]`,
+			want: []MultiValued{{Value: "7"}},
+		},
+		{
+			name:    "Filter outside of remove",
+			ops:     `[{"op":"add","path":"members[value eq \"1\"]","value":[]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported member filter",
+			ops:     `[{"op":"remove","path":"members[display eq \"jane\"]"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(tt.ops), &ops); err != nil {
+				t.Fatalf("Failed to unmarshal operations: %s", err)
+			}
+
+			got := current()
+			err := applyGroupPatch(&got, ops)
+			if tt.wantErr {
+				assert.True(t, errors.IsKind(err, errors.BadRequest))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Members)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package scim implements the SCIM 2.0 provisioning protocol (RFC 7643 and
+// RFC 7644) on top of the users and roles of an organization.
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+)
+
+const (
+	ContentType = "application/scim+json"
+
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+	// Version is the weak entity tag of the resource, also sent as ETag
+	Version string `json:"version,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValued is an entry of a multi valued attribute such as emails,
+// groups or members.
+type MultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type User struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName"`
+	Name        *Name         `json:"name,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Emails      []MultiValued `json:"emails,omitempty"`
+	// Active is a pointer so that a missing attribute keeps the user active
+	Active   *bool         `json:"active,omitempty"`
+	Password string        `json:"password,omitempty"`
+	Groups   []MultiValued `json:"groups,omitempty"`
+	Meta     *Meta         `json:"meta,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []MultiValued `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func newUser(user models.Users, groups []models.Groups, baseURL string) User {
+	active := user.Active
+	res := User{
+		Schemas:     []string{SchemaUser},
+		ID:          formatID(user.ID),
+		ExternalID:  user.ExternalID,
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Emails: []MultiValued{{
+			Value:   user.Email,
+			Type:    "work",
+			Primary: true,
+		}},
+		Meta: newMeta("User", baseURL+"/Users/"+formatID(user.ID), user.CreatedAt, user.UpdatedAt),
+	}
+	if user.Version != 0 {
+		res.Meta.Version = formatETag(user.Version)
+	}
+	if user.GivenName != "" || user.FamilyName != "" {
+		res.Name = &Name{
+			Formatted:  strings.TrimSpace(user.GivenName + " " + user.FamilyName),
+			GivenName:  user.GivenName,
+			FamilyName: user.FamilyName,
+		}
+	}
+	for _, group := range groups {
+		res.Groups = append(res.Groups, MultiValued{
+			Value:   formatID(group.ID),
+			Display: group.Name,
+			Ref:     baseURL + "/Groups/" + formatID(group.ID),
+		})
+	}
+	return res
+}
+
+// model returns the user of the organization described by the resource,
+// the primary email or else the first one is used.
+func (u User) model(organizationID, id int32) models.Users {
+	user := models.Users{
+		ID:             id,
+		OrganizationID: organizationID,
+		ExternalID:     u.ExternalID,
+		Username:       u.UserName,
+		DisplayName:    u.DisplayName,
+		Active:         u.Active == nil || *u.Active,
+	}
+	if u.Name != nil {
+		user.GivenName = u.Name.GivenName
+		user.FamilyName = u.Name.FamilyName
+	}
+	for i, email := range u.Emails {
+		if i == 0 || email.Primary {
+			user.Email = email.Value
+		}
+		if email.Primary {
+			break
+		}
+	}
+	return user
+}
+
+func newGroup(group models.Groups, baseURL string) Group {
+	res := Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          formatID(group.ID),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.Name,
+		Meta:        newMeta("Group", baseURL+"/Groups/"+formatID(group.ID), group.CreatedAt, group.UpdatedAt),
+	}
+	for _, member := range group.Members {
+		res.Members = append(res.Members, MultiValued{
+			Value:   formatID(member.UserID),
+			Display: member.Username,
+			Ref:     baseURL + "/Users/" + formatID(member.UserID),
+		})
+	}
+	return res
+}
+
+func (g Group) model(organizationID, id int32) (models.Groups, error) {
+	group := models.Groups{
+		ID:             id,
+		OrganizationID: organizationID,
+		Name:           g.DisplayName,
+		ExternalID:     g.ExternalID,
+		Members:        make([]models.GroupMembers, 0, len(g.Members)),
+	}
+	for _, member := range g.Members {
+		userID, err := parseID(member.Value)
+		if err != nil {
+			return models.Groups{}, invalidValue("scim.Group.model", err, "Invalid group member")
+		}
+		group.Members = append(group.Members, models.GroupMembers{UserID: userID})
+	}
+	return group, nil
+}
+
+func newMeta(resourceType, location string, created, modified time.Time) *Meta {
+	meta := &Meta{
+		ResourceType: resourceType,
+		Location:     location,
+	}
+	if !created.IsZero() {
+		meta.Created = &created
+	}
+	if !modified.IsZero() {
+		meta.LastModified = &modified
+	}
+	return meta
+}
+
+func formatID(id int32) string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+func parseID(id string) (int32, error) {
+	v, err := strconv.ParseInt(id, 10, 32)
+	return int32(v), err
+}
+
+// formatETag returns the weak entity tag of the version.
+func formatETag(version int32) string {
+	return `W/"` + formatID(version) + `"`
+}
+
+// parseETag returns the version of a weak or strong entity tag.
+func parseETag(tag string) (int32, error) {
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, fmt.Errorf("invalid entity tag %q", tag)
+	}
+	return parseID(tag[1 : len(tag)-1])
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"net/http"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -25,6 +26,7 @@ type Server struct {
 	log    *logger.Logger
 	engine *gin.Engine
 	server *http.Server
+	tasks  []func(ctx context.Context)
 }
 
 func NewServer(c *config.Config, l *logger.Logger) *Server {
@@ -59,6 +61,13 @@ func (s *Server) SetRoutes(services *handlers.Services) {
 	router.NewRouter(s.engine, s.log, s.cfg, services)
 }
 
+// AddTask runs task in the background while the server runs. Its context is
+// cancelled once the server stopped serving requests, and Run waits for it
+// to return before exiting.
+func (s *Server) AddTask(task func(ctx context.Context)) {
+	s.tasks = append(s.tasks, task)
+}
+
 func (s *Server) Run() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -71,6 +80,17 @@ func (s *Server) Run() {
 		}
 	}(s.log)
 
+	tasksCtx, stopTasks := context.WithCancel(context.Background())
+	defer stopTasks()
+	var tasks sync.WaitGroup
+	for _, task := range s.tasks {
+		tasks.Add(1)
+		go func(task func(ctx context.Context)) {
+			defer tasks.Done()
+			task(tasksCtx)
+		}(task)
+	}
+
 	s.log.Info("%s started", s.cfg.App.Name)
 	s.log.Info("version: %s", s.cfg.App.Version)
 
@@ -89,5 +109,19 @@ func (s *Server) Run() {
 		s.log.Error("App server forced to shutdown: ", err)
 	}
 
+	// The background tasks run until the last requests are served, so the
+	// work they queued is picked up
+	stopTasks()
+	done := make(chan struct{})
+	go func() {
+		tasks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log.Error("App background tasks forced to stop")
+	}
+
 	s.log.Info("App exiting")
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"syscall"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/handlers"
 	"github.com/Pedrommb91/go-auth/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -47,7 +49,7 @@ func TestServer_Run(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := NewServer(tt.fields.cfg, tt.fields.log)
 			s.ServerConfigure()
-			s.SetRoutes(nil)
+			s.SetRoutes(&handlers.Services{})
 			go func() {
 				time.Sleep(time.Millisecond * 500)
 				p, err := os.FindProcess(os.Getpid())
@@ -63,3 +65,34 @@ func TestServer_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_RunStopsTasks(t *testing.T) {
+	s := NewServer(&config.Config{API: config.API{Address: ":0"}}, logger.New("info"))
+	s.ServerConfigure()
+	s.SetRoutes(&handlers.Services{})
+
+	started := make(chan struct{})
+	stopped := false
+	s.AddTask(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		stopped = true
+	})
+
+	go func() {
+		<-started
+		p, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Errorf("Could not find process")
+			return
+		}
+		if err := p.Signal(syscall.SIGINT); err != nil {
+			t.Errorf("Could not send signal")
+		}
+	}()
+	s.Run()
+
+	if !stopped {
+		t.Errorf("Server.Run() returned before its tasks stopped")
+	}
+}
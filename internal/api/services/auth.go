@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"github.com/Pedrommb91/go-auth/internal/api/authenticators"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+type AuthService struct {
+	authenticator authenticators.Authenticator
+}
+
+type AuthServiceInterface interface {
+	Login(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error)
+}
+
+func NewAuthService(authenticator authenticators.Authenticator) AuthService {
+	return AuthService{
+		authenticator: authenticator,
+	}
+}
+
+func (s AuthService) Login(ctx context.Context, org models.Organizations, username, password string) (models.Identity, error) {
+	const op errors.Op = "services.Login"
+
+	identity, err := s.authenticator.Authenticate(ctx, org, username, password)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate user"),
+		)
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-faker/faker/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthService_Login(t *testing.T) {
+	dummyID := faker.UUIDHyphenated()
+	errors.NewUUID = func() uuid.UUID {
+		return uuid.FromStringOrNil(dummyID)
+	}
+
+	org := models.Organizations{ID: 1, Slug: "default"}
+	identity := models.Identity{
+		User: models.Users{
+			ID:       1,
+			Username: faker.Username(),
+			Email:    faker.Email(),
+		},
+		Roles:   []string{"admin"},
+		Backend: "ldap",
+	}
+
+	type authenticateMockResponse struct {
+		identity models.Identity
+		err      error
+	}
+	tests := []struct {
+		name                     string
+		authenticateMockResponse authenticateMockResponse
+		want                     models.Identity
+		expectedErr              error
+	}{
+		{
+			name: "Success",
+			authenticateMockResponse: authenticateMockResponse{
+				identity: identity,
+			},
+			want: identity,
+		},
+		{
+			name: "Invalid credentials",
+			authenticateMockResponse: authenticateMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("invalid credentials")),
+					errors.KindUnauthorized(),
+				),
+			},
+			want: models.Identity{},
+			expectedErr: errors.Build(
+				errors.WithError(fmt.Errorf("invalid credentials")),
+				errors.KindUnauthorized(),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := mocks.NewAuthenticator(t)
+			a.On("Authenticate", mock.Anything, org, identity.User.Username, "password").
+				Return(tt.authenticateMockResponse.identity, tt.authenticateMockResponse.err)
+
+			s := NewAuthService(a)
+			got, err := s.Login(context.Background(), org, identity.User.Username, "password")
+			if !errors.Equal(errors.GetFirstNestedError(err), tt.expectedErr) {
+				t.Errorf("AuthService.Login() error = %v, wantErr %v", err, tt.expectedErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
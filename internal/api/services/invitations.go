@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/encrypt"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/mailer"
+	"github.com/rs/zerolog"
+)
+
+type InvitationService struct {
+	r         models.InvitationRepositoryInterface
+	users     models.UserReaderInterface
+	members   models.MembershipRepositoryInterface
+	orgs      models.OrganizationReaderInterface
+	mailer    mailer.Mailer
+	cfg       config.Invitations
+	encrypt   config.Encrypt
+	encryptor encrypt.Encryptor
+	clock     clock.Clock
+	tx        models.TransactorInterface
+	outbox    models.OutboxInterface
+}
+
+type InvitationServiceInterface interface {
+	Invite(ctx context.Context, organizationID int32, email, role string) (models.Invitations, error)
+	GetInvitations(ctx context.Context, organizationID int32) ([]models.Invitations, error)
+	// Accept uses the invitation token to add the invited email to the
+	// organization. Username and password are only needed when there is no
+	// user with that email yet.
+	Accept(ctx context.Context, token, username, password string) (models.Memberships, error)
+}
+
+type InvitationServiceDependencies struct {
+	Invitations   models.InvitationRepositoryInterface
+	Users         models.UserReaderInterface
+	Members       models.MembershipRepositoryInterface
+	Organizations models.OrganizationReaderInterface
+	Mailer        mailer.Mailer
+	Encryptor     encrypt.Encryptor
+	Clock         clock.Clock
+	Transactor    models.TransactorInterface
+	Outbox        models.OutboxInterface
+}
+
+func NewInvitationService(cfg *config.Config, deps InvitationServiceDependencies) InvitationService {
+	return InvitationService{
+		r:         deps.Invitations,
+		users:     deps.Users,
+		members:   deps.Members,
+		orgs:      deps.Organizations,
+		mailer:    deps.Mailer,
+		cfg:       cfg.Invitations,
+		encrypt:   cfg.Encrypt,
+		encryptor: deps.Encryptor,
+		clock:     deps.Clock,
+		tx:        deps.Transactor,
+		outbox:    deps.Outbox,
+	}
+}
+
+func (s InvitationService) Invite(ctx context.Context, organizationID int32, email, role string) (models.Invitations, error) {
+	const op errors.Op = "services.Invite"
+
+	org, err := s.orgs.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+
+	invitation, err := s.r.AddInvitation(ctx, models.Invitations{
+		OrganizationID: org.ID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashToken(token),
+		ExpiresAt:      s.clock.Now().UTC().Add(s.cfg.TTL),
+	})
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create invitation"),
+		)
+	}
+
+	err = s.mailer.Send(mailer.Message{
+		To:      []string{email},
+		Subject: fmt.Sprintf("You have been invited to %s", org.Name),
+		Body: fmt.Sprintf("You have been invited to join %s as %s.\n\nAccept the invitation before %s at:\n%s\n",
+			org.Name, role, invitation.ExpiresAt.Format("2006-01-02 15:04 MST"), s.acceptURL(token)),
+	})
+	if err != nil {
+		return models.Invitations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to send invitation"),
+		)
+	}
+
+	return invitation, nil
+}
+
+func (s InvitationService) GetInvitations(ctx context.Context, organizationID int32) ([]models.Invitations, error) {
+	const op errors.Op = "services.GetInvitations"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, organizationID); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	invitations, err := s.r.GetInvitations(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get invitations"),
+		)
+	}
+
+	return invitations, nil
+}
+
+func (s InvitationService) Accept(ctx context.Context, token, username, password string) (models.Memberships, error) {
+	const op errors.Op = "services.Accept"
+
+	invitation, err := s.r.GetInvitationByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	if !invitation.AcceptedAt.IsZero() {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("invitation %d was accepted at %s", invitation.ID, invitation.AcceptedAt)),
+			errors.WithMessage("Invitation was already used"),
+			errors.KindConflict(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	if !s.clock.Now().Before(invitation.ExpiresAt) {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("invitation %d expired at %s", invitation.ID, invitation.ExpiresAt)),
+			errors.WithMessage("Invitation has expired"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	user, err := s.users.GetUserByEmail(ctx, invitation.OrganizationID, invitation.Email)
+	if errors.IsKind(err, errors.NotFound) {
+		user, err = s.newUser(invitation, username, password)
+	}
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	// a user created by the invitation is registered in the same transaction
+	var userID int32
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if userID, err = s.r.AcceptInvitation(ctx, invitation, user); err != nil || user.ID != 0 {
+			return err
+		}
+		user.ID = userID
+		return s.outbox.Record(ctx, models.UserRegistered, models.NewUserEvent(user, int64(user.ID)))
+	})
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to accept invitation"),
+		)
+	}
+
+	member, err := s.members.GetMember(ctx, invitation.OrganizationID, userID)
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get member"),
+		)
+	}
+
+	return member, nil
+}
+
+func (s InvitationService) newUser(invitation models.Invitations, username, password string) (models.Users, error) {
+	const op errors.Op = "services.newUser"
+
+	body := models.RegisterUserRequestBody{
+		Username: username,
+		Email:    invitation.Email,
+		Password: password,
+	}
+	if err := body.Validate(); err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid fields"),
+		)
+	}
+
+	salt := s.encryptor.GenerateSalt(64, true, true)
+	passHash, err := s.encryptor.Encrypt(password, salt, s.encrypt.Password)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to enryp password"),
+		)
+	}
+
+	return models.Users{
+		OrganizationID: invitation.OrganizationID,
+		Username:       username,
+		Email:          invitation.Email,
+		Active:         true,
+		Credentials: models.Credentials{
+			Salt:     salt,
+			PassHash: passHash,
+		},
+	}, nil
+}
+
+func (s InvitationService) acceptURL(token string) string {
+	u, err := url.Parse(s.cfg.AcceptURL)
+	if err != nil {
+		return s.cfg.AcceptURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
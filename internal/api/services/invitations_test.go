@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type invitationMocks struct {
+	invitations *mocks.InvitationRepositoryInterface
+	users       *mocks.UserReaderInterface
+	members     *mocks.MembershipRepositoryInterface
+	orgs        *mocks.OrganizationReaderInterface
+	mailer      *mocks.Mailer
+	encryptor   *mocks.Encryptor
+	clock       *mocks.Clock
+	outbox      *mocks.OutboxInterface
+}
+
+func newInvitationService(t *testing.T, now time.Time) (InvitationService, invitationMocks) {
+	m := invitationMocks{
+		invitations: mocks.NewInvitationRepositoryInterface(t),
+		users:       mocks.NewUserReaderInterface(t),
+		members:     mocks.NewMembershipRepositoryInterface(t),
+		orgs:        mocks.NewOrganizationReaderInterface(t),
+		mailer:      mocks.NewMailer(t),
+		encryptor:   mocks.NewEncryptor(t),
+		clock:       mocks.NewClock(t),
+		outbox:      mocks.NewOutboxInterface(t),
+	}
+	m.clock.On("Now").Return(now).Maybe()
+
+	cfg := &config.Config{
+		Encrypt: config.Encrypt{Password: "secret"},
+		Invitations: config.Invitations{
+			TTL:       24 * time.Hour,
+			AcceptURL: "https://auth.acme.com/invitations/accept",
+		},
+	}
+
+	return NewInvitationService(cfg, InvitationServiceDependencies{
+		Invitations:   m.invitations,
+		Users:         m.users,
+		Members:       m.members,
+		Organizations: m.orgs,
+		Mailer:        m.mailer,
+		Encryptor:     m.encryptor,
+		Clock:         m.clock,
+		Transactor:    inTx(t),
+		Outbox:        m.outbox,
+	}), m
+}
+
+func TestInvitationService_Invite(t *testing.T) {
+	now := time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC)
+	org := models.Organizations{ID: 2, Slug: "acme", Name: "Acme"}
+
+	s, m := newInvitationService(t, now)
+	m.orgs.On("GetOrganizationByID", mock.Anything, int32(2)).Return(org, nil)
+
+	var stored models.Invitations
+	m.invitations.On("AddInvitation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(models.Invitations)
+	}).Return(func(_ context.Context, inv models.Invitations) models.Invitations {
+		inv.ID = 7
+		return inv
+	}, nil)
+
+	var sent mailer.Message
+	m.mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(0).(mailer.Message)
+	}).Return(nil)
+
+	got, err := s.Invite(context.Background(), 2, "jane@acme.com", "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), got.ID)
+	assert.Equal(t, now.Add(24*time.Hour), stored.ExpiresAt)
+	assert.Len(t, stored.TokenHash, 64)
+
+	// the email carries the token whose hash was stored
+	assert.Equal(t, []string{"jane@acme.com"}, sent.To)
+	_, token, found := strings.Cut(sent.Body, "https://auth.acme.com/invitations/accept?token=")
+	assert.True(t, found)
+	assert.Equal(t, stored.TokenHash, hashToken(strings.TrimSpace(token)))
+}
+
+func TestInvitationService_Invite_MailerFails(t *testing.T) {
+	s, m := newInvitationService(t, time.Now())
+	m.orgs.On("GetOrganizationByID", mock.Anything, int32(2)).Return(models.Organizations{ID: 2}, nil)
+	m.invitations.On("AddInvitation", mock.Anything, mock.Anything).Return(models.Invitations{ID: 7}, nil)
+	m.mailer.On("Send", mock.Anything).Return(errors.Build(
+		errors.WithError(fmt.Errorf("connection refused")),
+		errors.KindBadGateway(),
+	))
+
+	_, err := s.Invite(context.Background(), 2, "jane@acme.com", "admin")
+	assert.True(t, errors.IsKind(err, errors.BadGateway))
+}
+
+func TestInvitationService_Accept(t *testing.T) {
+	now := time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC)
+	token := "token"
+	pending := models.Invitations{
+		ID:             7,
+		OrganizationID: 2,
+		Email:          "jane@acme.com",
+		Role:           "admin",
+		TokenHash:      hashToken(token),
+		ExpiresAt:      now.Add(time.Hour),
+	}
+	member := models.Memberships{OrganizationID: 2, UserID: 3, Email: "jane@acme.com", Roles: []string{"admin"}}
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	t.Run("Existing user", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		user := models.Users{ID: 3, OrganizationID: 2, Email: "jane@acme.com"}
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(pending, nil)
+		m.users.On("GetUserByEmail", mock.Anything, int32(2), "jane@acme.com").Return(user, nil)
+		m.invitations.On("AcceptInvitation", mock.Anything, pending, user).Return(int32(3), nil)
+		m.members.On("GetMember", mock.Anything, int32(2), int32(3)).Return(member, nil)
+
+		got, err := s.Accept(context.Background(), token, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, member, got)
+	})
+
+	t.Run("New user", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(pending, nil)
+		m.users.On("GetUserByEmail", mock.Anything, int32(2), "jane@acme.com").Return(models.Users{}, notFound)
+		m.encryptor.On("GenerateSalt", 64, true, true).Return("salt")
+		m.encryptor.On("Encrypt", "#sdjU1kaL!", "salt", "secret").Return("hash", nil)
+		created := models.Users{
+			OrganizationID: 2,
+			Username:       "jane",
+			Email:          "jane@acme.com",
+			Active:         true,
+			Credentials:    models.Credentials{Salt: "salt", PassHash: "hash"},
+		}
+		m.invitations.On("AcceptInvitation", mock.Anything, pending, created).Return(int32(3), nil)
+		created.ID = 3
+		m.outbox.On("Record", mock.Anything, models.UserRegistered, models.NewUserEvent(created, 3)).Return(nil)
+		m.members.On("GetMember", mock.Anything, int32(2), int32(3)).Return(member, nil)
+
+		got, err := s.Accept(context.Background(), token, "jane", "#sdjU1kaL!")
+		assert.NoError(t, err)
+		assert.Equal(t, member, got)
+	})
+
+	t.Run("New user event fails", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(pending, nil)
+		m.users.On("GetUserByEmail", mock.Anything, int32(2), "jane@acme.com").Return(models.Users{}, notFound)
+		m.encryptor.On("GenerateSalt", 64, true, true).Return("salt")
+		m.encryptor.On("Encrypt", "#sdjU1kaL!", "salt", "secret").Return("hash", nil)
+		m.invitations.On("AcceptInvitation", mock.Anything, pending, mock.Anything).Return(int32(3), nil)
+		m.outbox.On("Record", mock.Anything, models.UserRegistered, mock.Anything).Return(fmt.Errorf("outbox unavailable"))
+
+		_, err := s.Accept(context.Background(), token, "jane", "#sdjU1kaL!")
+		assert.Error(t, err)
+	})
+
+	t.Run("New user without password", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(pending, nil)
+		m.users.On("GetUserByEmail", mock.Anything, int32(2), "jane@acme.com").Return(models.Users{}, notFound)
+
+		_, err := s.Accept(context.Background(), token, "jane", "")
+		assert.True(t, errors.IsKind(err, errors.BadRequest))
+	})
+
+	t.Run("Already accepted", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		accepted := pending
+		accepted.AcceptedAt = now.Add(-time.Minute)
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(accepted, nil)
+
+		_, err := s.Accept(context.Background(), token, "", "")
+		assert.True(t, errors.IsKind(err, errors.Conflict))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		s, m := newInvitationService(t, now.Add(2*time.Hour))
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, pending.TokenHash).Return(pending, nil)
+
+		_, err := s.Accept(context.Background(), token, "", "")
+		assert.True(t, errors.IsKind(err, errors.BadRequest))
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		s, m := newInvitationService(t, now)
+		m.invitations.On("GetInvitationByTokenHash", mock.Anything, mock.Anything).Return(models.Invitations{}, notFound)
+
+		_, err := s.Accept(context.Background(), "other", "", "")
+		assert.True(t, errors.IsKind(err, errors.NotFound))
+	})
+}
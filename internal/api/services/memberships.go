@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+type MembershipService struct {
+	r    models.MembershipRepositoryInterface
+	orgs models.OrganizationReaderInterface
+}
+
+type MembershipServiceInterface interface {
+	GetMembers(ctx context.Context, organizationID int32, cursor pagination.Cursor, size int) (pagination.Page[models.Memberships], error)
+	UpdateMemberRole(ctx context.Context, organizationID, userID int32, role string) (models.Memberships, error)
+	RemoveMember(ctx context.Context, organizationID, userID int32) error
+}
+
+func NewMembershipService(r models.MembershipRepositoryInterface, orgs models.OrganizationReaderInterface) MembershipService {
+	return MembershipService{
+		r:    r,
+		orgs: orgs,
+	}
+}
+
+func (s MembershipService) GetMembers(ctx context.Context, organizationID int32, cursor pagination.Cursor, size int) (pagination.Page[models.Memberships], error) {
+	const op errors.Op = "services.GetMembers"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, organizationID); err != nil {
+		return pagination.Page[models.Memberships]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	members, err := s.r.GetMembers(ctx, organizationID, cursor, size)
+	if err != nil {
+		return pagination.Page[models.Memberships]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get members"),
+		)
+	}
+
+	return members, nil
+}
+
+func (s MembershipService) UpdateMemberRole(ctx context.Context, organizationID, userID int32, role string) (models.Memberships, error) {
+	const op errors.Op = "services.UpdateMemberRole"
+
+	if err := s.r.SetMemberRole(ctx, organizationID, userID, role); err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update member"),
+		)
+	}
+
+	member, err := s.r.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return models.Memberships{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get member"),
+		)
+	}
+
+	return member, nil
+}
+
+func (s MembershipService) RemoveMember(ctx context.Context, organizationID, userID int32) error {
+	const op errors.Op = "services.RemoveMember"
+
+	if err := s.r.RemoveMember(ctx, organizationID, userID); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to remove member"),
+		)
+	}
+
+	return nil
+}
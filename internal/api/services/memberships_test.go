@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipService(t *testing.T) {
+	member := models.Memberships{OrganizationID: 2, UserID: 3, Username: "jane", Roles: []string{"viewer"}}
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	r := mocks.NewMembershipRepositoryInterface(t)
+	orgs := mocks.NewOrganizationReaderInterface(t)
+	orgs.On("GetOrganizationByID", mock.Anything, int32(2)).Return(models.Organizations{ID: 2}, nil)
+	orgs.On("GetOrganizationByID", mock.Anything, int32(9)).Return(models.Organizations{}, notFound)
+	page := pagination.Page[models.Memberships]{Items: []models.Memberships{member}, Next: &pagination.Cursor{ID: 3}}
+	r.On("GetMembers", mock.Anything, int32(2), pagination.Cursor{}, 1).Return(page, nil)
+	r.On("SetMemberRole", mock.Anything, int32(2), int32(3), "viewer").Return(nil)
+	r.On("GetMember", mock.Anything, int32(2), int32(3)).Return(member, nil)
+	r.On("RemoveMember", mock.Anything, int32(2), int32(4)).Return(notFound)
+
+	s := NewMembershipService(r, orgs)
+
+	members, err := s.GetMembers(context.Background(), 2, pagination.Cursor{}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, page, members)
+
+	_, err = s.GetMembers(context.Background(), 9, pagination.Cursor{}, 1)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+
+	got, err := s.UpdateMemberRole(context.Background(), 2, 3, "viewer")
+	assert.NoError(t, err)
+	assert.Equal(t, member, got)
+
+	err = s.RemoveMember(context.Background(), 2, 4)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+type OrganizationService struct {
+	r models.OrganizationReaderInterface
+}
+
+type OrganizationServiceInterface interface {
+	GetOrganizationByID(ctx context.Context, id int32) (models.Organizations, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (models.Organizations, error)
+	GetOrganizationByHost(ctx context.Context, host string) (models.Organizations, error)
+}
+
+func NewOrganizationService(r models.OrganizationReaderInterface) OrganizationService {
+	return OrganizationService{
+		r: r,
+	}
+}
+
+func (s OrganizationService) GetOrganizationByID(ctx context.Context, id int32) (models.Organizations, error) {
+	const op errors.Op = "services.GetOrganizationByID"
+
+	org, err := s.r.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	return org, nil
+}
+
+func (s OrganizationService) GetOrganizationBySlug(ctx context.Context, slug string) (models.Organizations, error) {
+	const op errors.Op = "services.GetOrganizationBySlug"
+
+	org, err := s.r.GetOrganizationBySlug(ctx, slug)
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	return org, nil
+}
+
+func (s OrganizationService) GetOrganizationByHost(ctx context.Context, host string) (models.Organizations, error) {
+	const op errors.Op = "services.GetOrganizationByHost"
+
+	org, err := s.r.GetOrganizationByHost(ctx, host)
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	return org, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationService_GetOrganization(t *testing.T) {
+	org := models.Organizations{ID: 2, Slug: "acme", Host: "auth.acme.com"}
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	r := mocks.NewOrganizationReaderInterface(t)
+	r.On("GetOrganizationBySlug", mock.Anything, "acme").Return(org, nil)
+	r.On("GetOrganizationBySlug", mock.Anything, "other").Return(models.Organizations{}, notFound)
+	r.On("GetOrganizationByHost", mock.Anything, "auth.acme.com").Return(org, nil)
+	r.On("GetOrganizationByID", mock.Anything, int32(2)).Return(org, nil)
+
+	s := NewOrganizationService(r)
+
+	got, err := s.GetOrganizationBySlug(context.Background(), "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, org, got)
+
+	_, err = s.GetOrganizationBySlug(context.Background(), "other")
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+
+	got, err = s.GetOrganizationByHost(context.Background(), "auth.acme.com")
+	assert.NoError(t, err)
+	assert.Equal(t, org, got)
+
+	got, err = s.GetOrganizationByID(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, org, got)
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/encrypt"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// ProvisioningService manages the users and groups of an organization on
+// behalf of its identity provider.
+type ProvisioningService struct {
+	users     models.UserProvisionerInterface
+	groups    models.GroupRepositoryInterface
+	tx        models.TransactorInterface
+	outbox    models.OutboxInterface
+	encrypt   config.Encrypt
+	encryptor encrypt.Encryptor
+}
+
+type ProvisioningServiceInterface interface {
+	GetUsers(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Users, int, error)
+	GetUser(ctx context.Context, organizationID, id int32) (models.Users, error)
+	// CreateUser adds the user, without a password it can only log in
+	// through SSO.
+	CreateUser(ctx context.Context, user models.Users, password string) (models.Users, error)
+	// ReplaceUser overwrites the user, the password is kept when empty.
+	ReplaceUser(ctx context.Context, user models.Users, password string) (models.Users, error)
+	DeleteUser(ctx context.Context, organizationID, id int32) error
+	GetUserGroups(ctx context.Context, organizationID int32, userIDs []int32) (map[int32][]models.Groups, error)
+	GetGroups(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Groups, int, error)
+	GetGroup(ctx context.Context, organizationID, id int32) (models.Groups, error)
+	CreateGroup(ctx context.Context, group models.Groups) (models.Groups, error)
+	ReplaceGroup(ctx context.Context, group models.Groups) (models.Groups, error)
+	DeleteGroup(ctx context.Context, organizationID, id int32) error
+}
+
+func NewProvisioningService(users models.UserProvisionerInterface, groups models.GroupRepositoryInterface, tx models.TransactorInterface, outbox models.OutboxInterface, encrypt config.Encrypt, encryptor encrypt.Encryptor) ProvisioningService {
+	return ProvisioningService{
+		users:     users,
+		groups:    groups,
+		tx:        tx,
+		outbox:    outbox,
+		encrypt:   encrypt,
+		encryptor: encryptor,
+	}
+}
+
+func (s ProvisioningService) GetUsers(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Users, int, error) {
+	const op errors.Op = "services.GetUsers"
+
+	users, total, err := s.users.GetUsers(ctx, organizationID, conditions, page)
+	if err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get users"),
+		)
+	}
+
+	return users, total, nil
+}
+
+func (s ProvisioningService) GetUser(ctx context.Context, organizationID, id int32) (models.Users, error) {
+	const op errors.Op = "services.GetUser"
+
+	user, err := s.users.GetUserByID(ctx, organizationID, id)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user"),
+		)
+	}
+
+	return user, nil
+}
+
+func (s ProvisioningService) CreateUser(ctx context.Context, user models.Users, password string) (models.Users, error) {
+	const op errors.Op = "services.CreateUser"
+
+	user, err := s.withPassword(user, password)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		)
+	}
+
+	var created models.Users
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.users.ProvisionUser(ctx, user); err != nil {
+			return err
+		}
+		return s.outbox.Record(ctx, models.UserRegistered, models.NewUserEvent(created, int64(created.ID)))
+	})
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create user"),
+		)
+	}
+
+	return created, nil
+}
+
+func (s ProvisioningService) ReplaceUser(ctx context.Context, user models.Users, password string) (models.Users, error) {
+	const op errors.Op = "services.ReplaceUser"
+
+	user, err := s.withPassword(user, password)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		)
+	}
+
+	var updated models.Users
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.users.UpdateUser(ctx, user); err != nil || password == "" {
+			return err
+		}
+		return s.outbox.Record(ctx, models.UserPasswordChanged, models.NewUserEvent(updated, int64(updated.ID)))
+	})
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update user"),
+		)
+	}
+
+	return updated, nil
+}
+
+func (s ProvisioningService) DeleteUser(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "services.DeleteUser"
+
+	if err := s.users.DeleteUser(ctx, organizationID, id); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete user"),
+		)
+	}
+
+	return nil
+}
+
+func (s ProvisioningService) GetUserGroups(ctx context.Context, organizationID int32, userIDs []int32) (map[int32][]models.Groups, error) {
+	const op errors.Op = "services.GetUserGroups"
+
+	groups, err := s.groups.GetUserGroups(ctx, organizationID, userIDs)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user groups"),
+		)
+	}
+
+	return groups, nil
+}
+
+func (s ProvisioningService) GetGroups(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Groups, int, error) {
+	const op errors.Op = "services.GetGroups"
+
+	groups, total, err := s.groups.GetGroups(ctx, organizationID, conditions, page)
+	if err != nil {
+		return nil, 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get groups"),
+		)
+	}
+
+	return groups, total, nil
+}
+
+func (s ProvisioningService) GetGroup(ctx context.Context, organizationID, id int32) (models.Groups, error) {
+	const op errors.Op = "services.GetGroup"
+
+	group, err := s.groups.GetGroup(ctx, organizationID, id)
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get group"),
+		)
+	}
+
+	return group, nil
+}
+
+func (s ProvisioningService) CreateGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	const op errors.Op = "services.CreateGroup"
+
+	group, err := s.groups.AddGroup(ctx, group)
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create group"),
+		)
+	}
+
+	return group, nil
+}
+
+func (s ProvisioningService) ReplaceGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	const op errors.Op = "services.ReplaceGroup"
+
+	group, err := s.groups.UpdateGroup(ctx, group)
+	if err != nil {
+		return models.Groups{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update group"),
+		)
+	}
+
+	return group, nil
+}
+
+func (s ProvisioningService) DeleteGroup(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "services.DeleteGroup"
+
+	if err := s.groups.DeleteGroup(ctx, organizationID, id); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete group"),
+		)
+	}
+
+	return nil
+}
+
+// withPassword sets the credentials of the user from the password, an empty
+// password leaves them untouched.
+func (s ProvisioningService) withPassword(user models.Users, password string) (models.Users, error) {
+	const op errors.Op = "services.withPassword"
+
+	user.Credentials = models.Credentials{}
+	if password == "" {
+		return user, nil
+	}
+
+	salt := s.encryptor.GenerateSalt(64, true, true)
+	passHash, err := s.encryptor.Encrypt(password, salt, s.encrypt.Password)
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to enryp password"),
+		)
+	}
+
+	user.Credentials = models.Credentials{
+		Salt:     salt,
+		PassHash: passHash,
+	}
+	return user, nil
+}
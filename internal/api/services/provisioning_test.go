@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisioningService_CreateUser(t *testing.T) {
+	user := models.Users{OrganizationID: 2, Username: "jane", Email: "jane@example.com", Active: true}
+
+	tests := []struct {
+		name     string
+		password string
+		expected models.Users
+	}{
+		{
+			name:     "Without password",
+			password: "",
+			expected: user,
+		},
+		{
+			name:     "With password",
+			password: "secret",
+			expected: models.Users{
+				OrganizationID: 2,
+				Username:       "jane",
+				Email:          "jane@example.com",
+				Active:         true,
+				Credentials:    models.Credentials{Salt: "salt", PassHash: "hash"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mocks.NewUserProvisionerInterface(t)
+			enc := mocks.NewEncryptor(t)
+			enc.On("GenerateSalt", 64, true, true).Return("salt").Maybe()
+			enc.On("Encrypt", "secret", "salt", "key").Return("hash", nil).Maybe()
+			users.On("ProvisionUser", mock.Anything, tt.expected).Return(tt.expected, nil)
+			outbox := mocks.NewOutboxInterface(t)
+			outbox.On("Record", mock.Anything, models.UserRegistered, models.NewUserEvent(tt.expected, 0)).Return(nil)
+
+			s := NewProvisioningService(users, mocks.NewGroupRepositoryInterface(t), inTx(t), outbox, config.Encrypt{Password: "key"}, enc)
+
+			// credentials in the request are never trusted
+			in := user
+			in.Credentials = models.Credentials{PassHash: "injected"}
+			got, err := s.CreateUser(context.Background(), in, tt.password)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestProvisioningService_ReplaceUser(t *testing.T) {
+	user := models.Users{ID: 5, OrganizationID: 2, Username: "jane", Email: "jane@example.com", Active: true}
+	withPassword := user
+	withPassword.Credentials = models.Credentials{Salt: "salt", PassHash: "hash"}
+	failed := errors.Build(
+		errors.WithError(fmt.Errorf("failed to record event")),
+	)
+
+	tests := []struct {
+		name        string
+		password    string
+		update      models.Users
+		recordErr   error
+		recorded    bool
+		expectedErr error
+	}{
+		{
+			name:   "Without password no event is recorded",
+			update: user,
+		},
+		{
+			name:     "With password the change is recorded",
+			password: "secret",
+			update:   withPassword,
+			recorded: true,
+		},
+		{
+			name:        "Fails to record the change",
+			password:    "secret",
+			update:      withPassword,
+			recordErr:   failed,
+			recorded:    true,
+			expectedErr: failed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mocks.NewUserProvisionerInterface(t)
+			users.On("UpdateUser", mock.Anything, tt.update).Return(tt.update, nil)
+			enc := mocks.NewEncryptor(t)
+			enc.On("GenerateSalt", 64, true, true).Return("salt").Maybe()
+			enc.On("Encrypt", "secret", "salt", "key").Return("hash", nil).Maybe()
+			outbox := mocks.NewOutboxInterface(t)
+			if tt.recorded {
+				outbox.On("Record", mock.Anything, models.UserPasswordChanged, models.NewUserEvent(tt.update, 5)).Return(tt.recordErr)
+			}
+
+			s := NewProvisioningService(users, mocks.NewGroupRepositoryInterface(t), inTx(t), outbox, config.Encrypt{Password: "key"}, enc)
+
+			got, err := s.ReplaceUser(context.Background(), user, tt.password)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Equal(errors.GetFirstNestedError(err), tt.expectedErr), "%v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.update, got)
+		})
+	}
+}
+
+func TestProvisioningService_Groups(t *testing.T) {
+	group := models.Groups{ID: 3, OrganizationID: 2, Name: "admins"}
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	groups := mocks.NewGroupRepositoryInterface(t)
+	groups.On("GetGroup", mock.Anything, int32(2), int32(3)).Return(group, nil)
+	groups.On("DeleteGroup", mock.Anything, int32(2), int32(4)).Return(notFound)
+	groups.On("GetUserGroups", mock.Anything, int32(2), []int32{7}).Return(map[int32][]models.Groups{7: {group}}, nil)
+
+	s := NewProvisioningService(mocks.NewUserProvisionerInterface(t), groups, mocks.NewTransactorInterface(t), mocks.NewOutboxInterface(t), config.Encrypt{}, mocks.NewEncryptor(t))
+
+	got, err := s.GetGroup(context.Background(), 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, group, got)
+
+	userGroups, err := s.GetUserGroups(context.Background(), 2, []int32{7})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32][]models.Groups{7: {group}}, userGroups)
+
+	err = s.DeleteGroup(context.Background(), 2, 4)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type SCIMTokenService struct {
+	r    models.SCIMTokenRepositoryInterface
+	orgs models.OrganizationReaderInterface
+}
+
+type SCIMTokenServiceInterface interface {
+	// CreateToken returns the stored token together with its secret, which
+	// cannot be recovered afterwards.
+	CreateToken(ctx context.Context, organizationID int32, description string) (models.SCIMTokens, string, error)
+	GetTokens(ctx context.Context, organizationID int32) ([]models.SCIMTokens, error)
+	RevokeToken(ctx context.Context, organizationID, id int32) error
+	// Authenticate returns the organization the token provisions.
+	Authenticate(ctx context.Context, token string) (models.Organizations, error)
+}
+
+func NewSCIMTokenService(r models.SCIMTokenRepositoryInterface, orgs models.OrganizationReaderInterface) SCIMTokenService {
+	return SCIMTokenService{
+		r:    r,
+		orgs: orgs,
+	}
+}
+
+func (s SCIMTokenService) CreateToken(ctx context.Context, organizationID int32, description string) (models.SCIMTokens, string, error) {
+	const op errors.Op = "services.CreateToken"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, organizationID); err != nil {
+		return models.SCIMTokens{}, "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	secret, err := newSecretToken()
+	if err != nil {
+		return models.SCIMTokens{}, "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SCIM token"),
+		)
+	}
+
+	token, err := s.r.AddSCIMToken(ctx, models.SCIMTokens{
+		OrganizationID: organizationID,
+		Description:    description,
+		TokenHash:      hashToken(secret),
+	})
+	if err != nil {
+		return models.SCIMTokens{}, "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SCIM token"),
+		)
+	}
+
+	return token, secret, nil
+}
+
+func (s SCIMTokenService) GetTokens(ctx context.Context, organizationID int32) ([]models.SCIMTokens, error) {
+	const op errors.Op = "services.GetTokens"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, organizationID); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	tokens, err := s.r.GetSCIMTokens(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get SCIM tokens"),
+		)
+	}
+
+	return tokens, nil
+}
+
+func (s SCIMTokenService) RevokeToken(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "services.RevokeToken"
+
+	if err := s.r.DeleteSCIMToken(ctx, organizationID, id); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to revoke SCIM token"),
+		)
+	}
+
+	return nil
+}
+
+func (s SCIMTokenService) Authenticate(ctx context.Context, token string) (models.Organizations, error) {
+	const op errors.Op = "services.Authenticate"
+
+	if token == "" {
+		return models.Organizations{}, invalidSCIMToken(op, fmt.Errorf("missing bearer token"))
+	}
+
+	stored, err := s.r.UseSCIMToken(ctx, hashToken(token))
+	if errors.IsKind(err, errors.NotFound) {
+		// the not found is not nested so that the caller gets unauthorized
+		return models.Organizations{}, invalidSCIMToken(op, fmt.Errorf("unknown SCIM token"))
+	}
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to authenticate SCIM client"),
+		)
+	}
+
+	org, err := s.orgs.GetOrganizationByID(ctx, stored.OrganizationID)
+	if err != nil {
+		return models.Organizations{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	return org, nil
+}
+
+func invalidSCIMToken(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Invalid SCIM token"),
+		errors.KindUnauthorized(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
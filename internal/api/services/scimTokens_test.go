@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSCIMTokenService(t *testing.T) {
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	r := mocks.NewSCIMTokenRepositoryInterface(t)
+	orgs := mocks.NewOrganizationReaderInterface(t)
+	orgs.On("GetOrganizationByID", mock.Anything, int32(2)).Return(models.Organizations{ID: 2}, nil)
+	orgs.On("GetOrganizationByID", mock.Anything, int32(9)).Return(models.Organizations{}, notFound)
+
+	var stored models.SCIMTokens
+	r.On("AddSCIMToken", mock.Anything, mock.Anything).Return(func(_ context.Context, token models.SCIMTokens) models.SCIMTokens {
+		token.ID = 5
+		stored = token
+		return token
+	}, nil)
+
+	s := NewSCIMTokenService(r, orgs)
+
+	token, secret, err := s.CreateToken(context.Background(), 2, "okta")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.Equal(t, int32(5), token.ID)
+	assert.Equal(t, "okta", token.Description)
+	// only the hash of the secret is stored
+	assert.Equal(t, hashToken(secret), stored.TokenHash)
+
+	_, _, err = s.CreateToken(context.Background(), 9, "okta")
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+
+	r.On("UseSCIMToken", mock.Anything, hashToken(secret)).Return(stored, nil)
+	r.On("UseSCIMToken", mock.Anything, hashToken("unknown")).Return(models.SCIMTokens{}, notFound)
+
+	org, err := s.Authenticate(context.Background(), secret)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), org.ID)
+
+	_, err = s.Authenticate(context.Background(), "unknown")
+	assert.True(t, errors.IsKind(err, errors.Unauthorized))
+
+	_, err = s.Authenticate(context.Background(), "")
+	assert.True(t, errors.IsKind(err, errors.Unauthorized))
+}
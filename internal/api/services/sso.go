@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/sso"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type SSOService struct {
+	registry    *sso.Registry
+	users       models.UserReaderInterface
+	provisioner models.UserProvisionerInterface
+	tx          models.TransactorInterface
+	outbox      models.OutboxInterface
+}
+
+type SSOServiceInterface interface {
+	Metadata(org models.Organizations) ([]byte, error)
+	LoginURL(org models.Organizations) (string, error)
+	// ConsumeAssertion returns the identity of the stored user the assertion
+	// is about, users seen for the first time are provisioned without
+	// credentials.
+	ConsumeAssertion(ctx context.Context, org models.Organizations, samlResponse, relayState string) (models.Identity, error)
+}
+
+func NewSSOService(registry *sso.Registry, users models.UserReaderInterface, provisioner models.UserProvisionerInterface,
+	tx models.TransactorInterface, outbox models.OutboxInterface) SSOService {
+	return SSOService{
+		registry:    registry,
+		users:       users,
+		provisioner: provisioner,
+		tx:          tx,
+		outbox:      outbox,
+	}
+}
+
+func (s SSOService) Metadata(org models.Organizations) ([]byte, error) {
+	const op errors.Op = "services.Metadata"
+
+	provider, err := s.registry.Get(org.Slug)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	metadata, err := provider.Metadata()
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to build service provider metadata"),
+		)
+	}
+
+	return metadata, nil
+}
+
+func (s SSOService) LoginURL(org models.Organizations) (string, error) {
+	const op errors.Op = "services.LoginURL"
+
+	provider, err := s.registry.Get(org.Slug)
+	if err != nil {
+		return "", errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	redirect, err := provider.AuthnRequestURL()
+	if err != nil {
+		return "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create authentication request"),
+		)
+	}
+
+	return redirect, nil
+}
+
+func (s SSOService) ConsumeAssertion(ctx context.Context, org models.Organizations, samlResponse, relayState string) (models.Identity, error) {
+	const op errors.Op = "services.ConsumeAssertion"
+
+	provider, err := s.registry.Get(org.Slug)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	identity, err := provider.ConsumeResponse(samlResponse, relayState)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to consume SAML assertion"),
+		)
+	}
+	identity.User.OrganizationID = org.ID
+
+	user, err := s.linkUser(ctx, identity.User)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to link SAML identity to a user"),
+		)
+	}
+	identity.User = user
+
+	return identity, nil
+}
+
+// linkUser returns the stored user of the asserted one, looked up by email
+// and else by username within the organization. Unknown users are
+// provisioned.
+func (s SSOService) linkUser(ctx context.Context, asserted models.Users) (models.Users, error) {
+	const op errors.Op = "services.linkUser"
+
+	var user models.Users
+	var err error
+	switch {
+	case asserted.Email != "":
+		user, err = s.users.GetUserByEmail(ctx, asserted.OrganizationID, asserted.Email)
+	case asserted.Username != "":
+		user, err = s.users.GetUserByUsername(ctx, asserted.OrganizationID, asserted.Username)
+	default:
+		return models.Users{}, unlinkableIdentity(op, fmt.Errorf("assertion has neither email nor username"))
+	}
+	if err == nil {
+		if !user.Active {
+			return models.Users{}, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("user %d is inactive", user.ID)),
+				errors.WithMessage("User is inactive"),
+				errors.KindForbidden(),
+				errors.WithSeverity(zerolog.WarnLevel),
+			)
+		}
+		return user, nil
+	}
+	if !errors.IsKind(err, errors.NotFound) {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get user"),
+		)
+	}
+
+	if asserted.Email == "" {
+		return models.Users{}, unlinkableIdentity(op, fmt.Errorf("unknown user %s has no email to be provisioned with", asserted.Username))
+	}
+	if asserted.Username == "" {
+		asserted.Username = asserted.Email
+	}
+	asserted.Active = true
+
+	var created models.Users
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.provisioner.ProvisionUser(ctx, asserted); err != nil {
+			return err
+		}
+		return s.outbox.Record(ctx, models.UserRegistered, models.NewUserEvent(created, int64(created.ID)))
+	})
+	if err != nil {
+		return models.Users{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to provision user"),
+		)
+	}
+
+	return created, nil
+}
+
+func unlinkableIdentity(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("The SAML assertion does not identify a user"),
+		errors.KindUnauthorized(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/internal/api/sso"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSSOService_TenantWithoutSAML(t *testing.T) {
+	registry, err := sso.NewRegistry(config.SAML{}, &clock.RealClock{})
+	assert.NoError(t, err)
+
+	s := NewSSOService(registry, mocks.NewUserReaderInterface(t), mocks.NewUserProvisionerInterface(t), inTx(t), mocks.NewOutboxInterface(t))
+
+	org := models.Organizations{ID: 2, Slug: "acme"}
+
+	_, err = s.Metadata(org)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+
+	_, err = s.LoginURL(org)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+
+	_, err = s.ConsumeAssertion(context.Background(), org, "response", "")
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+}
+
+func TestSSOService_linkUser(t *testing.T) {
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+	stored := models.Users{ID: 4, OrganizationID: 2, Username: "jane", Email: "jane@example.com", Active: true}
+
+	users := mocks.NewUserReaderInterface(t)
+	users.On("GetUserByEmail", mock.Anything, int32(2), "jane@example.com").Return(stored, nil)
+	users.On("GetUserByEmail", mock.Anything, int32(2), "john@example.com").Return(models.Users{}, notFound)
+	users.On("GetUserByEmail", mock.Anything, int32(2), "gone@example.com").Return(models.Users{ID: 6, Active: false}, nil)
+	users.On("GetUserByUsername", mock.Anything, int32(2), "nobody").Return(models.Users{}, notFound)
+
+	provisioner := mocks.NewUserProvisionerInterface(t)
+	provisioner.On("ProvisionUser", mock.Anything, models.Users{
+		OrganizationID: 2,
+		Username:       "john@example.com",
+		Email:          "john@example.com",
+		Active:         true,
+	}).Return(func(_ context.Context, user models.Users) models.Users {
+		user.ID = 5
+		return user
+	}, nil)
+
+	outbox := mocks.NewOutboxInterface(t)
+	outbox.On("Record", mock.Anything, models.UserRegistered, models.UserEvent{
+		OrganizationID: 2,
+		UserID:         5,
+		Username:       "john@example.com",
+		Email:          "john@example.com",
+	}).Return(nil)
+
+	s := NewSSOService(nil, users, provisioner, inTx(t), outbox)
+
+	user, err := s.linkUser(context.Background(), models.Users{OrganizationID: 2, Username: "j.doe", Email: "jane@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, stored, user, "known users are looked up by email")
+
+	user, err = s.linkUser(context.Background(), models.Users{OrganizationID: 2, Email: "john@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), user.ID, "unknown users are provisioned")
+
+	_, err = s.linkUser(context.Background(), models.Users{OrganizationID: 2, Email: "gone@example.com"})
+	assert.True(t, errors.IsKind(err, errors.Forbidden))
+
+	_, err = s.linkUser(context.Background(), models.Users{OrganizationID: 2, Username: "nobody"})
+	assert.True(t, errors.IsKind(err, errors.Unauthorized), "users without email cannot be provisioned")
+
+	_, err = s.linkUser(context.Background(), models.Users{OrganizationID: 2})
+	assert.True(t, errors.IsKind(err, errors.Unauthorized))
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/Pedrommb91/go-auth/config"
 	"github.com/Pedrommb91/go-auth/internal/api/models"
 	"github.com/Pedrommb91/go-auth/pkg/encrypt"
@@ -9,23 +10,27 @@ import (
 
 type UserService struct {
 	r         models.UserRepositoryInterface
+	tx        models.TransactorInterface
+	outbox    models.OutboxInterface
 	encrypt   config.Encrypt
 	encryptor encrypt.Encryptor
 }
 
 type UserServiceInterface interface {
-	AddUser(username, email, password string) (int64, error)
+	AddUser(ctx context.Context, organizationID int32, username, email, password string) (int64, error)
 }
 
-func NewUserService(r models.UserRepositoryInterface, encrypt config.Encrypt, encryptor encrypt.Encryptor) UserService {
+func NewUserService(r models.UserRepositoryInterface, tx models.TransactorInterface, outbox models.OutboxInterface, encrypt config.Encrypt, encryptor encrypt.Encryptor) UserService {
 	return UserService{
 		r:         r,
+		tx:        tx,
+		outbox:    outbox,
 		encrypt:   encrypt,
 		encryptor: encryptor,
 	}
 }
 
-func (s UserService) AddUser(username, email, password string) (int64, error) {
+func (s UserService) AddUser(ctx context.Context, organizationID int32, username, email, password string) (int64, error) {
 	const op errors.Op = "services.AddUser"
 
 	salt := s.encryptor.GenerateSalt(64, true, true)
@@ -38,13 +43,24 @@ func (s UserService) AddUser(username, email, password string) (int64, error) {
 		)
 	}
 
-	id, err := s.r.AddUser(models.Users{
-		Username: username,
-		Email:    email,
+	user := models.Users{
+		OrganizationID: organizationID,
+		Username:       username,
+		Email:          email,
+		Active:         true,
 		Credentials: models.Credentials{
 			Salt:     salt,
 			PassHash: passHash,
 		},
+	}
+
+	var id int64
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = s.r.AddUser(ctx, user); err != nil {
+			return err
+		}
+		return s.outbox.Record(ctx, models.UserRegistered, models.NewUserEvent(user, id))
 	})
 	if err != nil {
 		return 0, errors.Build(
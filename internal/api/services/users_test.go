@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"github.com/stretchr/testify/mock"
 	"testing"
 
 	"github.com/Pedrommb91/go-auth/config"
@@ -25,6 +27,9 @@ func TestUserService_AddUser(t *testing.T) {
 	type encryptMockResponse struct {
 		err error
 	}
+	type recordMockResponse struct {
+		err error
+	}
 	type fields struct {
 		encrypt config.Encrypt
 	}
@@ -38,6 +43,7 @@ func TestUserService_AddUser(t *testing.T) {
 		name                string
 		addUserMockResponse addUserMockResponse
 		encryptMockResponse encryptMockResponse
+		recordMockResponse  recordMockResponse
 		fields              fields
 		args                args
 		want                int64
@@ -120,25 +126,62 @@ func TestUserService_AddUser(t *testing.T) {
 				errors.WithError(fmt.Errorf("failed to add user")),
 			),
 		},
+		{
+			name: "Fails to record the registration",
+			addUserMockResponse: addUserMockResponse{
+				response: 1,
+				err:      nil,
+			},
+			encryptMockResponse: encryptMockResponse{
+				err: nil,
+			},
+			recordMockResponse: recordMockResponse{
+				err: errors.Build(
+					errors.WithError(fmt.Errorf("failed to record event")),
+				),
+			},
+			fields: fields{
+				encrypt: config.Encrypt{
+					Password: faker.Password(),
+				},
+			},
+			args: args{
+				username: faker.Username(),
+				email:    faker.Email(),
+				password: faker.Password(),
+				salt:     faker.Password(),
+			},
+			want: 0,
+			expectedErr: errors.Build(
+				errors.WithError(fmt.Errorf("failed to record event")),
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := mocks.NewUserRepositoryInterface(t)
-			r.On("AddUser", models.Users{
-				Username: tt.args.username,
-				Email:    tt.args.email,
+			user := models.Users{
+				OrganizationID: 1,
+				Username:       tt.args.username,
+				Email:          tt.args.email,
+				Active:         true,
 				Credentials: models.Credentials{
 					Salt:     tt.args.salt,
 					PassHash: tt.args.password,
 				},
-			}).Return(tt.addUserMockResponse.response, tt.addUserMockResponse.err).Maybe()
+			}
+			r := mocks.NewUserRepositoryInterface(t)
+			r.On("AddUser", mock.Anything, user).Return(tt.addUserMockResponse.response, tt.addUserMockResponse.err).Maybe()
+
+			outbox := mocks.NewOutboxInterface(t)
+			outbox.On("Record", mock.Anything, models.UserRegistered, models.NewUserEvent(user, tt.addUserMockResponse.response)).
+				Return(tt.recordMockResponse.err).Maybe()
 
 			enc := mocks.NewEncryptor(t)
 			enc.On("GenerateSalt", 64, true, true).Return(tt.args.salt).Maybe()
 			enc.On("Encrypt", tt.args.password, tt.args.salt, tt.fields.encrypt.Password).Return(tt.args.password, tt.encryptMockResponse.err).Maybe() // no encryption
 
-			s := NewUserService(r, tt.fields.encrypt, enc)
-			got, err := s.AddUser(tt.args.username, tt.args.email, tt.args.password)
+			s := NewUserService(r, inTx(t), outbox, tt.fields.encrypt, enc)
+			got, err := s.AddUser(context.Background(), 1, tt.args.username, tt.args.email, tt.args.password)
 			if !errors.Equal(errors.GetFirstNestedError(err), tt.expectedErr) {
 				t.Errorf("UserService.AddUser() error = %v, wantErr %v", err, tt.expectedErr)
 				return
@@ -149,3 +192,12 @@ func TestUserService_AddUser(t *testing.T) {
 		})
 	}
 }
+
+// inTx returns a transactor running the units of work without a transaction.
+func inTx(t *testing.T) *mocks.TransactorInterface {
+	tx := mocks.NewTransactorInterface(t)
+	tx.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	return tx
+}
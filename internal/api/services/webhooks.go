@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/outbox"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/Pedrommb91/go-auth/pkg/webhook"
+	"github.com/rs/zerolog"
+)
+
+type WebhookService struct {
+	r     models.WebhookRepositoryInterface
+	orgs  models.OrganizationReaderInterface
+	guard *webhook.Guard
+	clock clock.Clock
+}
+
+type WebhookServiceInterface interface {
+	// CreateWebhook subscribes the url to the event types, a secret is
+	// generated when none is given.
+	CreateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error)
+	GetWebhooks(ctx context.Context, organizationID int32) ([]models.Webhooks, error)
+	// UpdateWebhook keeps the secret of the webhook when none is given.
+	UpdateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error)
+	DeleteWebhook(ctx context.Context, organizationID, id int32) error
+	GetDeliveries(ctx context.Context, organizationID, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[models.WebhookDeliveries], error)
+	// Redeliver queues the delivery again, whatever its state, with all its
+	// attempts available.
+	Redeliver(ctx context.Context, organizationID, webhookID int32, id int64) (models.WebhookDeliveries, error)
+}
+
+// NewWebhookService refuses the webhooks whose url the guard does not allow.
+func NewWebhookService(r models.WebhookRepositoryInterface, orgs models.OrganizationReaderInterface, guard *webhook.Guard, c clock.Clock) WebhookService {
+	return WebhookService{
+		r:     r,
+		orgs:  orgs,
+		guard: guard,
+		clock: c,
+	}
+}
+
+func (s WebhookService) CreateWebhook(ctx context.Context, wh models.Webhooks) (models.Webhooks, error) {
+	const op errors.Op = "services.CreateWebhook"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, wh.OrganizationID); err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+	if err := s.validateWebhook(ctx, wh); err != nil {
+		return models.Webhooks{}, invalidWebhook(op, err)
+	}
+
+	if wh.Secret == "" {
+		secret, err := newSecretToken()
+		if err != nil {
+			return models.Webhooks{}, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to create webhook"),
+			)
+		}
+		wh.Secret = secret
+	}
+
+	created, err := s.r.AddWebhook(ctx, wh)
+	if err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create webhook"),
+		)
+	}
+
+	return created, nil
+}
+
+func (s WebhookService) GetWebhooks(ctx context.Context, organizationID int32) ([]models.Webhooks, error) {
+	const op errors.Op = "services.GetWebhooks"
+
+	if _, err := s.orgs.GetOrganizationByID(ctx, organizationID); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get organization"),
+		)
+	}
+
+	webhooks, err := s.r.GetWebhooks(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhooks"),
+		)
+	}
+
+	return webhooks, nil
+}
+
+func (s WebhookService) UpdateWebhook(ctx context.Context, wh models.Webhooks) (models.Webhooks, error) {
+	const op errors.Op = "services.UpdateWebhook"
+
+	if err := s.validateWebhook(ctx, wh); err != nil {
+		return models.Webhooks{}, invalidWebhook(op, err)
+	}
+
+	updated, err := s.r.UpdateWebhook(ctx, wh)
+	if err != nil {
+		return models.Webhooks{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to update webhook"),
+		)
+	}
+
+	return updated, nil
+}
+
+func (s WebhookService) DeleteWebhook(ctx context.Context, organizationID, id int32) error {
+	const op errors.Op = "services.DeleteWebhook"
+
+	if err := s.r.DeleteWebhook(ctx, organizationID, id); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to delete webhook"),
+		)
+	}
+
+	return nil
+}
+
+func (s WebhookService) GetDeliveries(ctx context.Context, organizationID, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[models.WebhookDeliveries], error) {
+	const op errors.Op = "services.GetDeliveries"
+
+	// the deliveries are only listed through a webhook of the organization
+	if _, err := s.r.GetWebhook(ctx, organizationID, webhookID); err != nil {
+		return pagination.Page[models.WebhookDeliveries]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook"),
+		)
+	}
+
+	page, err := s.r.GetDeliveries(ctx, webhookID, cursor, size)
+	if err != nil {
+		return pagination.Page[models.WebhookDeliveries]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook deliveries"),
+		)
+	}
+
+	return page, nil
+}
+
+func (s WebhookService) Redeliver(ctx context.Context, organizationID, webhookID int32, id int64) (models.WebhookDeliveries, error) {
+	const op errors.Op = "services.Redeliver"
+
+	if _, err := s.r.GetWebhook(ctx, organizationID, webhookID); err != nil {
+		return models.WebhookDeliveries{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhook"),
+		)
+	}
+
+	delivery, err := s.r.Redeliver(ctx, webhookID, id, s.clock.Now().UTC())
+	if err != nil {
+		return models.WebhookDeliveries{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to redeliver webhook delivery"),
+		)
+	}
+
+	return delivery, nil
+}
+
+// Publish makes the service an outbox sink, the event is queued for every
+// active webhook of its organization subscribed to its type. The delivered
+// body is the event as the other sinks receive it. When queuing fails the
+// outbox publishes the event again, the webhooks it was already queued for
+// are skipped by AddDelivery.
+func (s WebhookService) Publish(ctx context.Context, msg outbox.Message) error {
+	const op errors.Op = "services.Publish"
+
+	var event struct {
+		OrganizationID int32 `json:"organization_id"`
+	}
+	if err := json.Unmarshal(msg.Payload, &event); err != nil || event.OrganizationID == 0 {
+		// events of no organization have no webhooks
+		return nil
+	}
+
+	webhooks, err := s.r.GetWebhooks(ctx, event.OrganizationID)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to get webhooks"),
+		)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to encode event"),
+		)
+	}
+
+	for _, wh := range webhooks {
+		if !wh.Active || !wh.Subscribed(msg.Type) {
+			continue
+		}
+		err := s.r.AddDelivery(ctx, models.WebhookDeliveries{
+			WebhookID:     wh.ID,
+			EventID:       msg.ID,
+			EventType:     msg.Type,
+			Payload:       string(body),
+			NextAttemptAt: s.clock.Now().UTC(),
+		})
+		if err != nil {
+			return errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage(fmt.Sprintf("Failed to queue event %d for webhook %d", msg.ID, wh.ID)),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateWebhook checks the webhook before it is stored, its url must not
+// reach the network of the service.
+func (s WebhookService) validateWebhook(ctx context.Context, wh models.Webhooks) error {
+	u, err := url.Parse(wh.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook url must be an absolute http or https url")
+	}
+	if len(wh.EventTypes) == 0 {
+		return fmt.Errorf("webhook must subscribe to at least one event type")
+	}
+	for _, t := range wh.EventTypes {
+		known := false
+		for _, e := range models.EventTypes {
+			known = known || e == t
+		}
+		if !known {
+			return fmt.Errorf("unknown event type %q", t)
+		}
+	}
+	return s.guard.CheckURL(ctx, wh.URL)
+}
+
+func invalidWebhook(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage(err.Error()),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+const (
+	defaultWebhookBatchSize   = 50
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBackoff     = 30 * time.Second
+	maxWebhookBackoff         = 6 * time.Hour
+	// deliveryLease is how long a claimed delivery is hidden from the other
+	// dispatchers, it bounds the request of the delivery
+	deliveryLease = time.Minute
+)
+
+// WebhookDispatcher sends the due deliveries to their webhooks. Failed
+// deliveries are retried with an exponential backoff, once their attempts
+// run out they are dead until redelivered.
+type WebhookDispatcher struct {
+	r      models.WebhookRepositoryInterface
+	sender *webhook.Sender
+	log    logger.Interface
+	clock  clock.Clock
+	cfg    config.Webhooks
+}
+
+func NewWebhookDispatcher(r models.WebhookRepositoryInterface, sender *webhook.Sender, l logger.Interface, c clock.Clock, cfg config.Webhooks) *WebhookDispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultWebhookBackoff
+	}
+	return &WebhookDispatcher{
+		r:      r,
+		sender: sender,
+		log:    l,
+		clock:  c,
+		cfg:    cfg,
+	}
+}
+
+// Run sends the due deliveries until ctx is done. The requests in progress
+// are not interrupted, so Run returns once they are over.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more deliveries are due
+		for {
+			sent, err := d.Dispatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.log.Error(err)
+			}
+			if err != nil || sent < d.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch sends a batch of due deliveries and returns how many were tried.
+// It stops early when ctx is done.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) (int, error) {
+	const op errors.Op = "services.Dispatch"
+
+	now := d.clock.Now().UTC()
+	deliveries, err := d.r.ClaimDeliveries(ctx, now, now.Add(deliveryLease), d.cfg.BatchSize)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to claim webhook deliveries"),
+		)
+	}
+
+	for i, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return i, nil
+		}
+		if err := d.send(delivery); err != nil {
+			return i, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage(fmt.Sprintf("Failed to save webhook delivery %d", delivery.ID)),
+			)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// send tries the claimed delivery and saves its outcome, the context of the
+// dispatcher is not used so a shutdown does not cut a request short.
+func (d *WebhookDispatcher) send(delivery models.WebhookDeliveries) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryLease)
+	defer cancel()
+
+	var code int
+	var failure error
+	if delivery.Webhook.Active {
+		code, failure = d.sender.Send(ctx, webhook.Request{
+			URL:        delivery.Webhook.URL,
+			Secret:     delivery.Webhook.Secret,
+			DeliveryID: delivery.ID,
+			EventType:  delivery.EventType,
+			Body:       []byte(delivery.Payload),
+		})
+	} else {
+		failure = fmt.Errorf("webhook %d is inactive", delivery.WebhookID)
+	}
+
+	now := d.clock.Now().UTC()
+	delivery.LastStatusCode = int32(code)
+	delivery.LastError = ""
+	delivery.NextAttemptAt = now
+	switch {
+	case failure == nil:
+		delivery.Status = models.DeliveryDelivered
+		delivery.DeliveredAt = now
+	case int(delivery.Attempts) >= d.cfg.MaxAttempts || !delivery.Webhook.Active:
+		d.log.Error("webhook delivery %d is dead after %d attempts: %s", delivery.ID, delivery.Attempts, failure)
+		delivery.Status = models.DeliveryDead
+		delivery.LastError = failure.Error()
+	default:
+		d.log.Warn("webhook delivery %d failed, attempt %d of %d: %s", delivery.ID, delivery.Attempts, d.cfg.MaxAttempts, failure)
+		delivery.Status = models.DeliveryPending
+		delivery.LastError = failure.Error()
+		delivery.NextAttemptAt = now.Add(d.wait(int(delivery.Attempts)))
+	}
+
+	return d.r.SaveAttempt(ctx, delivery)
+}
+
+// wait is the backoff after the given number of failed attempts.
+func (d *WebhookDispatcher) wait(attempts int) time.Duration {
+	wait := d.cfg.Backoff
+	for i := 1; i < attempts && wait < maxWebhookBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxWebhookBackoff {
+		return maxWebhookBackoff
+	}
+	return wait
+}
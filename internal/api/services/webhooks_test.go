@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/outbox"
+	"github.com/Pedrommb91/go-auth/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookNow = time.Date(2023, 8, 15, 12, 0, 0, 0, time.UTC)
+
+// webhookHosts resolves the names it holds, the other ones are unknown.
+type webhookHosts map[string]string
+
+func (h webhookHosts) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ip, ok := h[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+func newWebhookGuard(t *testing.T) *webhook.Guard {
+	guard, err := webhook.NewGuard(nil, webhookHosts{
+		"example.com":          "93.184.216.34",
+		"internal.example.com": "10.0.0.5",
+	})
+	require.NoError(t, err)
+	return guard
+}
+
+func TestWebhookService(t *testing.T) {
+	notFound := errors.Build(
+		errors.WithError(fmt.Errorf("no rows")),
+		errors.KindNotFound(),
+	)
+
+	r := mocks.NewWebhookRepositoryInterface(t)
+	orgs := mocks.NewOrganizationReaderInterface(t)
+	c := mocks.NewClock(t)
+	orgs.On("GetOrganizationByID", mock.Anything, int32(2)).Return(models.Organizations{ID: 2}, nil)
+	r.On("AddWebhook", mock.Anything, mock.Anything).Return(func(_ context.Context, wh models.Webhooks) models.Webhooks {
+		wh.ID = 5
+		return wh
+	}, nil)
+
+	s := NewWebhookService(r, orgs, newWebhookGuard(t), c)
+
+	wh, err := s.CreateWebhook(context.Background(), models.Webhooks{
+		OrganizationID: 2,
+		URL:            "https://example.com/hooks",
+		EventTypes:     []string{models.UserRegistered},
+		Active:         true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), wh.ID)
+	assert.NotEmpty(t, wh.Secret, "a secret is generated")
+
+	invalid := []models.Webhooks{
+		{OrganizationID: 2, URL: "ftp://example.com", EventTypes: []string{models.UserRegistered}},
+		{OrganizationID: 2, URL: "/hooks", EventTypes: []string{models.UserRegistered}},
+		{OrganizationID: 2, URL: "https://example.com/hooks"},
+		{OrganizationID: 2, URL: "https://example.com/hooks", EventTypes: []string{"user.deleted"}},
+		{OrganizationID: 2, URL: "http://127.0.0.1:8080/hooks", EventTypes: []string{models.UserRegistered}},
+		{OrganizationID: 2, URL: "http://169.254.169.254/latest", EventTypes: []string{models.UserRegistered}},
+		{OrganizationID: 2, URL: "https://internal.example.com/hooks", EventTypes: []string{models.UserRegistered}},
+	}
+	for _, wh := range invalid {
+		_, err := s.CreateWebhook(context.Background(), wh)
+		assert.True(t, errors.IsKind(err, errors.BadRequest), "%+v", wh)
+	}
+
+	r.On("GetWebhook", mock.Anything, int32(2), int32(5)).Return(models.Webhooks{ID: 5, OrganizationID: 2}, nil)
+	r.On("GetWebhook", mock.Anything, int32(3), int32(5)).Return(models.Webhooks{}, notFound)
+	c.On("Now").Return(webhookNow)
+	r.On("Redeliver", mock.Anything, int32(5), int64(7), webhookNow).
+		Return(models.WebhookDeliveries{ID: 7, WebhookID: 5, Status: models.DeliveryPending}, nil)
+
+	delivery, err := s.Redeliver(context.Background(), 2, 5, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeliveryPending, delivery.Status)
+
+	// the deliveries of the webhooks of other organizations are not found
+	_, err = s.Redeliver(context.Background(), 3, 5, 7)
+	assert.True(t, errors.IsKind(err, errors.NotFound))
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	r := mocks.NewWebhookRepositoryInterface(t)
+	c := mocks.NewClock(t)
+	c.On("Now").Return(webhookNow)
+	r.On("GetWebhooks", mock.Anything, int32(2)).Return([]models.Webhooks{
+		{ID: 1, OrganizationID: 2, EventTypes: []string{models.UserRegistered}, Active: true},
+		{ID: 2, OrganizationID: 2, EventTypes: []string{models.UserPasswordChanged}, Active: true},
+		{ID: 3, OrganizationID: 2, EventTypes: []string{models.UserRegistered}, Active: false},
+	}, nil)
+
+	var queued []models.WebhookDeliveries
+	r.On("AddDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = append(queued, args.Get(1).(models.WebhookDeliveries))
+	}).Return(nil)
+
+	s := NewWebhookService(r, mocks.NewOrganizationReaderInterface(t), newWebhookGuard(t), c)
+	msg := outbox.Message{
+		ID:        11,
+		Type:      models.UserRegistered,
+		Payload:   json.RawMessage(`{"organization_id":2,"user_id":4}`),
+		CreatedAt: webhookNow,
+	}
+	require.NoError(t, s.Publish(context.Background(), msg))
+
+	// only the active webhook subscribed to the event gets it
+	require.Len(t, queued, 1)
+	assert.Equal(t, int32(1), queued[0].WebhookID)
+	assert.Equal(t, int64(11), queued[0].EventID)
+	assert.Equal(t, webhookNow, queued[0].NextAttemptAt)
+	var body outbox.Message
+	require.NoError(t, json.Unmarshal([]byte(queued[0].Payload), &body))
+	assert.Equal(t, msg, body)
+
+	// events of no organization have no webhooks
+	msg.Payload = json.RawMessage(`{"user_id":4}`)
+	assert.NoError(t, s.Publish(context.Background(), msg))
+}
+
+func TestWebhookDispatcher_Dispatch(t *testing.T) {
+	status := http.StatusOK
+	var verified error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified = webhook.Verify("secret", r.Header.Get(webhook.SignatureHeader), body, time.Minute, webhookNow)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c := mocks.NewClock(t)
+	c.On("Now").Return(webhookNow)
+	cfg := config.Webhooks{BatchSize: 10, MaxAttempts: 3, Backoff: time.Minute}
+	hook := models.Webhooks{ID: 1, URL: srv.URL, Secret: "secret", Active: true}
+
+	tests := []struct {
+		name     string
+		status   int
+		attempts int32
+		active   bool
+		want     models.WebhookDeliveries
+	}{
+		{
+			name:     "Delivered",
+			status:   http.StatusNoContent,
+			attempts: 1,
+			active:   true,
+			want:     models.WebhookDeliveries{Status: models.DeliveryDelivered, LastStatusCode: 204, DeliveredAt: webhookNow, NextAttemptAt: webhookNow},
+		},
+		{
+			name:     "Retried with backoff",
+			status:   http.StatusInternalServerError,
+			attempts: 2,
+			active:   true,
+			want:     models.WebhookDeliveries{Status: models.DeliveryPending, LastStatusCode: 500, NextAttemptAt: webhookNow.Add(2 * time.Minute)},
+		},
+		{
+			name:     "Dead after the last attempt",
+			status:   http.StatusBadGateway,
+			attempts: 3,
+			active:   true,
+			want:     models.WebhookDeliveries{Status: models.DeliveryDead, LastStatusCode: 502, NextAttemptAt: webhookNow},
+		},
+		{
+			name:     "Dead once the webhook is inactive",
+			attempts: 1,
+			want:     models.WebhookDeliveries{Status: models.DeliveryDead, NextAttemptAt: webhookNow},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			hook.Active = tt.active
+			claimed := models.WebhookDeliveries{ID: 7, WebhookID: 1, EventType: models.UserRegistered, Payload: `{"id":11}`, Attempts: tt.attempts, Webhook: hook}
+
+			r := mocks.NewWebhookRepositoryInterface(t)
+			r.On("ClaimDeliveries", mock.Anything, webhookNow, webhookNow.Add(deliveryLease), 10).
+				Return([]models.WebhookDeliveries{claimed}, nil)
+			var saved models.WebhookDeliveries
+			r.On("SaveAttempt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(models.WebhookDeliveries)
+			}).Return(nil)
+
+			d := NewWebhookDispatcher(r, webhook.NewSender(srv.Client(), c), logger.New("error"), c, cfg)
+			sent, err := d.Dispatch(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, sent)
+
+			assert.Equal(t, tt.want.Status, saved.Status)
+			assert.Equal(t, tt.want.LastStatusCode, saved.LastStatusCode)
+			assert.Equal(t, tt.want.DeliveredAt, saved.DeliveredAt)
+			assert.Equal(t, tt.want.NextAttemptAt, saved.NextAttemptAt)
+			assert.Equal(t, tt.want.Status == models.DeliveryDelivered, saved.LastError == "")
+			if tt.active {
+				assert.NoError(t, verified, "the requests are signed with the secret of the webhook")
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package sso
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	nerrors "errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/crewjam/saml"
+	"github.com/rs/zerolog"
+)
+
+const (
+	SAMLBackend = "saml"
+
+	// requestTTL is how long an authentication request waits for the response
+	requestTTL = 10 * time.Minute
+)
+
+// Provider is the SAML service provider of a single tenant. The requests in
+// flight and the consumed assertions are only kept in the memory of the
+// process, so the responses must reach the instance that made the request
+// and replays are only detected by the instance that consumed the
+// assertion: run a single instance, or pin the SAML routes of a tenant to
+// one.
+type Provider struct {
+	sp         *saml.ServiceProvider
+	cfg        config.SAMLProvider
+	clock      clock.Clock
+	requests   *expiringSet
+	assertions *expiringSet
+}
+
+func NewProvider(tenant, baseURL string, key *rsa.PrivateKey, cert *x509.Certificate, idp *saml.EntityDescriptor, cfg config.SAMLProvider, clk clock.Clock) (*Provider, error) {
+	const op errors.Op = "sso.NewProvider"
+
+	base := strings.TrimSuffix(baseURL, "/") + "/api/v1/saml/" + url.PathEscape(tenant)
+	metadataURL, err := url.Parse(base + "/metadata")
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid SAML base url"),
+		)
+	}
+	acsURL, err := url.Parse(base + "/acs")
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid SAML base url"),
+		)
+	}
+
+	return &Provider{
+		sp: &saml.ServiceProvider{
+			EntityID:          metadataURL.String(),
+			Key:               key,
+			Certificate:       cert,
+			MetadataURL:       *metadataURL,
+			AcsURL:            *acsURL,
+			IDPMetadata:       idp,
+			AllowIDPInitiated: cfg.AllowIDPInitiated,
+		},
+		cfg:        cfg,
+		clock:      clk,
+		requests:   newExpiringSet(clk),
+		assertions: newExpiringSet(clk),
+	}, nil
+}
+
+// Metadata returns the service provider metadata document.
+func (p *Provider) Metadata() ([]byte, error) {
+	const op errors.Op = "sso.Provider.Metadata"
+
+	data, err := xml.MarshalIndent(p.sp.Metadata(), "", "  ")
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to build SAML metadata"),
+		)
+	}
+
+	return data, nil
+}
+
+// AuthnRequestURL builds a redirect binding authentication request to the
+// identity provider. The request id travels as relay state so the response
+// can be matched with it.
+func (p *Provider) AuthnRequestURL() (string, error) {
+	const op errors.Op = "sso.Provider.AuthnRequestURL"
+
+	req, err := p.sp.MakeAuthenticationRequest(
+		p.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding,
+		saml.HTTPPostBinding,
+	)
+	if err != nil {
+		return "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SAML authentication request"),
+		)
+	}
+
+	redirect, err := req.Redirect(req.ID, p.sp)
+	if err != nil {
+		return "", errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to create SAML authentication request"),
+		)
+	}
+
+	p.requests.add(req.ID, p.clock.Now().Add(requestTTL))
+
+	return redirect.String(), nil
+}
+
+// ConsumeResponse validates a base64 encoded SAML response received on the
+// assertion consumer service and maps the assertion to an identity. Every
+// assertion is accepted only once and the request it answers is only used
+// up by a valid response.
+func (p *Provider) ConsumeResponse(samlResponse, relayState string) (models.Identity, error) {
+	const op errors.Op = "sso.Provider.ConsumeResponse"
+
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return models.Identity{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid SAML response"),
+			errors.KindBadRequest(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	possibleRequestIDs := make([]string, 0, 1)
+	if relayState != "" && p.requests.has(relayState) {
+		possibleRequestIDs = append(possibleRequestIDs, relayState)
+	}
+
+	assertion, err := p.sp.ParseXMLResponse(raw, possibleRequestIDs)
+	if err != nil {
+		// the library hides the reason behind a generic message
+		var invalid *saml.InvalidResponseError
+		if nerrors.As(err, &invalid) && invalid.PrivateErr != nil {
+			err = invalid.PrivateErr
+		}
+		return models.Identity{}, invalidAssertion(op, err)
+	}
+
+	// concurrent responses to the same request are both valid, only the
+	// first one gets it
+	if len(possibleRequestIDs) > 0 && !p.requests.consume(relayState) {
+		return models.Identity{}, invalidAssertion(op, fmt.Errorf("request %s was already answered", relayState))
+	}
+
+	expiresAt := p.clock.Now().Add(saml.MaxIssueDelay)
+	if assertion.Conditions != nil && !assertion.Conditions.NotOnOrAfter.IsZero() {
+		expiresAt = assertion.Conditions.NotOnOrAfter.Add(saml.MaxClockSkew)
+	}
+	if !p.assertions.add(assertion.ID, expiresAt) {
+		return models.Identity{}, invalidAssertion(op, fmt.Errorf("assertion %s was already consumed", assertion.ID))
+	}
+
+	return p.toIdentity(assertion), nil
+}
+
+func (p *Provider) toIdentity(assertion *saml.Assertion) models.Identity {
+	user := models.Users{
+		Username: firstValue(attributeValues(assertion, p.cfg.UsernameAttribute)),
+		Email:    firstValue(attributeValues(assertion, p.cfg.EmailAttribute)),
+	}
+	if user.Username == "" && assertion.Subject != nil && assertion.Subject.NameID != nil {
+		user.Username = assertion.Subject.NameID.Value
+	}
+
+	roles := make([]string, 0)
+	for _, group := range attributeValues(assertion, p.cfg.GroupAttribute) {
+		// viper lower cases map keys
+		if role, ok := p.cfg.GroupRoles[strings.ToLower(group)]; ok {
+			roles = append(roles, role)
+		}
+	}
+
+	return models.Identity{
+		User:    user,
+		Roles:   roles,
+		Backend: SAMLBackend,
+	}
+}
+
+// attributeValues finds an attribute either by its name or its friendly name.
+func attributeValues(assertion *saml.Assertion, name string) []string {
+	values := make([]string, 0)
+	if name == "" {
+		return values
+	}
+
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			if attr.Name != name && attr.FriendlyName != name {
+				continue
+			}
+			for _, v := range attr.Values {
+				values = append(values, v.Value)
+			}
+		}
+	}
+
+	return values
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func invalidAssertion(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Invalid SAML assertion"),
+		errors.KindUnauthorized(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
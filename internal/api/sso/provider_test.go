@@ -0,0 +1,276 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseURL = "https://auth.example.com"
+
+type testIDP struct {
+	idp *saml.IdentityProvider
+	sp  *Provider
+}
+
+// GetServiceProvider implements saml.ServiceProviderProvider.
+func (t *testIDP) GetServiceProvider(r *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	if serviceProviderID != t.sp.sp.EntityID {
+		return nil, os.ErrNotExist
+	}
+	return t.sp.sp.Metadata(), nil
+}
+
+func newKeyPair(t *testing.T, cn string) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return key, cert
+}
+
+func newTestIDP(t *testing.T, cfg config.SAMLProvider) *testIDP {
+	t.Helper()
+
+	idpKey, idpCert := newKeyPair(t, "idp.example.com")
+	metadataURL, _ := url.Parse("https://idp.example.com/metadata")
+	ssoURL, _ := url.Parse("https://idp.example.com/sso")
+
+	ti := &testIDP{
+		idp: &saml.IdentityProvider{
+			Key:         idpKey,
+			Certificate: idpCert,
+			MetadataURL: *metadataURL,
+			SSOURL:      *ssoURL,
+		},
+	}
+	ti.idp.ServiceProviderProvider = ti
+
+	spKey, spCert := newKeyPair(t, "auth.example.com")
+	sp, err := NewProvider("acme", testBaseURL, spKey, spCert, ti.idp.Metadata(), cfg, &clock.RealClock{})
+	require.NoError(t, err)
+	ti.sp = sp
+
+	return ti
+}
+
+// respond plays the identity provider side for the given redirect url and
+// returns the encoded response and relay state posted to the acs.
+func (ti *testIDP) respond(t *testing.T, redirect string, session *saml.Session, tamper func(*saml.IdpAuthnRequest)) (string, string) {
+	t.Helper()
+
+	req, err := saml.NewIdpAuthnRequest(ti.idp, httptest.NewRequest(http.MethodGet, redirect, nil))
+	require.NoError(t, err)
+	require.NoError(t, req.Validate())
+	require.NoError(t, saml.DefaultAssertionMaker{}.MakeAssertion(req, session))
+	if tamper != nil {
+		tamper(req)
+	}
+
+	form, err := req.PostBinding()
+	require.NoError(t, err)
+
+	return form.SAMLResponse, form.RelayState
+}
+
+func testSession() *saml.Session {
+	return &saml.Session{
+		ID:        "session-id",
+		NameID:    "alice@example.com",
+		UserName:  "alice",
+		UserEmail: "alice@example.com",
+		CustomAttributes: []saml.Attribute{
+			{
+				Name:   "groups",
+				Values: []saml.AttributeValue{{Value: "Admins"}, {Value: "Users"}},
+			},
+		},
+	}
+}
+
+func TestProvider_Metadata(t *testing.T) {
+	ti := newTestIDP(t, config.SAMLProvider{})
+
+	data, err := ti.sp.Metadata()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `entityID="https://auth.example.com/api/v1/saml/acme/metadata"`)
+	assert.Contains(t, string(data), `Location="https://auth.example.com/api/v1/saml/acme/acs"`)
+}
+
+func TestProvider_ConsumeResponse(t *testing.T) {
+	cfg := config.SAMLProvider{
+		UsernameAttribute: "uid",
+		EmailAttribute:    "eduPersonPrincipalName",
+		GroupAttribute:    "groups",
+		GroupRoles:        map[string]string{"admins": "admin"},
+	}
+
+	t.Run("Success maps attributes to the user and roles", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(redirect, "https://idp.example.com/sso?"))
+
+		response, relayState := ti.respond(t, redirect, testSession(), nil)
+		got, err := ti.sp.ConsumeResponse(response, relayState)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", got.User.Username)
+		assert.Equal(t, "alice@example.com", got.User.Email)
+		assert.Equal(t, []string{"admin"}, got.Roles)
+		assert.Equal(t, SAMLBackend, got.Backend)
+	})
+
+	t.Run("Name id is used when the username attribute is missing", func(t *testing.T) {
+		ti := newTestIDP(t, config.SAMLProvider{UsernameAttribute: "missing"})
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), nil)
+		got, err := ti.sp.ConsumeResponse(response, relayState)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", got.User.Username)
+		assert.Equal(t, []string{}, got.Roles)
+	})
+
+	t.Run("Response can not be used twice", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), nil)
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		require.NoError(t, err)
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Replayed assertion is rejected for idp initiated logins", func(t *testing.T) {
+		idpInitiated := cfg
+		idpInitiated.AllowIDPInitiated = true
+		ti := newTestIDP(t, idpInitiated)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, _ := ti.respond(t, redirect, testSession(), nil)
+		_, err = ti.sp.ConsumeResponse(response, "")
+		require.NoError(t, err)
+		_, err = ti.sp.ConsumeResponse(response, "")
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Unsolicited response is rejected", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, _ := ti.respond(t, redirect, testSession(), nil)
+		_, err = ti.sp.ConsumeResponse(response, "")
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Assertion for another audience is rejected", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), func(req *saml.IdpAuthnRequest) {
+			req.Assertion.Conditions.AudienceRestrictions[0].Audience.Value = "https://other.example.com"
+		})
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Expired assertion is rejected", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), func(req *saml.IdpAuthnRequest) {
+			past := time.Now().Add(-time.Hour)
+			req.Assertion.IssueInstant = past
+			req.Assertion.Conditions.NotBefore = past
+			req.Assertion.Conditions.NotOnOrAfter = past.Add(time.Minute)
+		})
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Tampered assertion is rejected", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), nil)
+		raw, err := base64.StdEncoding.DecodeString(response)
+		require.NoError(t, err)
+		tampered := strings.Replace(string(raw), `Version="2.0"`, `Version="2.0" Consent="urn:oasis:names:tc:SAML:2.0:consent:obtained"`, 1)
+		require.NotEqual(t, string(raw), tampered)
+
+		_, err = ti.sp.ConsumeResponse(base64.StdEncoding.EncodeToString([]byte(tampered)), relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Rejected response does not use up the request", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		response, relayState := ti.respond(t, redirect, testSession(), func(req *saml.IdpAuthnRequest) {
+			req.Assertion.Conditions.AudienceRestrictions[0].Audience.Value = "https://other.example.com"
+		})
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+
+		response, relayState = ti.respond(t, redirect, testSession(), nil)
+		got, err := ti.sp.ConsumeResponse(response, relayState)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", got.User.Username)
+	})
+
+	t.Run("Assertion signed by another key is rejected", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		redirect, err := ti.sp.AuthnRequestURL()
+		require.NoError(t, err)
+
+		ti.idp.Key, _ = newKeyPair(t, "idp.example.com")
+		response, relayState := ti.respond(t, redirect, testSession(), nil)
+		_, err = ti.sp.ConsumeResponse(response, relayState)
+		assert.True(t, errors.IsKind(err, errors.Unauthorized))
+	})
+
+	t.Run("Invalid encoding", func(t *testing.T) {
+		ti := newTestIDP(t, cfg)
+		_, err := ti.sp.ConsumeResponse("%%%", "")
+		assert.True(t, errors.IsKind(err, errors.BadRequest))
+	})
+}
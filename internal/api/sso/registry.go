@@ -0,0 +1,156 @@
+package sso
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/rs/zerolog"
+)
+
+const metadataFetchTimeout = 10 * time.Second
+
+// Registry holds the service provider of every tenant with SAML configured.
+type Registry struct {
+	providers map[string]*Provider
+}
+
+func NewRegistry(cfg config.SAML, clk clock.Clock) (*Registry, error) {
+	const op errors.Op = "sso.NewRegistry"
+
+	r := &Registry{
+		providers: make(map[string]*Provider),
+	}
+	if len(cfg.Providers) == 0 {
+		return r, nil
+	}
+
+	key, cert, err := loadKeyPair(cfg.CertificateFile, cfg.KeyFile)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	for tenant, pc := range cfg.Providers {
+		idp, err := loadIDPMetadata(pc)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithNestedErrorCopy(err),
+			)
+		}
+
+		r.providers[strings.ToLower(tenant)], err = NewProvider(tenant, cfg.BaseURL, key, cert, idp, pc, clk)
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithNestedErrorCopy(err),
+			)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Registry) Get(tenant string) (*Provider, error) {
+	const op errors.Op = "sso.Registry.Get"
+
+	p, ok := r.providers[strings.ToLower(tenant)]
+	if !ok {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("no SAML provider configured for tenant %s", tenant)),
+			errors.WithMessage("SAML is not configured for this tenant"),
+			errors.KindNotFound(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+
+	return p, nil
+}
+
+func loadKeyPair(certFile, keyFile string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	const op errors.Op = "sso.loadKeyPair"
+
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to load SAML key pair"),
+		)
+	}
+
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("SAML key must be a RSA key")),
+			errors.WithMessage("Failed to load SAML key pair"),
+		)
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to load SAML key pair"),
+		)
+	}
+
+	return key, cert, nil
+}
+
+func loadIDPMetadata(cfg config.SAMLProvider) (*saml.EntityDescriptor, error) {
+	const op errors.Op = "sso.loadIDPMetadata"
+
+	if cfg.IDPMetadata != "" {
+		idp, err := samlsp.ParseMetadata([]byte(cfg.IDPMetadata))
+		if err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Invalid identity provider metadata"),
+			)
+		}
+		return idp, nil
+	}
+
+	metadataURL, err := url.Parse(cfg.IDPMetadataURL)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Invalid identity provider metadata url"),
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), metadataFetchTimeout)
+	defer cancel()
+
+	idp, err := samlsp.FetchMetadata(ctx, http.DefaultClient, *metadataURL)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to fetch identity provider metadata"),
+			errors.KindBadGateway(),
+		)
+	}
+
+	return idp, nil
+}
@@ -0,0 +1,65 @@
+package sso
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+)
+
+// expiringSet remembers keys until their expiration time. It is used to track
+// the authentication requests in flight and the assertions already consumed.
+// Keys are only known to the process that added them.
+type expiringSet struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	entries map[string]time.Time
+}
+
+func newExpiringSet(clock clock.Clock) *expiringSet {
+	return &expiringSet{
+		clock:   clock,
+		entries: make(map[string]time.Time),
+	}
+}
+
+// add stores the key and reports false when it is already present.
+func (s *expiringSet) add(key string, expiresAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	for k, v := range s.entries {
+		if !now.Before(v) {
+			delete(s.entries, k)
+		}
+	}
+
+	if _, ok := s.entries[key]; ok {
+		return false
+	}
+	s.entries[key] = expiresAt
+	return true
+}
+
+// has reports whether the key is present and not expired.
+func (s *expiringSet) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.entries[key]
+	return ok && s.clock.Now().Before(expiresAt)
+}
+
+// consume removes the key and reports whether it was present and not expired.
+func (s *expiringSet) consume(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.entries[key]
+	if !ok {
+		return false
+	}
+	delete(s.entries, key)
+	return s.clock.Now().Before(expiresAt)
+}
@@ -1,33 +1,136 @@
 package app
 
 import (
-	"database/sql"
+	"context"
+	"net"
+	"os"
 
 	"github.com/Pedrommb91/go-auth/config"
 	"github.com/Pedrommb91/go-auth/internal/api"
+	"github.com/Pedrommb91/go-auth/internal/api/authenticators"
 	"github.com/Pedrommb91/go-auth/internal/api/handlers"
 	"github.com/Pedrommb91/go-auth/internal/api/repositories"
 	"github.com/Pedrommb91/go-auth/internal/api/services"
+	"github.com/Pedrommb91/go-auth/internal/api/sso"
+	"github.com/Pedrommb91/go-auth/migrations"
+	"github.com/Pedrommb91/go-auth/pkg/clock"
 	"github.com/Pedrommb91/go-auth/pkg/database"
 	"github.com/Pedrommb91/go-auth/pkg/encrypt"
 	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/Pedrommb91/go-auth/pkg/mailer"
+	"github.com/Pedrommb91/go-auth/pkg/outbox"
+	"github.com/Pedrommb91/go-auth/pkg/webhook"
+	"go.opentelemetry.io/otel"
 )
 
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
-	db := database.NewPostgresOrDie(cfg.Database)
-	services := createServices(db, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := database.ConnectDB(ctx, cfg.Database)
+	if err != nil {
+		l.Fatal(err)
+	}
+	if cfg.Database.AutoMigrate {
+		err := database.NewMigrator(db.Primary(), migrations.FS, os.Stderr).UpLocked(ctx)
+		if err != nil {
+			l.Fatal(err)
+		}
+	}
+	db.Use(database.NewLogHook(l, cfg.Database.SlowQueryThreshold))
+	if cfg.Database.Tracing {
+		db.Use(database.NewTracingHook(otel.GetTracerProvider()))
+	}
+	go db.MonitorReplicas(ctx, cfg.Database.ReplicaCheckInterval)
+
+	guard, err := webhook.NewGuard(cfg.Webhooks.AllowedNetworks, net.DefaultResolver)
+	if err != nil {
+		l.Fatal(err)
+	}
+	wr := repositories.NewWebhookRepository(db)
+	webhooks := services.NewWebhookService(wr, repositories.NewOrganizationRepository(db), guard, &clock.RealClock{})
+	dispatcher := services.NewWebhookDispatcher(wr,
+		webhook.NewSender(guard.Client(cfg.Webhooks.Timeout), &clock.RealClock{}),
+		l, &clock.RealClock{}, cfg.Webhooks)
+
+	services, err := createServices(db, cfg, l, webhooks)
+	if err != nil {
+		l.Fatal(err)
+	}
+
+	// the webhooks of the organizations are fed by the outbox as well
+	sinks, err := outbox.NewSinks(cfg.Outbox)
+	if err != nil {
+		l.Fatal(err)
+	}
+	sinks["organization_webhooks"] = webhooks
+	relay := outbox.NewRelay(db, sinks, l, &clock.RealClock{},
+		outbox.WithPollInterval(cfg.Outbox.PollInterval),
+		outbox.WithBatchSize(cfg.Outbox.BatchSize),
+		outbox.WithRetries(cfg.Outbox.MaxAttempts, cfg.Outbox.Backoff))
 
 	server := api.NewServer(cfg, l)
 	server.ServerConfigure()
 	server.SetRoutes(services)
+	server.AddTask(relay.Run)
+	server.AddTask(dispatcher.Run)
 	server.Run()
 }
 
-func createServices(db *sql.DB, cfg *config.Config) *handlers.Services {
+func createServices(db *database.DB, cfg *config.Config, l logger.Interface, webhooks services.WebhookServiceInterface) (*handlers.Services, error) {
 	ur := repositories.NewUserRepository(db)
-	return &handlers.Services{
-		User: services.NewUserService(ur, cfg.Encrypt, encrypt.NewPasswordEncryptor()),
+	or := repositories.NewOrganizationRepository(db)
+	mr := repositories.NewMembershipRepository(db)
+	gr := repositories.NewGroupRepository(db)
+	encryptor := encrypt.NewPasswordEncryptor()
+	tx := db.NewTransactor()
+	events := outbox.New(db, &clock.RealClock{})
+
+	authenticator, err := authenticators.New(cfg, authenticators.Dependencies{
+		Users:       ur,
+		Provisioner: ur,
+		Transactor:  tx,
+		Outbox:      events,
+		Encryptor:   encryptor,
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	registry, err := sso.NewRegistry(cfg.SAML, &clock.RealClock{})
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := mailer.New(cfg.Mailer, l)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := services.NewInvitationService(cfg, services.InvitationServiceDependencies{
+		Invitations:   repositories.NewInvitationRepository(db),
+		Users:         ur,
+		Members:       mr,
+		Organizations: or,
+		Mailer:        m,
+		Encryptor:     encryptor,
+		Clock:         &clock.RealClock{},
+		Transactor:    tx,
+		Outbox:        events,
+	})
+
+	return &handlers.Services{
+		User:         services.NewUserService(ur, tx, events, cfg.Encrypt, encryptor),
+		Auth:         services.NewAuthService(authenticator),
+		SSO:          services.NewSSOService(registry, ur, ur, tx, events),
+		Organization: services.NewOrganizationService(or),
+		Invitation:   invitations,
+		Membership:   services.NewMembershipService(mr, or),
+		SCIMToken:    services.NewSCIMTokenService(repositories.NewSCIMTokenRepository(db), or),
+		Provisioning: services.NewProvisioningService(ur, gr, tx, events, cfg.Encrypt, encryptor),
+		Webhook:      webhooks,
+		Health:       database.NewHealthChecker(db, cfg.Database.QueryTimeout),
+	}, nil
 }
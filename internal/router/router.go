@@ -5,10 +5,12 @@ import (
 	"github.com/Pedrommb91/go-auth/internal/api/handlers"
 	"github.com/Pedrommb91/go-auth/internal/api/middlewares"
 	"github.com/Pedrommb91/go-auth/internal/api/openapi"
+	"github.com/Pedrommb91/go-auth/internal/api/scim"
 	"github.com/Pedrommb91/go-auth/pkg/clock"
 	"github.com/Pedrommb91/go-auth/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/go-openapi/runtime/middleware"
+	"go.opentelemetry.io/otel"
 )
 
 func NewRouter(engine *gin.Engine, l logger.Interface, cfg *config.Config, services *handlers.Services) {
@@ -16,6 +18,13 @@ func NewRouter(engine *gin.Engine, l logger.Interface, cfg *config.Config, servi
 	engine.Use(gin.Recovery())
 
 	engine.Use(middlewares.ErrorHandler(&clock.RealClock{}, l))
+	engine.Use(middlewares.QueryTimeout(cfg.Database.QueryTimeout))
+	if cfg.Database.ReadYourWrites {
+		engine.Use(middlewares.ReadYourWrites())
+	}
+	if cfg.Database.Tracing {
+		engine.Use(middlewares.Tracing(otel.GetTracerProvider()))
+	}
 
 	// Swagger
 	engine.StaticFile("/swagger", "./spec/openapi.yaml")
@@ -26,6 +35,16 @@ func NewRouter(engine *gin.Engine, l logger.Interface, cfg *config.Config, servi
 		sh.ServeHTTP(ctx.Writer, ctx.Request)
 	})
 
+	if services.Health != nil {
+		engine.GET("/health", handlers.Health(services.Health))
+	}
+
+	// SCIM clients are scoped by their token instead of the tenant resolver
+	scim.RegisterHandlers(engine.Group("/scim/v2"), services.Provisioning, services.SCIMToken, cfg.SCIM, l)
+
+	// only the api routes registered below are scoped by tenant
+	engine.Use(middlewares.TenantResolver(services.Organization, cfg.Tenancy))
+
 	mid := make([]openapi.MiddlewareFunc, 0)
 	opt := openapi.GinServerOptions{
 		BaseURL:     "/api/v1/",
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/handlers"
 	"github.com/Pedrommb91/go-auth/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -29,7 +30,7 @@ func TestNewRouter(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			NewRouter(tt.args.engine, tt.args.l, tt.args.cfg, nil)
+			NewRouter(tt.args.engine, tt.args.l, tt.args.cfg, &handlers.Services{})
 			if len(tt.args.engine.Handlers) == 0 {
 				t.Errorf("Failed to register handlers")
 			}
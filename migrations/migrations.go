@@ -0,0 +1,8 @@
+// Package migrations embeds the schema migrations, one directory per
+// database dialect.
+package migrations
+
+import "embed"
+
+//go:embed */*.sql
+var FS embed.FS
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE credentials (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  salt VARCHAR(254) NOT NULL,
+  passhash VARCHAR(254) NOT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL
+);
+
+CREATE TABLE users (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  username VARCHAR(63) NOT NULL,
+  email VARCHAR(254) NOT NULL,
+  credentials_id INT,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT users_username_key UNIQUE (username),
+  CONSTRAINT users_email_key UNIQUE (email),
+  CONSTRAINT proper_email CHECK (email REGEXP '^[A-Za-z0-9._+%-]+@[A-Za-z0-9.-]+[.][A-Za-z]+$'),
+  CONSTRAINT fk_users_credentials
+    FOREIGN KEY (credentials_id) REFERENCES credentials (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE users;
+DROP TABLE credentials;
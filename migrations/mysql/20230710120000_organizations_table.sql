@@ -0,0 +1,67 @@
+-- +goose Up
+CREATE TABLE organizations (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  slug VARCHAR(63) NOT NULL,
+  name VARCHAR(254) NOT NULL,
+  host VARCHAR(253) DEFAULT NULL,
+  settings JSON NOT NULL DEFAULT (JSON_OBJECT()),
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT organizations_slug_key UNIQUE (slug),
+  CONSTRAINT organizations_host_key UNIQUE (host),
+  -- REGEXP ignores the case with the default collation
+  CONSTRAINT proper_slug CHECK (slug = LOWER(slug) AND slug REGEXP '^[a-z0-9][a-z0-9-]*$')
+);
+
+-- existing users are moved to the default organization
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default');
+
+ALTER TABLE users ADD COLUMN organization_id INT;
+UPDATE users SET organization_id = (SELECT id FROM organizations WHERE slug = 'default');
+ALTER TABLE users MODIFY organization_id INT NOT NULL;
+ALTER TABLE users
+  ADD CONSTRAINT fk_users_organizations
+    FOREIGN KEY (organization_id) REFERENCES organizations (id)
+    ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE users DROP INDEX users_username_key;
+ALTER TABLE users DROP INDEX users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_organization_username_key UNIQUE (organization_id, username);
+ALTER TABLE users ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
+
+CREATE TABLE roles (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  organization_id INT NOT NULL,
+  name VARCHAR(63) NOT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT roles_organization_name_key UNIQUE (organization_id, name),
+  CONSTRAINT fk_roles_organizations
+    FOREIGN KEY (organization_id) REFERENCES organizations (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles (
+  user_id INT NOT NULL,
+  role_id INT NOT NULL,
+  PRIMARY KEY (user_id, role_id),
+  CONSTRAINT fk_user_roles_users
+    FOREIGN KEY (user_id) REFERENCES users (id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_user_roles_roles
+    FOREIGN KEY (role_id) REFERENCES roles (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE user_roles;
+DROP TABLE roles;
+
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP FOREIGN KEY fk_users_organizations;
+ALTER TABLE users DROP INDEX users_organization_username_key;
+ALTER TABLE users DROP INDEX users_organization_email_key;
+ALTER TABLE users DROP COLUMN organization_id;
+
+DROP TABLE organizations;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE invitations (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  organization_id INT NOT NULL,
+  email VARCHAR(254) NOT NULL,
+  role_id INT NOT NULL,
+  token_hash VARCHAR(64) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  accepted_at DATETIME DEFAULT NULL,
+  accepted_user_id INT DEFAULT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT invitations_token_hash_key UNIQUE (token_hash),
+  CONSTRAINT fk_invitations_organizations
+    FOREIGN KEY (organization_id) REFERENCES organizations (id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_roles
+    FOREIGN KEY (role_id) REFERENCES roles (id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_users
+    FOREIGN KEY (accepted_user_id) REFERENCES users (id)
+    ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX invitations_organization_id_idx ON invitations (organization_id);
+
+-- +goose Down
+DROP TABLE invitations;
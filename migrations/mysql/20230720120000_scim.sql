@@ -0,0 +1,38 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN external_id VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN given_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN family_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN display_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE roles
+  ADD COLUMN external_id VARCHAR(254) DEFAULT NULL;
+
+CREATE TABLE scim_tokens (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  organization_id INT NOT NULL,
+  description VARCHAR(254) NOT NULL DEFAULT '',
+  token_hash VARCHAR(64) NOT NULL,
+  last_used_at DATETIME DEFAULT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT scim_tokens_token_hash_key UNIQUE (token_hash),
+  CONSTRAINT fk_scim_tokens_organizations
+    FOREIGN KEY (organization_id) REFERENCES organizations (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX scim_tokens_organization_id_idx ON scim_tokens (organization_id);
+
+-- +goose Down
+DROP TABLE scim_tokens;
+
+ALTER TABLE roles DROP COLUMN external_id;
+
+ALTER TABLE users
+  DROP COLUMN external_id,
+  DROP COLUMN given_name,
+  DROP COLUMN family_name,
+  DROP COLUMN display_name,
+  DROP COLUMN active;
@@ -0,0 +1,30 @@
+-- +goose Up
+ALTER TABLE credentials ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+ALTER TABLE users ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+-- mysql has no partial indexes, the unique keys include a column that is
+-- NULL for deleted users so that they only hold among the live ones
+ALTER TABLE users ADD COLUMN live TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL;
+ALTER TABLE users
+  DROP INDEX users_organization_username_key,
+  ADD CONSTRAINT users_organization_username_key UNIQUE (organization_id, username, live);
+ALTER TABLE users
+  DROP INDEX users_organization_email_key,
+  ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email, live);
+
+-- +goose Down
+-- deleted users would break the unique keys
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM credentials WHERE deleted_at IS NOT NULL;
+ALTER TABLE users
+  DROP INDEX users_organization_username_key,
+  ADD CONSTRAINT users_organization_username_key UNIQUE (organization_id, username);
+ALTER TABLE users
+  DROP INDEX users_organization_email_key,
+  ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
+ALTER TABLE users DROP COLUMN live;
+
+ALTER TABLE users DROP COLUMN deleted_at;
+
+ALTER TABLE credentials DROP COLUMN deleted_at;
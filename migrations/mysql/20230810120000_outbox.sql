@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE outbox (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  event_type VARCHAR(254) NOT NULL,
+  payload TEXT NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT DEFAULT NULL,
+  next_attempt_at DATETIME(6) NOT NULL,
+  delivered_at DATETIME(6) DEFAULT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP())
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (delivered_at, next_attempt_at);
+
+-- the sinks an event was delivered to, so a retry only goes to the others
+CREATE TABLE outbox_deliveries (
+  event_id INT NOT NULL,
+  sink VARCHAR(63) NOT NULL,
+  delivered_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (event_id, sink),
+  CONSTRAINT fk_outbox_deliveries_outbox
+    FOREIGN KEY (event_id) REFERENCES outbox (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE outbox_deliveries;
+DROP TABLE outbox;
@@ -0,0 +1,43 @@
+-- +goose Up
+CREATE TABLE webhooks (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  organization_id INT NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(254) NOT NULL,
+  event_types VARCHAR(1024) NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT fk_webhooks_organizations
+    FOREIGN KEY (organization_id) REFERENCES organizations (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX webhooks_organization_id_idx ON webhooks (organization_id);
+
+CREATE TABLE webhook_deliveries (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  webhook_id INT NOT NULL,
+  event_id INT NOT NULL,
+  event_type VARCHAR(254) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  last_status_code INT DEFAULT NULL,
+  last_error TEXT DEFAULT NULL,
+  next_attempt_at DATETIME(6) NOT NULL,
+  delivered_at DATETIME(6) DEFAULT NULL,
+  created_at DATETIME DEFAULT (UTC_TIMESTAMP()),
+  updated_at DATETIME DEFAULT NULL,
+  CONSTRAINT webhook_deliveries_event_key UNIQUE (webhook_id, event_id),
+  CONSTRAINT fk_webhook_deliveries_webhooks
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE webhook_deliveries;
+
+DROP TABLE webhooks;
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizations (
+  id SERIAL PRIMARY KEY,
+  slug VARCHAR(63) UNIQUE NOT NULL
+    CONSTRAINT
+      proper_slug CHECK (slug ~ '^[a-z0-9][a-z0-9-]*$'),
+  name VARCHAR(254) NOT NULL,
+  host VARCHAR(253) UNIQUE DEFAULT NULL,
+  settings JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL
+);
+
+-- existing users are moved to the default organization
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default');
+
+ALTER TABLE users
+  ADD COLUMN organization_id INT
+    CONSTRAINT fk_users_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE;
+UPDATE users SET organization_id = (SELECT id FROM organizations WHERE slug = 'default');
+ALTER TABLE users ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_organization_username_key UNIQUE (organization_id, username);
+ALTER TABLE users ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
+
+CREATE TABLE roles (
+  id SERIAL PRIMARY KEY,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_roles_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  name VARCHAR(63) NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+  CONSTRAINT roles_organization_name_key UNIQUE (organization_id, name)
+);
+
+CREATE TABLE user_roles (
+  user_id INT NOT NULL
+    CONSTRAINT fk_user_roles_users
+      REFERENCES users
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  role_id INT NOT NULL
+    CONSTRAINT fk_user_roles_roles
+      REFERENCES roles
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_roles;
+DROP TABLE roles;
+
+ALTER TABLE users DROP CONSTRAINT users_organization_username_key;
+ALTER TABLE users DROP CONSTRAINT users_organization_email_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN organization_id;
+
+DROP TABLE organizations;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE invitations (
+  id SERIAL PRIMARY KEY,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_invitations_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  email VARCHAR(254) NOT NULL,
+  role_id INT NOT NULL
+    CONSTRAINT fk_invitations_roles
+      REFERENCES roles
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  accepted_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+  accepted_user_id INT DEFAULT NULL
+    CONSTRAINT fk_invitations_users
+      REFERENCES users
+      ON UPDATE CASCADE ON DELETE SET NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX invitations_organization_id_idx ON invitations (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invitations;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+  ADD COLUMN external_id VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN given_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN family_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN display_name VARCHAR(254) DEFAULT NULL,
+  ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE roles
+  ADD COLUMN external_id VARCHAR(254) DEFAULT NULL;
+
+CREATE TABLE scim_tokens (
+  id SERIAL PRIMARY KEY,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_scim_tokens_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  description VARCHAR(254) NOT NULL DEFAULT '',
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  last_used_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX scim_tokens_organization_id_idx ON scim_tokens (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE scim_tokens;
+
+ALTER TABLE roles DROP COLUMN external_id;
+
+ALTER TABLE users
+  DROP COLUMN external_id,
+  DROP COLUMN given_name,
+  DROP COLUMN family_name,
+  DROP COLUMN display_name,
+  DROP COLUMN active;
+-- +goose StatementEnd
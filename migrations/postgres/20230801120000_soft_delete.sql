@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE credentials ADD COLUMN deleted_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL;
+
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL;
+
+-- deleted users keep their row, the unique keys only hold among the live ones
+ALTER TABLE users DROP CONSTRAINT users_organization_username_key;
+ALTER TABLE users DROP CONSTRAINT users_organization_email_key;
+CREATE UNIQUE INDEX users_organization_username_key ON users (organization_id, username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_organization_email_key ON users (organization_id, email) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_organization_username_key;
+DROP INDEX users_organization_email_key;
+-- deleted users would break the unique keys
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM credentials WHERE deleted_at IS NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_organization_username_key UNIQUE (organization_id, username);
+ALTER TABLE users ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
+
+ALTER TABLE users DROP COLUMN deleted_at;
+
+ALTER TABLE credentials DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhooks (
+  id SERIAL PRIMARY KEY,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_webhooks_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(254) NOT NULL,
+  event_types VARCHAR(1024) NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX webhooks_organization_id_idx ON webhooks (organization_id);
+
+CREATE TABLE webhook_deliveries (
+  id SERIAL PRIMARY KEY,
+  webhook_id INT NOT NULL
+    CONSTRAINT fk_webhook_deliveries_webhooks
+      REFERENCES webhooks
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  event_id INT NOT NULL,
+  event_type VARCHAR(254) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  last_status_code INT DEFAULT NULL,
+  last_error TEXT DEFAULT NULL,
+  next_attempt_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  delivered_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+  CONSTRAINT webhook_deliveries_event_key UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+
+DROP TABLE webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE credentials (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  salt VARCHAR(254) NOT NULL,
+  passhash VARCHAR(254) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE users (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  username VARCHAR(63) UNIQUE NOT NULL,
+  email VARCHAR(254) UNIQUE NOT NULL
+    CONSTRAINT
+      proper_email CHECK (email LIKE '%_@_%._%'),
+  credentials_id INT
+    CONSTRAINT fk_users_credentials
+      REFERENCES credentials
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE users;
+DROP TABLE credentials;
+-- +goose StatementEnd
@@ -0,0 +1,94 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  slug VARCHAR(63) UNIQUE NOT NULL
+    CONSTRAINT
+      proper_slug CHECK (slug NOT GLOB '*[^a-z0-9-]*' AND slug GLOB '[a-z0-9]*'),
+  name VARCHAR(254) NOT NULL,
+  host VARCHAR(253) UNIQUE DEFAULT NULL,
+  settings TEXT NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL
+);
+
+-- existing users are moved to the default organization
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default');
+
+-- sqlite cannot alter constraints, the users table is rebuilt with the
+-- unique keys scoped by organization
+CREATE TABLE users_organizations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_users_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  username VARCHAR(63) NOT NULL,
+  email VARCHAR(254) NOT NULL
+    CONSTRAINT
+      proper_email CHECK (email LIKE '%_@_%._%'),
+  credentials_id INT
+    CONSTRAINT fk_users_credentials
+      REFERENCES credentials
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT users_organization_username_key UNIQUE (organization_id, username),
+  CONSTRAINT users_organization_email_key UNIQUE (organization_id, email)
+);
+INSERT INTO users_organizations (id, organization_id, username, email, credentials_id, created_at, updated_at)
+  SELECT id, (SELECT id FROM organizations WHERE slug = 'default'), username, email, credentials_id, created_at, updated_at
+  FROM users;
+DROP TABLE users;
+ALTER TABLE users_organizations RENAME TO users;
+
+CREATE TABLE roles (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_roles_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  name VARCHAR(63) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT roles_organization_name_key UNIQUE (organization_id, name)
+);
+
+CREATE TABLE user_roles (
+  user_id INT NOT NULL
+    CONSTRAINT fk_user_roles_users
+      REFERENCES users
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  role_id INT NOT NULL
+    CONSTRAINT fk_user_roles_roles
+      REFERENCES roles
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_roles;
+DROP TABLE roles;
+
+CREATE TABLE users_global (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  username VARCHAR(63) UNIQUE NOT NULL,
+  email VARCHAR(254) UNIQUE NOT NULL
+    CONSTRAINT
+      proper_email CHECK (email LIKE '%_@_%._%'),
+  credentials_id INT
+    CONSTRAINT fk_users_credentials
+      REFERENCES credentials
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL
+);
+INSERT INTO users_global (id, username, email, credentials_id, created_at, updated_at)
+  SELECT id, username, email, credentials_id, created_at, updated_at FROM users;
+DROP TABLE users;
+ALTER TABLE users_global RENAME TO users;
+
+DROP TABLE organizations;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE invitations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_invitations_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  email VARCHAR(254) NOT NULL,
+  role_id INT NOT NULL
+    CONSTRAINT fk_invitations_roles
+      REFERENCES roles
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  accepted_at TIMESTAMP DEFAULT NULL,
+  accepted_user_id INT DEFAULT NULL
+    CONSTRAINT fk_invitations_users
+      REFERENCES users
+      ON UPDATE CASCADE ON DELETE SET NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL
+);
+
+CREATE INDEX invitations_organization_id_idx ON invitations (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invitations;
+-- +goose StatementEnd
@@ -0,0 +1,99 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- sqlite cannot alter constraints, the users table is rebuilt with the
+-- unique keys only holding among the live users. Foreign keys are off while
+-- the old table is dropped so that it does not cascade to roles and invitations
+PRAGMA foreign_keys = OFF;
+
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE credentials ADD COLUMN deleted_at TIMESTAMP DEFAULT NULL;
+
+CREATE TABLE users_soft_delete (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_users_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  username VARCHAR(63) NOT NULL,
+  email VARCHAR(254) NOT NULL
+    CONSTRAINT
+      proper_email CHECK (email LIKE '%_@_%._%'),
+  credentials_id INT
+    CONSTRAINT fk_users_credentials
+      REFERENCES credentials
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL,
+  external_id VARCHAR(254) DEFAULT NULL,
+  given_name VARCHAR(254) DEFAULT NULL,
+  family_name VARCHAR(254) DEFAULT NULL,
+  display_name VARCHAR(254) DEFAULT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  deleted_at TIMESTAMP DEFAULT NULL
+);
+INSERT INTO users_soft_delete (id, organization_id, username, email, credentials_id, created_at, updated_at,
+    external_id, given_name, family_name, display_name, active)
+  SELECT id, organization_id, username, email, credentials_id, created_at, updated_at,
+    external_id, given_name, family_name, display_name, active
+  FROM users;
+DROP TABLE users;
+ALTER TABLE users_soft_delete RENAME TO users;
+
+CREATE UNIQUE INDEX users_organization_username_key ON users (organization_id, username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_organization_email_key ON users (organization_id, email) WHERE deleted_at IS NULL;
+
+COMMIT;
+-- +goose StatementEnd
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+-- deleted users would break the unique keys
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM credentials WHERE deleted_at IS NOT NULL;
+
+PRAGMA foreign_keys = OFF;
+
+-- +goose StatementBegin
+BEGIN;
+
+CREATE TABLE users_organizations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  organization_id INT NOT NULL
+    CONSTRAINT fk_users_organizations
+      REFERENCES organizations
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  username VARCHAR(63) NOT NULL,
+  email VARCHAR(254) NOT NULL
+    CONSTRAINT
+      proper_email CHECK (email LIKE '%_@_%._%'),
+  credentials_id INT
+    CONSTRAINT fk_users_credentials
+      REFERENCES credentials
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NULL,
+  external_id VARCHAR(254) DEFAULT NULL,
+  given_name VARCHAR(254) DEFAULT NULL,
+  family_name VARCHAR(254) DEFAULT NULL,
+  display_name VARCHAR(254) DEFAULT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  CONSTRAINT users_organization_username_key UNIQUE (organization_id, username),
+  CONSTRAINT users_organization_email_key UNIQUE (organization_id, email)
+);
+INSERT INTO users_organizations (id, organization_id, username, email, credentials_id, created_at, updated_at,
+    external_id, given_name, family_name, display_name, active)
+  SELECT id, organization_id, username, email, credentials_id, created_at, updated_at,
+    external_id, given_name, family_name, display_name, active
+  FROM users;
+DROP TABLE users;
+ALTER TABLE users_organizations RENAME TO users;
+
+ALTER TABLE credentials DROP COLUMN deleted_at;
+
+COMMIT;
+-- +goose StatementEnd
+
+PRAGMA foreign_keys = ON;
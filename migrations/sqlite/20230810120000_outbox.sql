@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  event_type VARCHAR(254) NOT NULL,
+  payload TEXT NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT DEFAULT NULL,
+  next_attempt_at TIMESTAMP NOT NULL,
+  delivered_at TIMESTAMP DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE delivered_at IS NULL;
+
+-- the sinks an event was delivered to, so a retry only goes to the others
+CREATE TABLE outbox_deliveries (
+  event_id INT NOT NULL
+    CONSTRAINT fk_outbox_deliveries_outbox
+      REFERENCES outbox
+      ON UPDATE CASCADE ON DELETE CASCADE,
+  sink VARCHAR(63) NOT NULL,
+  delivered_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (event_id, sink)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_deliveries;
+DROP TABLE outbox;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Authenticator is an autogenerated mock type for the Authenticator type
+type Authenticator struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, org, username, password
+func (_m *Authenticator) Authenticate(ctx context.Context, org models.Organizations, username string, password string) (models.Identity, error) {
+	ret := _m.Called(ctx, org, username, password)
+
+	var r0 models.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organizations, string, string) (models.Identity, error)); ok {
+		return rf(ctx, org, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organizations, string, string) models.Identity); ok {
+		r0 = rf(ctx, org, username, password)
+	} else {
+		r0 = ret.Get(0).(models.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Organizations, string, string) error); ok {
+		r1 = rf(ctx, org, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuthenticator creates a new instance of Authenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Authenticator {
+	mock := &Authenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
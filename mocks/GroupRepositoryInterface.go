@@ -0,0 +1,174 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRepositoryInterface is an autogenerated mock type for the GroupRepositoryInterface type
+type GroupRepositoryInterface struct {
+	mock.Mock
+}
+
+// AddGroup provides a mock function with given fields: ctx, group
+func (_m *GroupRepositoryInterface) AddGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 models.Groups
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Groups) (models.Groups, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Groups) models.Groups); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Get(0).(models.Groups)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Groups) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, organizationID, id
+func (_m *GroupRepositoryInterface) DeleteGroup(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroup provides a mock function with given fields: ctx, organizationID, id
+func (_m *GroupRepositoryInterface) GetGroup(ctx context.Context, organizationID int32, id int32) (models.Groups, error) {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 models.Groups
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) (models.Groups, error)); ok {
+		return rf(ctx, organizationID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) models.Groups); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Get(0).(models.Groups)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, organizationID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroups provides a mock function with given fields: ctx, organizationID, conditions, page
+func (_m *GroupRepositoryInterface) GetGroups(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Groups, int, error) {
+	ret := _m.Called(ctx, organizationID, conditions, page)
+
+	var r0 []models.Groups
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []models.Condition, models.Page) ([]models.Groups, int, error)); ok {
+		return rf(ctx, organizationID, conditions, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []models.Condition, models.Page) []models.Groups); ok {
+		r0 = rf(ctx, organizationID, conditions, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Groups)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, []models.Condition, models.Page) int); ok {
+		r1 = rf(ctx, organizationID, conditions, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int32, []models.Condition, models.Page) error); ok {
+		r2 = rf(ctx, organizationID, conditions, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserGroups provides a mock function with given fields: ctx, organizationID, userIDs
+func (_m *GroupRepositoryInterface) GetUserGroups(ctx context.Context, organizationID int32, userIDs []int32) (map[int32][]models.Groups, error) {
+	ret := _m.Called(ctx, organizationID, userIDs)
+
+	var r0 map[int32][]models.Groups
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []int32) (map[int32][]models.Groups, error)); ok {
+		return rf(ctx, organizationID, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []int32) map[int32][]models.Groups); ok {
+		r0 = rf(ctx, organizationID, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int32][]models.Groups)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, []int32) error); ok {
+		r1 = rf(ctx, organizationID, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroup provides a mock function with given fields: ctx, group
+func (_m *GroupRepositoryInterface) UpdateGroup(ctx context.Context, group models.Groups) (models.Groups, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 models.Groups
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Groups) (models.Groups, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Groups) models.Groups); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Get(0).(models.Groups)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Groups) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGroupRepositoryInterface creates a new instance of GroupRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroupRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GroupRepositoryInterface {
+	mock := &GroupRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
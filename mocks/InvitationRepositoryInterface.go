@@ -0,0 +1,127 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvitationRepositoryInterface is an autogenerated mock type for the InvitationRepositoryInterface type
+type InvitationRepositoryInterface struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, invitation, user
+func (_m *InvitationRepositoryInterface) AcceptInvitation(ctx context.Context, invitation models.Invitations, user models.Users) (int32, error) {
+	ret := _m.Called(ctx, invitation, user)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Invitations, models.Users) (int32, error)); ok {
+		return rf(ctx, invitation, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Invitations, models.Users) int32); ok {
+		r0 = rf(ctx, invitation, user)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Invitations, models.Users) error); ok {
+		r1 = rf(ctx, invitation, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddInvitation provides a mock function with given fields: ctx, invitation
+func (_m *InvitationRepositoryInterface) AddInvitation(ctx context.Context, invitation models.Invitations) (models.Invitations, error) {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 models.Invitations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Invitations) (models.Invitations, error)); ok {
+		return rf(ctx, invitation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Invitations) models.Invitations); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Get(0).(models.Invitations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Invitations) error); ok {
+		r1 = rf(ctx, invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *InvitationRepositoryInterface) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (models.Invitations, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 models.Invitations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Invitations, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Invitations); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.Invitations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, organizationID
+func (_m *InvitationRepositoryInterface) GetInvitations(ctx context.Context, organizationID int32) ([]models.Invitations, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.Invitations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.Invitations, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.Invitations); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitations)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInvitationRepositoryInterface creates a new instance of InvitationRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationRepositoryInterface {
+	mock := &InvitationRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvitationServiceInterface is an autogenerated mock type for the InvitationServiceInterface type
+type InvitationServiceInterface struct {
+	mock.Mock
+}
+
+// Accept provides a mock function with given fields: ctx, token, username, password
+func (_m *InvitationServiceInterface) Accept(ctx context.Context, token string, username string, password string) (models.Memberships, error) {
+	ret := _m.Called(ctx, token, username, password)
+
+	var r0 models.Memberships
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (models.Memberships, error)); ok {
+		return rf(ctx, token, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) models.Memberships); ok {
+		r0 = rf(ctx, token, username, password)
+	} else {
+		r0 = ret.Get(0).(models.Memberships)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, organizationID
+func (_m *InvitationServiceInterface) GetInvitations(ctx context.Context, organizationID int32) ([]models.Invitations, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.Invitations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.Invitations, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.Invitations); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitations)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Invite provides a mock function with given fields: ctx, organizationID, email, role
+func (_m *InvitationServiceInterface) Invite(ctx context.Context, organizationID int32, email string, role string) (models.Invitations, error) {
+	ret := _m.Called(ctx, organizationID, email, role)
+
+	var r0 models.Invitations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string, string) (models.Invitations, error)); ok {
+		return rf(ctx, organizationID, email, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string, string) models.Invitations); ok {
+		r0 = rf(ctx, organizationID, email, role)
+	} else {
+		r0 = ret.Get(0).(models.Invitations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string, string) error); ok {
+		r1 = rf(ctx, organizationID, email, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInvitationServiceInterface creates a new instance of InvitationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationServiceInterface {
+	mock := &InvitationServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// MembershipServiceInterface is an autogenerated mock type for the MembershipServiceInterface type
+type MembershipServiceInterface struct {
+	mock.Mock
+}
+
+// GetMembers provides a mock function with given fields: ctx, organizationID, cursor, size
+func (_m *MembershipServiceInterface) GetMembers(ctx context.Context, organizationID int32, cursor pagination.Cursor, size int) (pagination.Page[models.Memberships], error) {
+	ret := _m.Called(ctx, organizationID, cursor, size)
+
+	var r0 pagination.Page[models.Memberships]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, pagination.Cursor, int) (pagination.Page[models.Memberships], error)); ok {
+		return rf(ctx, organizationID, cursor, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, pagination.Cursor, int) pagination.Page[models.Memberships]); ok {
+		r0 = rf(ctx, organizationID, cursor, size)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[models.Memberships])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, pagination.Cursor, int) error); ok {
+		r1 = rf(ctx, organizationID, cursor, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, organizationID, userID
+func (_m *MembershipServiceInterface) RemoveMember(ctx context.Context, organizationID int32, userID int32) error {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, organizationID, userID, role
+func (_m *MembershipServiceInterface) UpdateMemberRole(ctx context.Context, organizationID int32, userID int32, role string) (models.Memberships, error) {
+	ret := _m.Called(ctx, organizationID, userID, role)
+
+	var r0 models.Memberships
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, string) (models.Memberships, error)); ok {
+		return rf(ctx, organizationID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, string) models.Memberships); ok {
+		r0 = rf(ctx, organizationID, userID, role)
+	} else {
+		r0 = ret.Get(0).(models.Memberships)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = rf(ctx, organizationID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMembershipServiceInterface creates a new instance of MembershipServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMembershipServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MembershipServiceInterface {
+	mock := &MembershipServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationReaderInterface is an autogenerated mock type for the OrganizationReaderInterface type
+type OrganizationReaderInterface struct {
+	mock.Mock
+}
+
+// GetOrganizationByHost provides a mock function with given fields: ctx, host
+func (_m *OrganizationReaderInterface) GetOrganizationByHost(ctx context.Context, host string) (models.Organizations, error) {
+	ret := _m.Called(ctx, host)
+
+	var r0 models.Organizations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Organizations, error)); ok {
+		return rf(ctx, host)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Organizations); ok {
+		r0 = rf(ctx, host)
+	} else {
+		r0 = ret.Get(0).(models.Organizations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrganizationByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationReaderInterface) GetOrganizationByID(ctx context.Context, id int32) (models.Organizations, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 models.Organizations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (models.Organizations, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) models.Organizations); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Organizations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrganizationBySlug provides a mock function with given fields: ctx, slug
+func (_m *OrganizationReaderInterface) GetOrganizationBySlug(ctx context.Context, slug string) (models.Organizations, error) {
+	ret := _m.Called(ctx, slug)
+
+	var r0 models.Organizations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Organizations, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Organizations); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(models.Organizations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOrganizationReaderInterface creates a new instance of OrganizationReaderInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationReaderInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationReaderInterface {
+	mock := &OrganizationReaderInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxInterface is an autogenerated mock type for the OutboxInterface type
+type OutboxInterface struct {
+	mock.Mock
+}
+
+// Record provides a mock function with given fields: ctx, eventType, payload
+func (_m *OutboxInterface) Record(ctx context.Context, eventType string, payload interface{}) error {
+	ret := _m.Called(ctx, eventType, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, eventType, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutboxInterface creates a new instance of OutboxInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxInterface {
+	mock := &OutboxInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
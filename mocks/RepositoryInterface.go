@@ -0,0 +1,189 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// RepositoryInterface is an autogenerated mock type for the RepositoryInterface type
+type RepositoryInterface[T interface{}] struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, model
+func (_m *RepositoryInterface[T]) Create(ctx context.Context, model T) (int64, error) {
+	ret := _m.Called(ctx, model)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, T) (int64, error)); ok {
+		return rf(ctx, model)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, T) int64); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, T) error); ok {
+		r1 = rf(ctx, model)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *RepositoryInterface[T]) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Exists provides a mock function with given fields: ctx, id
+func (_m *RepositoryInterface[T]) Exists(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBy provides a mock function with given fields: ctx, column, value
+func (_m *RepositoryInterface[T]) FindBy(ctx context.Context, column string, value interface{}) ([]T, error) {
+	ret := _m.Called(ctx, column, value)
+
+	var r0 []T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) ([]T, error)); ok {
+		return rf(ctx, column, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) []T); ok {
+		r0 = rf(ctx, column, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, column, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *RepositoryInterface[T]) GetByID(ctx context.Context, id int64) (T, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (T, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) T); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, cursor, size
+func (_m *RepositoryInterface[T]) List(ctx context.Context, cursor pagination.Cursor, size int) (pagination.Page[T], error) {
+	ret := _m.Called(ctx, cursor, size)
+
+	var r0 pagination.Page[T]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Cursor, int) (pagination.Page[T], error)); ok {
+		return rf(ctx, cursor, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Cursor, int) pagination.Page[T]); ok {
+		r0 = rf(ctx, cursor, size)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[T])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Cursor, int) error); ok {
+		r1 = rf(ctx, cursor, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, model, columns
+func (_m *RepositoryInterface[T]) Update(ctx context.Context, id int64, model T, columns ...string) error {
+	_va := make([]interface{}, len(columns))
+	for _i := range columns {
+		_va[_i] = columns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, model)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, T, ...string) error); ok {
+		r0 = rf(ctx, id, model, columns...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepositoryInterface creates a new instance of RepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepositoryInterface[T interface{}](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RepositoryInterface[T] {
+	mock := &RepositoryInterface[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
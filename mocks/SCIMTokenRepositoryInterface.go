@@ -0,0 +1,117 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SCIMTokenRepositoryInterface is an autogenerated mock type for the SCIMTokenRepositoryInterface type
+type SCIMTokenRepositoryInterface struct {
+	mock.Mock
+}
+
+// AddSCIMToken provides a mock function with given fields: ctx, token
+func (_m *SCIMTokenRepositoryInterface) AddSCIMToken(ctx context.Context, token models.SCIMTokens) (models.SCIMTokens, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 models.SCIMTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SCIMTokens) (models.SCIMTokens, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SCIMTokens) models.SCIMTokens); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(models.SCIMTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SCIMTokens) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSCIMToken provides a mock function with given fields: ctx, organizationID, id
+func (_m *SCIMTokenRepositoryInterface) DeleteSCIMToken(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSCIMTokens provides a mock function with given fields: ctx, organizationID
+func (_m *SCIMTokenRepositoryInterface) GetSCIMTokens(ctx context.Context, organizationID int32) ([]models.SCIMTokens, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.SCIMTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.SCIMTokens, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.SCIMTokens); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SCIMTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseSCIMToken provides a mock function with given fields: ctx, tokenHash
+func (_m *SCIMTokenRepositoryInterface) UseSCIMToken(ctx context.Context, tokenHash string) (models.SCIMTokens, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 models.SCIMTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.SCIMTokens, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.SCIMTokens); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.SCIMTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSCIMTokenRepositoryInterface creates a new instance of SCIMTokenRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSCIMTokenRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SCIMTokenRepositoryInterface {
+	mock := &SCIMTokenRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,124 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SCIMTokenServiceInterface is an autogenerated mock type for the SCIMTokenServiceInterface type
+type SCIMTokenServiceInterface struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *SCIMTokenServiceInterface) Authenticate(ctx context.Context, token string) (models.Organizations, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 models.Organizations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Organizations, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Organizations); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(models.Organizations)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateToken provides a mock function with given fields: ctx, organizationID, description
+func (_m *SCIMTokenServiceInterface) CreateToken(ctx context.Context, organizationID int32, description string) (models.SCIMTokens, string, error) {
+	ret := _m.Called(ctx, organizationID, description)
+
+	var r0 models.SCIMTokens
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (models.SCIMTokens, string, error)); ok {
+		return rf(ctx, organizationID, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) models.SCIMTokens); ok {
+		r0 = rf(ctx, organizationID, description)
+	} else {
+		r0 = ret.Get(0).(models.SCIMTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) string); ok {
+		r1 = rf(ctx, organizationID, description)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int32, string) error); ok {
+		r2 = rf(ctx, organizationID, description)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTokens provides a mock function with given fields: ctx, organizationID
+func (_m *SCIMTokenServiceInterface) GetTokens(ctx context.Context, organizationID int32) ([]models.SCIMTokens, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.SCIMTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.SCIMTokens, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.SCIMTokens); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SCIMTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: ctx, organizationID, id
+func (_m *SCIMTokenServiceInterface) RevokeToken(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSCIMTokenServiceInterface creates a new instance of SCIMTokenServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSCIMTokenServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SCIMTokenServiceInterface {
+	mock := &SCIMTokenServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SSOServiceInterface is an autogenerated mock type for the SSOServiceInterface type
+type SSOServiceInterface struct {
+	mock.Mock
+}
+
+// ConsumeAssertion provides a mock function with given fields: ctx, org, samlResponse, relayState
+func (_m *SSOServiceInterface) ConsumeAssertion(ctx context.Context, org models.Organizations, samlResponse string, relayState string) (models.Identity, error) {
+	ret := _m.Called(ctx, org, samlResponse, relayState)
+
+	var r0 models.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organizations, string, string) (models.Identity, error)); ok {
+		return rf(ctx, org, samlResponse, relayState)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organizations, string, string) models.Identity); ok {
+		r0 = rf(ctx, org, samlResponse, relayState)
+	} else {
+		r0 = ret.Get(0).(models.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Organizations, string, string) error); ok {
+		r1 = rf(ctx, org, samlResponse, relayState)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginURL provides a mock function with given fields: org
+func (_m *SSOServiceInterface) LoginURL(org models.Organizations) (string, error) {
+	ret := _m.Called(org)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Organizations) (string, error)); ok {
+		return rf(org)
+	}
+	if rf, ok := ret.Get(0).(func(models.Organizations) string); ok {
+		r0 = rf(org)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Organizations) error); ok {
+		r1 = rf(org)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Metadata provides a mock function with given fields: org
+func (_m *SSOServiceInterface) Metadata(org models.Organizations) ([]byte, error) {
+	ret := _m.Called(org)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Organizations) ([]byte, error)); ok {
+		return rf(org)
+	}
+	if rf, ok := ret.Get(0).(func(models.Organizations) []byte); ok {
+		r0 = rf(org)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Organizations) error); ok {
+		r1 = rf(org)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSSOServiceInterface creates a new instance of SSOServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSSOServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SSOServiceInterface {
+	mock := &SSOServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserProvisionerInterface is an autogenerated mock type for the UserProvisionerInterface type
+type UserProvisionerInterface struct {
+	mock.Mock
+}
+
+// DeleteUser provides a mock function with given fields: ctx, organizationID, id
+func (_m *UserProvisionerInterface) DeleteUser(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUserByID provides a mock function with given fields: ctx, organizationID, id
+func (_m *UserProvisionerInterface) GetUserByID(ctx context.Context, organizationID int32, id int32) (models.Users, error) {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) (models.Users, error)); ok {
+		return rf(ctx, organizationID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) models.Users); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, organizationID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx, organizationID, conditions, page
+func (_m *UserProvisionerInterface) GetUsers(ctx context.Context, organizationID int32, conditions []models.Condition, page models.Page) ([]models.Users, int, error) {
+	ret := _m.Called(ctx, organizationID, conditions, page)
+
+	var r0 []models.Users
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []models.Condition, models.Page) ([]models.Users, int, error)); ok {
+		return rf(ctx, organizationID, conditions, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, []models.Condition, models.Page) []models.Users); ok {
+		r0 = rf(ctx, organizationID, conditions, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Users)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, []models.Condition, models.Page) int); ok {
+		r1 = rf(ctx, organizationID, conditions, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int32, []models.Condition, models.Page) error); ok {
+		r2 = rf(ctx, organizationID, conditions, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ProvisionUser provides a mock function with given fields: ctx, user
+func (_m *UserProvisionerInterface) ProvisionUser(ctx context.Context, user models.Users) (models.Users, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) (models.Users, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) models.Users); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Users) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *UserProvisionerInterface) UpdateUser(ctx context.Context, user models.Users) (models.Users, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) (models.Users, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) models.Users); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Users) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserProvisionerInterface creates a new instance of UserProvisionerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserProvisionerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserProvisionerInterface {
+	mock := &UserProvisionerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,13 +2,92 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // UserReaderInterface is an autogenerated mock type for the UserReaderInterface type
 type UserReaderInterface struct {
 	mock.Mock
 }
 
+// GetUserByEmail provides a mock function with given fields: ctx, organizationID, email
+func (_m *UserReaderInterface) GetUserByEmail(ctx context.Context, organizationID int32, email string) (models.Users, error) {
+	ret := _m.Called(ctx, organizationID, email)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (models.Users, error)); ok {
+		return rf(ctx, organizationID, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) models.Users); ok {
+		r0 = rf(ctx, organizationID, email)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = rf(ctx, organizationID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByUsername provides a mock function with given fields: ctx, organizationID, username
+func (_m *UserReaderInterface) GetUserByUsername(ctx context.Context, organizationID int32, username string) (models.Users, error) {
+	ret := _m.Called(ctx, organizationID, username)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (models.Users, error)); ok {
+		return rf(ctx, organizationID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) models.Users); ok {
+		r0 = rf(ctx, organizationID, username)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = rf(ctx, organizationID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, organizationID, userID
+func (_m *UserReaderInterface) GetUserRoles(ctx context.Context, organizationID int32, userID int32) ([]string, error) {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) ([]string, error)); ok {
+		return rf(ctx, organizationID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) []string); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, organizationID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserReaderInterface creates a new instance of UserReaderInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserReaderInterface(t interface {
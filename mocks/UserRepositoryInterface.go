@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/Pedrommb91/go-auth/internal/api/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,23 +14,97 @@ type UserRepositoryInterface struct {
 	mock.Mock
 }
 
-// AddUser provides a mock function with given fields: user
-func (_m *UserRepositoryInterface) AddUser(user models.Users) (int64, error) {
-	ret := _m.Called(user)
+// AddUser provides a mock function with given fields: ctx, user
+func (_m *UserRepositoryInterface) AddUser(ctx context.Context, user models.Users) (int64, error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.Users) (int64, error)); ok {
-		return rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) (int64, error)); ok {
+		return rf(ctx, user)
 	}
-	if rf, ok := ret.Get(0).(func(models.Users) int64); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) int64); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(models.Users) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Users) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, organizationID, email
+func (_m *UserRepositoryInterface) GetUserByEmail(ctx context.Context, organizationID int32, email string) (models.Users, error) {
+	ret := _m.Called(ctx, organizationID, email)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (models.Users, error)); ok {
+		return rf(ctx, organizationID, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) models.Users); ok {
+		r0 = rf(ctx, organizationID, email)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = rf(ctx, organizationID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByUsername provides a mock function with given fields: ctx, organizationID, username
+func (_m *UserRepositoryInterface) GetUserByUsername(ctx context.Context, organizationID int32, username string) (models.Users, error) {
+	ret := _m.Called(ctx, organizationID, username)
+
+	var r0 models.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (models.Users, error)); ok {
+		return rf(ctx, organizationID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) models.Users); ok {
+		r0 = rf(ctx, organizationID, username)
+	} else {
+		r0 = ret.Get(0).(models.Users)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = rf(ctx, organizationID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, organizationID, userID
+func (_m *UserRepositoryInterface) GetUserRoles(ctx context.Context, organizationID int32, userID int32) ([]string, error) {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) ([]string, error)); ok {
+		return rf(ctx, organizationID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) []string); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, organizationID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
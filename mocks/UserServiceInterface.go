@@ -2,30 +2,34 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // UserServiceInterface is an autogenerated mock type for the UserServiceInterface type
 type UserServiceInterface struct {
 	mock.Mock
 }
 
-// AddUser provides a mock function with given fields: username, email, password
-func (_m *UserServiceInterface) AddUser(username string, email string, password string) (int64, error) {
-	ret := _m.Called(username, email, password)
+// AddUser provides a mock function with given fields: ctx, organizationID, username, email, password
+func (_m *UserServiceInterface) AddUser(ctx context.Context, organizationID int32, username string, email string, password string) (int64, error) {
+	ret := _m.Called(ctx, organizationID, username, email, password)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (int64, error)); ok {
-		return rf(username, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string, string, string) (int64, error)); ok {
+		return rf(ctx, organizationID, username, email, password)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) int64); ok {
-		r0 = rf(username, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string, string, string) int64); ok {
+		r0 = rf(ctx, organizationID, username, email, password)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(username, email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string, string, string) error); ok {
+		r1 = rf(ctx, organizationID, username, email, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/Pedrommb91/go-auth/internal/api/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,23 +14,23 @@ type UserWriterInterface struct {
 	mock.Mock
 }
 
-// AddUser provides a mock function with given fields: user
-func (_m *UserWriterInterface) AddUser(user models.Users) (int64, error) {
-	ret := _m.Called(user)
+// AddUser provides a mock function with given fields: ctx, user
+func (_m *UserWriterInterface) AddUser(ctx context.Context, user models.Users) (int64, error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.Users) (int64, error)); ok {
-		return rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) (int64, error)); ok {
+		return rf(ctx, user)
 	}
-	if rf, ok := ret.Get(0).(func(models.Users) int64); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Users) int64); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(models.Users) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Users) error); ok {
+		r1 = rf(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,247 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/Pedrommb91/go-auth/pkg/pagination"
+
+	time "time"
+)
+
+// WebhookRepositoryInterface is an autogenerated mock type for the WebhookRepositoryInterface type
+type WebhookRepositoryInterface struct {
+	mock.Mock
+}
+
+// AddDelivery provides a mock function with given fields: ctx, delivery
+func (_m *WebhookRepositoryInterface) AddDelivery(ctx context.Context, delivery models.WebhookDeliveries) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookDeliveries) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddWebhook provides a mock function with given fields: ctx, webhook
+func (_m *WebhookRepositoryInterface) AddWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) (models.Webhooks, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) models.Webhooks); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(models.Webhooks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Webhooks) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, now, lease, limit
+func (_m *WebhookRepositoryInterface) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Time, limit int) ([]models.WebhookDeliveries, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	var r0 []models.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]models.WebhookDeliveries, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []models.WebhookDeliveries); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDeliveries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, organizationID, id
+func (_m *WebhookRepositoryInterface) DeleteWebhook(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, webhookID, cursor, size
+func (_m *WebhookRepositoryInterface) GetDeliveries(ctx context.Context, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[models.WebhookDeliveries], error) {
+	ret := _m.Called(ctx, webhookID, cursor, size)
+
+	var r0 pagination.Page[models.WebhookDeliveries]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, pagination.Cursor, int) (pagination.Page[models.WebhookDeliveries], error)); ok {
+		return rf(ctx, webhookID, cursor, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, pagination.Cursor, int) pagination.Page[models.WebhookDeliveries]); ok {
+		r0 = rf(ctx, webhookID, cursor, size)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[models.WebhookDeliveries])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, pagination.Cursor, int) error); ok {
+		r1 = rf(ctx, webhookID, cursor, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhook provides a mock function with given fields: ctx, organizationID, id
+func (_m *WebhookRepositoryInterface) GetWebhook(ctx context.Context, organizationID int32, id int32) (models.Webhooks, error) {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) (models.Webhooks, error)); ok {
+		return rf(ctx, organizationID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) models.Webhooks); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Get(0).(models.Webhooks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, organizationID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, organizationID
+func (_m *WebhookRepositoryInterface) GetWebhooks(ctx context.Context, organizationID int32) ([]models.Webhooks, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.Webhooks, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.Webhooks); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhooks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeliver provides a mock function with given fields: ctx, webhookID, id, at
+func (_m *WebhookRepositoryInterface) Redeliver(ctx context.Context, webhookID int32, id int64, at time.Time) (models.WebhookDeliveries, error) {
+	ret := _m.Called(ctx, webhookID, id, at)
+
+	var r0 models.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int64, time.Time) (models.WebhookDeliveries, error)); ok {
+		return rf(ctx, webhookID, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int64, time.Time) models.WebhookDeliveries); ok {
+		r0 = rf(ctx, webhookID, id, at)
+	} else {
+		r0 = ret.Get(0).(models.WebhookDeliveries)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int64, time.Time) error); ok {
+		r1 = rf(ctx, webhookID, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAttempt provides a mock function with given fields: ctx, delivery
+func (_m *WebhookRepositoryInterface) SaveAttempt(ctx context.Context, delivery models.WebhookDeliveries) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookDeliveries) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *WebhookRepositoryInterface) UpdateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) (models.Webhooks, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) models.Webhooks); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(models.Webhooks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Webhooks) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookRepositoryInterface creates a new instance of WebhookRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepositoryInterface {
+	mock := &WebhookRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
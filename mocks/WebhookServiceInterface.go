@@ -0,0 +1,167 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Pedrommb91/go-auth/internal/api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// WebhookServiceInterface is an autogenerated mock type for the WebhookServiceInterface type
+type WebhookServiceInterface struct {
+	mock.Mock
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *WebhookServiceInterface) CreateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) (models.Webhooks, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) models.Webhooks); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(models.Webhooks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Webhooks) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, organizationID, id
+func (_m *WebhookServiceInterface) DeleteWebhook(ctx context.Context, organizationID int32, id int32) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, organizationID, webhookID, cursor, size
+func (_m *WebhookServiceInterface) GetDeliveries(ctx context.Context, organizationID int32, webhookID int32, cursor pagination.Cursor, size int) (pagination.Page[models.WebhookDeliveries], error) {
+	ret := _m.Called(ctx, organizationID, webhookID, cursor, size)
+
+	var r0 pagination.Page[models.WebhookDeliveries]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, pagination.Cursor, int) (pagination.Page[models.WebhookDeliveries], error)); ok {
+		return rf(ctx, organizationID, webhookID, cursor, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, pagination.Cursor, int) pagination.Page[models.WebhookDeliveries]); ok {
+		r0 = rf(ctx, organizationID, webhookID, cursor, size)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[models.WebhookDeliveries])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32, pagination.Cursor, int) error); ok {
+		r1 = rf(ctx, organizationID, webhookID, cursor, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, organizationID
+func (_m *WebhookServiceInterface) GetWebhooks(ctx context.Context, organizationID int32) ([]models.Webhooks, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]models.Webhooks, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []models.Webhooks); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhooks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeliver provides a mock function with given fields: ctx, organizationID, webhookID, id
+func (_m *WebhookServiceInterface) Redeliver(ctx context.Context, organizationID int32, webhookID int32, id int64) (models.WebhookDeliveries, error) {
+	ret := _m.Called(ctx, organizationID, webhookID, id)
+
+	var r0 models.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, int64) (models.WebhookDeliveries, error)); ok {
+		return rf(ctx, organizationID, webhookID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32, int64) models.WebhookDeliveries); ok {
+		r0 = rf(ctx, organizationID, webhookID, id)
+	} else {
+		r0 = ret.Get(0).(models.WebhookDeliveries)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32, int64) error); ok {
+		r1 = rf(ctx, organizationID, webhookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *WebhookServiceInterface) UpdateWebhook(ctx context.Context, webhook models.Webhooks) (models.Webhooks, error) {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 models.Webhooks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) (models.Webhooks, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhooks) models.Webhooks); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(models.Webhooks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Webhooks) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookServiceInterface creates a new instance of WebhookServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookServiceInterface {
+	mock := &WebhookServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
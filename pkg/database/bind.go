@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// bind returns q rewriting the $n placeholders of the statements when the
+// dialect only knows positional ones, so the same statement runs on every
+// dialect.
+func bind(q Querier, d Dialect) Querier {
+	if d.Placeholder(1) != "?" {
+		return q
+	}
+	return positionalQuerier{q}
+}
+
+type positionalQuerier struct {
+	q Querier
+}
+
+func (p positionalQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, args = rebind(query, args)
+	return p.q.ExecContext(ctx, query, args...)
+}
+
+func (p positionalQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query, args = rebind(query, args)
+	return p.q.QueryContext(ctx, query, args...)
+}
+
+func (p positionalQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	query, args = rebind(query, args)
+	return p.q.QueryRowContext(ctx, query, args...)
+}
+
+// rebind replaces the $n placeholders of query with ?, the arguments are
+// repeated and reordered to follow them. Quoted text is left untouched and
+// queries without $n placeholders are returned as they are.
+func rebind(query string, args []any) (string, []any) {
+	if !strings.Contains(query, "$") {
+		return query, args
+	}
+
+	var b strings.Builder
+	bound := make([]any, 0, len(args))
+	var quote byte
+	found := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '$':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			n, err := strconv.Atoi(query[i+1 : j])
+			if err != nil || n < 1 || n > len(args) {
+				break
+			}
+			b.WriteByte('?')
+			bound = append(bound, args[n-1])
+			found = true
+			i = j - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	if !found {
+		return query, args
+	}
+	return b.String(), bound
+}
+
+// InsertID runs the INSERT statement and returns the id of the new row, read
+// with RETURNING or from the result depending on the dialect.
+func InsertID(ctx context.Context, q Querier, d Dialect, query string, args ...any) (int64, error) {
+	if !d.SupportsReturning() {
+		res, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return res.LastInsertId()
+	}
+
+	var id int64
+	err := q.QueryRowContext(ctx, query+" RETURNING id", args...).Scan(&id)
+	return id, err
+}
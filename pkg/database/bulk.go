@@ -0,0 +1,203 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// insertBatchSize caps the rows written by a single INSERT of InsertMany.
+const insertBatchSize = 500
+
+// Upsert inserts the model or, when it clashes with an existing row on the
+// conflict columns, updates the update columns of that row. Without update
+// columns every written column but the conflict ones, the id and the
+// created timestamp is updated. The id of the inserted or updated row is returned, the references of the
+// model are not written.
+func (q *queryBuilder[T]) Upsert(model T, conflict []string, update []string) (int64, error) {
+	const op errors.Op = "database.Upsert"
+
+	if q.err != nil {
+		return 0, invalidQuery(op, q.err)
+	}
+	if len(conflict) == 0 {
+		return 0, invalidQuery(op, fmt.Errorf("missing conflict columns"))
+	}
+
+	model = stamp(model, q.now(), createdTimestamp, updatedTimestamp).(T)
+	parser := NewModelParser[T](model)
+	columns := parser.GetColumns()
+	values, err := parser.GetRowValues()
+	if err != nil {
+		return 0, invalidModel(op, err, "upsert entry")
+	}
+
+	if len(update) == 0 {
+		skip := map[string]bool{"id": true, parser.GetTimestampColumn(createdTimestamp): true}
+		for _, column := range conflict {
+			skip[column] = true
+		}
+		for i, column := range columns {
+			if !skip[column] && (values[i] != nil || q.dialect.SupportsDefault()) {
+				update = append(update, column)
+			}
+		}
+	}
+	// an update of the conflict column to itself still reports the id of
+	// the existing row, ignoring the insert would not
+	if len(update) == 0 {
+		update = conflict[:1]
+	}
+
+	// the unique keys of soft deleted tables only hold among the live rows
+	where := ""
+	if deleted := q.timestampColumn(deletedTimestamp); deleted != "" {
+		where = deleted + " IS NULL"
+	}
+
+	p := q.newParams()
+	query := q.insertSQL(p, parser.GetTableName(), columns, [][]any{values}) + q.dialect.Upsert(conflict, where, update)
+	// without RETURNING the id of an updated row is only reported through
+	// LAST_INSERT_ID
+	if !q.dialect.SupportsReturning() {
+		query += ", id = LAST_INSERT_ID(id)"
+	}
+
+	id, err := InsertID(q.ctx, q.conn(), q.dialect, query, p.args...)
+	if err != nil {
+		return 0, constraintError(op, err, "upsert entry")
+	}
+
+	return id, nil
+}
+
+// InsertMany inserts the models with multi row inserts in a single
+// transaction and returns their ids in order. The references of the models
+// are not written.
+func (q *queryBuilder[T]) InsertMany(models []T) ([]int64, error) {
+	const op errors.Op = "database.InsertMany"
+
+	if q.err != nil {
+		return nil, invalidQuery(op, q.err)
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	parser := NewModelParser[T](models[0])
+	table := parser.GetTableName()
+	columns := parser.GetColumns()
+	rows := make([][]any, 0, len(models))
+	now := q.now()
+	for _, model := range models {
+		model = stamp(model, now, createdTimestamp, updatedTimestamp).(T)
+		row, err := NewModelParser[T](model).GetRowValues()
+		if err != nil {
+			return nil, invalidModel(op, err, "insert entries")
+		}
+		rows = append(rows, row)
+	}
+
+	tx, err := q.begin()
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to insert entries"),
+		)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(models))
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && end-start < insertBatchSize && q.sameColumns(rows[start], rows[end]) {
+			end++
+		}
+
+		batch, err := q.insertRows(q.hooked(tx), table, columns, rows[start:end])
+		if err != nil {
+			return nil, constraintError(op, err, "insert entries")
+		}
+		ids = append(ids, batch...)
+		start = end
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to insert entries"),
+		)
+	}
+
+	return ids, nil
+}
+
+// sameColumns reports whether two rows can share an INSERT, always when the
+// columns left to their default can be written as DEFAULT.
+func (q *queryBuilder[T]) sameColumns(a, b []any) bool {
+	if q.dialect.SupportsDefault() {
+		return true
+	}
+
+	written := false
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) {
+			return false
+		}
+		written = written || a[i] != nil
+	}
+	// DEFAULT VALUES inserts a single row
+	return written
+}
+
+// insertRows inserts the rows with a single statement and returns their ids
+// in the order of the rows.
+func (q *queryBuilder[T]) insertRows(conn Querier, table string, columns []string, rows [][]any) ([]int64, error) {
+	p := q.newParams()
+	query := q.insertSQL(p, table, columns, rows)
+
+	// the auto increment ids of a multi row insert are consecutive, the
+	// first one is reported
+	if !q.dialect.SupportsReturning() {
+		res, err := conn.ExecContext(q.ctx, query, p.args...)
+		if err != nil {
+			return nil, err
+		}
+		first, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]int64, len(rows))
+		for i := range ids {
+			ids[i] = first + int64(i)
+		}
+		return ids, nil
+	}
+
+	result, err := conn.QueryContext(q.ctx, query+" RETURNING id", p.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	ids := make([]int64, 0, len(rows))
+	for result.Next() {
+		var id int64
+		if err := result.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+
+	// the ids are generated in the order of the rows but RETURNING does not
+	// promise to report them in that order
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
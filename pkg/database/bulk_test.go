@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder_UpsertSQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conflict := []string{"organization_id", "username"}
+
+	id, err := With[models.Users](ctx, db).Upsert(models.Users{
+		OrganizationID: 1,
+		Username:       "jane",
+		Email:          "jane@example.com",
+	}, conflict, nil)
+	require.NoError(t, err)
+
+	updated, err := With[models.Users](ctx, db).Upsert(models.Users{
+		OrganizationID: 1,
+		Username:       "jane",
+		Email:          "jane.doe@example.com",
+		DisplayName:    "Jane",
+	}, conflict, []string{"email"})
+	require.NoError(t, err)
+	assert.Equal(t, id, updated)
+
+	got, err := With[models.Users](ctx, db).Where("id", Equal, id).Run()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "jane.doe@example.com", got[0].Email)
+	assert.Empty(t, got[0].DisplayName, "only the update columns are written")
+
+	_, err = With[models.Users](ctx, db).Upsert(models.Users{Username: "john"}, nil, nil)
+	assert.True(t, errors.IsKind(err, errors.BadRequest))
+}
+
+func TestQueryBuilder_InsertManySQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	users := []models.Users{
+		{OrganizationID: 1, Username: "a", Email: "a@example.com"},
+		{OrganizationID: 1, Username: "b", Email: "b@example.com", DisplayName: "B"},
+		{OrganizationID: 1, Username: "c", Email: "c@example.com", DisplayName: "C"},
+	}
+
+	ids, err := With[models.Users](ctx, db).InsertMany(users)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	for i, id := range ids {
+		got, err := With[models.Users](ctx, db).Where("id", Equal, id).Run()
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, users[i].Username, got[0].Username)
+	}
+
+	_, err = With[models.Users](ctx, db).InsertMany([]models.Users{
+		{OrganizationID: 1, Username: "d", Email: "d@example.com"},
+		{OrganizationID: 1, Username: "a", Email: "a@example.com"},
+	})
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "duplicates are a bad request: %v", err)
+
+	count, err := With[models.Users](ctx, db).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count, "a failed batch inserts nothing")
+}
+
+func TestQueryBuilder_InsertManyPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO credentials\(id, salt, passhash, created_at, updated_at, deleted_at\) `+
+		`VALUES \(default, \$1, \$2, \$3, \$4, default\), \(default, \$5, \$6, \$7, \$8, default\) RETURNING id`).
+		WithArgs("s1", "h1", sqlmock.AnyArg(), sqlmock.AnyArg(), "s2", "h2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(8)).AddRow(int64(7)))
+	mock.ExpectCommit()
+
+	ids, err := With[models.Credentials](context.Background(), db).InsertMany([]models.Credentials{
+		{Salt: "s1", PassHash: "h1"},
+		{Salt: "s2", PassHash: "h2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 8}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_UpsertMySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO credentials\(id, salt, passhash, created_at, updated_at, deleted_at\) `+
+		`VALUES \(default, \?, \?, \?, \?, default\) `+
+		`ON DUPLICATE KEY UPDATE passhash = VALUES\(passhash\), id = LAST_INSERT_ID\(id\)$`).
+		WithArgs("salt", "hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO credentials\(id, salt, passhash, created_at, updated_at, deleted_at\) ` +
+		`VALUES \(default, \?, \?, \?, \?, default\), \(default, \?, \?, \?, \?, default\)$`).
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectCommit()
+
+	q := With[models.Credentials](context.Background(), db)
+	q.dialect = MySQL
+	id, err := q.Upsert(models.Credentials{Salt: "salt", PassHash: "hash"}, []string{"salt"}, []string{"passhash"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+
+	q = With[models.Credentials](context.Background(), db)
+	q.dialect = MySQL
+	ids, err := q.InsertMany([]models.Credentials{{Salt: "s1", PassHash: "h1"}, {Salt: "s2", PassHash: "h2"}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
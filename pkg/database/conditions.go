@@ -0,0 +1,215 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	Equal        = "="
+	NotEqual     = "<>"
+	Less         = "<"
+	LessEqual    = "<="
+	Greater      = ">"
+	GreaterEqual = ">="
+	Like         = "LIKE"
+	ILike        = "ILIKE"
+	In           = "IN"
+	NotIn        = "NOT IN"
+	IsNull       = "IS NULL"
+	IsNotNull    = "IS NOT NULL"
+)
+
+var operators = map[string]bool{
+	Equal:        true,
+	NotEqual:     true,
+	Less:         true,
+	LessEqual:    true,
+	Greater:      true,
+	GreaterEqual: true,
+	Like:         true,
+	ILike:        true,
+	In:           true,
+	NotIn:        true,
+	IsNull:       true,
+	IsNotNull:    true,
+}
+
+// Conditions is a list of comparisons on the columns of a model, joined with
+// AND unless they are added as an OR group.
+type Conditions struct {
+	columns    map[string]bool
+	conditions []condition
+	err        error
+}
+
+type condition struct {
+	conjunction string
+	column      string
+	operator    string
+	value       any
+	group       *Conditions
+}
+
+func newConditions(columns []string) *Conditions {
+	c := &Conditions{columns: make(map[string]bool, len(columns))}
+	for _, column := range columns {
+		c.columns[column] = true
+	}
+	return c
+}
+
+// Where adds a comparison of the column, IS NULL and IS NOT NULL ignore the
+// value and IN expects a slice.
+func (c *Conditions) Where(column, operator string, value any) *Conditions {
+	operator = strings.ToUpper(operator)
+	if operator == "!=" {
+		operator = NotEqual
+	}
+
+	switch {
+	case !c.columns[column]:
+		c.fail(fmt.Errorf("unknown column %q", column))
+	case !operators[operator]:
+		c.fail(fmt.Errorf("unknown operator %q", operator))
+	}
+
+	c.conditions = append(c.conditions, condition{
+		conjunction: "AND",
+		column:      column,
+		operator:    operator,
+		value:       value,
+	})
+	return c
+}
+
+// And adds the conditions of the group between parentheses.
+func (c *Conditions) And(group func(c *Conditions)) *Conditions {
+	return c.group("AND", group)
+}
+
+// Or adds the conditions of the group between parentheses, the group is
+// joined with OR to the previous conditions.
+func (c *Conditions) Or(group func(c *Conditions)) *Conditions {
+	return c.group("OR", group)
+}
+
+func (c *Conditions) group(conjunction string, group func(c *Conditions)) *Conditions {
+	g := &Conditions{columns: c.columns}
+	group(g)
+	if g.err != nil {
+		c.fail(g.err)
+	}
+
+	c.conditions = append(c.conditions, condition{
+		conjunction: conjunction,
+		group:       g,
+	})
+	return c
+}
+
+func (c *Conditions) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *Conditions) empty() bool {
+	return len(c.conditions) == 0
+}
+
+// sql writes the conditions, binding the values to the params. Columns are
+// qualified with the table when it is given.
+func (c *Conditions) sql(p *params, table string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	var b strings.Builder
+	for i, cond := range c.conditions {
+		if i > 0 {
+			b.WriteString(" " + cond.conjunction + " ")
+		}
+
+		if cond.group != nil {
+			if cond.group.empty() {
+				b.WriteString("TRUE")
+				continue
+			}
+			s, err := cond.group.sql(p, table)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString("(" + s + ")")
+			continue
+		}
+
+		column := qualify(table, cond.column)
+		switch cond.operator {
+		case IsNull, IsNotNull:
+			b.WriteString(column + " " + cond.operator)
+		case In, NotIn:
+			list, err := p.addList(cond.value)
+			if err != nil {
+				return "", err
+			}
+			switch {
+			case list != "":
+				b.WriteString(column + " " + cond.operator + " (" + list + ")")
+			case cond.operator == In:
+				b.WriteString("FALSE")
+			default:
+				b.WriteString("TRUE")
+			}
+		case ILike:
+			b.WriteString(column + " " + p.getDialect().ILike() + " " + p.add(cond.value))
+		default:
+			b.WriteString(column + " " + cond.operator + " " + p.add(cond.value))
+		}
+	}
+
+	return b.String(), nil
+}
+
+// params holds the arguments of a statement, the placeholders are the ones
+// of postgres unless a dialect is set.
+type params struct {
+	dialect Dialect
+	args    []any
+}
+
+func (p *params) getDialect() Dialect {
+	if p.dialect == nil {
+		return Postgres
+	}
+	return p.dialect
+}
+
+// add binds the value and returns its placeholder.
+func (p *params) add(v any) string {
+	p.args = append(p.args, v)
+	return p.getDialect().Placeholder(len(p.args))
+}
+
+// addList binds every element of the slice and returns the placeholders
+// separated by commas.
+func (p *params) addList(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected a slice, got %T", v)
+	}
+
+	list := make([]string, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		list = append(list, p.add(val.Index(i).Interface()))
+	}
+	return strings.Join(list, ", "), nil
+}
+
+func qualify(table, column string) string {
+	if table == "" {
+		return column
+	}
+	return table + "." + column
+}
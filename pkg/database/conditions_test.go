@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+)
+
+func TestConditions_sql(t *testing.T) {
+	columns := []string{"id", "username", "email", "created_at"}
+
+	tests := []struct {
+		name       string
+		conditions func(c *Conditions)
+		wantSQL    string
+		wantArgs   []any
+		wantErr    bool
+	}{
+		{
+			name: "Comparisons are joined with and",
+			conditions: func(c *Conditions) {
+				c.Where("username", "=", "o'brien").Where("id", ">=", 3)
+			},
+			wantSQL:  "username = $1 AND id >= $2",
+			wantArgs: []any{"o'brien", 3},
+		},
+		{
+			name: "Or group",
+			conditions: func(c *Conditions) {
+				c.Where("id", "!=", 1).Or(func(c *Conditions) {
+					c.Where("username", "like", "j%").Where("email", IsNotNull, nil)
+				})
+			},
+			wantSQL:  "id <> $1 OR (username LIKE $2 AND email IS NOT NULL)",
+			wantArgs: []any{1, "j%"},
+		},
+		{
+			name: "In expands the slice",
+			conditions: func(c *Conditions) {
+				c.Where("id", In, []int32{4, 5}).And(func(c *Conditions) {
+					c.Where("email", NotIn, []string{})
+				})
+			},
+			wantSQL:  "id IN ($1, $2) AND (TRUE)",
+			wantArgs: []any{int32(4), int32(5)},
+		},
+		{
+			name: "Empty in matches nothing",
+			conditions: func(c *Conditions) {
+				c.Where("id", In, []int32{})
+			},
+			wantSQL: "FALSE",
+		},
+		{
+			name: "Unknown column",
+			conditions: func(c *Conditions) {
+				c.Where("password", "=", "x")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown operator in group",
+			conditions: func(c *Conditions) {
+				c.Or(func(c *Conditions) {
+					c.Where("id", "~", 1)
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "In without a slice",
+			conditions: func(c *Conditions) {
+				c.Where("id", In, 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConditions(columns)
+			tt.conditions(c)
+
+			p := &params{}
+			got, err := c.sql(p, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sql() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantSQL {
+				t.Errorf("sql() = %v, want %v", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(p.args, tt.wantArgs) {
+				t.Errorf("sql() args = %v, want %v", p.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQueryBuilder_selectSQL(t *testing.T) {
+	// the connection is only opened by the first query
+	db, _ := sql.Open("postgres", "")
+
+	query, args, err := With[models.Credentials](context.Background(), db).
+		Select("id", "salt").
+		Where("id", In, []int{1, 2}).
+		OrderBy("created_at", "desc").
+		Limit(10).
+		Offset(20).
+		selectSQL()
+	if err != nil {
+		t.Fatalf("selectSQL() error = %v", err)
+	}
+
+	wantQuery := "SELECT id, salt FROM credentials WHERE (id IN ($1, $2)) AND deleted_at IS NULL ORDER BY created_at DESC LIMIT $3 OFFSET $4"
+	if query != wantQuery {
+		t.Errorf("selectSQL() = %v, want %v", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []any{1, 2, 10, 20}) {
+		t.Errorf("selectSQL() args = %v", args)
+	}
+
+	_, _, err = With[models.Credentials](context.Background(), db).OrderBy("passhash", "sideways").selectSQL()
+	if err == nil {
+		t.Errorf("selectSQL() expected an error for an invalid direction")
+	}
+
+	_, err = With[models.Credentials](context.Background(), db).Delete()
+	if err == nil {
+		t.Errorf("Delete() expected an error without conditions")
+	}
+}
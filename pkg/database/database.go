@@ -1,29 +1,110 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
 
+	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
 )
 
-func NewPostgresOrDie(cfg config.Database) *sql.DB {
-	connStr := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s search_path=%s sslmode=%s",
-		cfg.Host,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-		cfg.Port,
-		cfg.Schema,
-		cfg.SslMode)
-
-	// Connect to database
-	db, err := sql.Open("postgres", connStr)
+// Open prepares the connection pool of the configured driver, no connection
+// is made until the pool is used.
+func Open(cfg config.Database) (*sql.DB, error) {
+	d, err := DialectByName(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return openDSN(cfg, d.DSN(cfg))
+}
+
+// openDSN prepares the connection pool of dsn, with the driver and the pool
+// settings of cfg.
+func openDSN(cfg config.Database, dsn string) (*sql.DB, error) {
+	d, err := DialectByName(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(d.DriverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// a single connection keeps in memory databases alive and serializes
+	// the writers of the file
+	if d == SQLite {
+		db.SetMaxOpenConns(1)
+	} else if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// Connect opens the pool and pings the database until it answers or the
+// configured retries run out.
+func Connect(ctx context.Context, cfg config.Database) (*sql.DB, error) {
+	const op errors.Op = "database.Connect"
+
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to open the database"),
+		)
+	}
+
+	if err := ping(ctx, db, cfg.PingRetries, cfg.PingBackoff); err != nil {
+		db.Close()
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to connect to the database"),
+		)
+	}
+
+	return db, nil
+}
+
+// ping pings db, after a failure it waits backoff and tries again up to
+// retries times, doubling the wait every time.
+func ping(ctx context.Context, db *sql.DB, retries int, backoff time.Duration) error {
+	err := db.PingContext(ctx)
+	for attempt := 0; err != nil && attempt < retries; attempt++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		err = db.PingContext(ctx)
+	}
+	return err
+}
+
+func NewOrDie(cfg config.Database) *sql.DB {
+	db, err := Connect(context.Background(), cfg)
 	if err != nil {
 		panic(err)
 	}
 
 	return db
 }
+
+func NewPostgresOrDie(cfg config.Database) *sql.DB {
+	cfg.Driver = Postgres.Name()
+	return NewOrDie(cfg)
+}
@@ -0,0 +1,447 @@
+package database
+
+import (
+	"database/sql"
+	nerrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect holds the SQL that differs between the supported databases.
+type Dialect interface {
+	// Name is the dialect of the migrations and of the database config.
+	Name() string
+	DriverName() string
+	DSN(cfg config.Database) string
+	// Placeholder returns the placeholder of the n-th argument, from 1.
+	Placeholder(n int) string
+	// SupportsReturning reports whether INSERT ... RETURNING is available,
+	// otherwise the id is read from the result.
+	SupportsReturning() bool
+	// SupportsDefault reports whether DEFAULT can be written in VALUES,
+	// otherwise columns left to their default are omitted.
+	SupportsDefault() bool
+	// ILike is the operator of case insensitive LIKE.
+	ILike() string
+	// Now is the current UTC timestamp.
+	Now() string
+	// Upsert is the clause appended to an INSERT updating the columns when
+	// the conflict columns clash with an existing row, no columns ignore
+	// the insert instead. A where condition targets the partial unique
+	// index of the conflict columns holding under that condition.
+	Upsert(conflict []string, where string, update []string) string
+	// Lock and Unlock take and release the session lock named by their only
+	// argument, they are empty when the dialect has no such locks.
+	Lock() string
+	Unlock() string
+	// IsConstraintViolation reports integrity constraint violations.
+	IsConstraintViolation(err error) bool
+	// IsUniqueViolation reports unique and primary key violations.
+	IsUniqueViolation(err error) bool
+	// IsSerializationFailure reports transactions that may succeed when
+	// retried.
+	IsSerializationFailure(err error) bool
+	// Columns lists the name and the type of the columns of the table named
+	// by its only argument, ForeignKeys the column and the referenced table
+	// of its foreign keys.
+	Columns() string
+	ForeignKeys() string
+	// ColumnType is the type of the columns of the kind written by the
+	// scaffolded migrations.
+	ColumnType(kind ColumnKind) string
+	// AlterColumnType, AddForeignKey and DropForeignKey are empty when the
+	// dialect can only change the table by rebuilding it.
+	AlterColumnType(table, column, typ string) string
+	AddForeignKey(table, column, references string) string
+	DropForeignKey(table, column string) string
+}
+
+var (
+	Postgres Dialect = postgresDialect{}
+	SQLite   Dialect = sqliteDialect{}
+	MySQL    Dialect = mysqlDialect{}
+)
+
+var dialects = []Dialect{Postgres, SQLite, MySQL}
+
+// DialectByName returns the dialect of the database config, postgres when
+// name is empty.
+func DialectByName(name string) (Dialect, error) {
+	if name == "" {
+		return Postgres, nil
+	}
+	for _, d := range dialects {
+		if d.Name() == name {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown database driver %q", name)
+}
+
+// DialectOf returns the dialect of the driver of db, postgres for unknown
+// drivers.
+func DialectOf(db *sql.DB) Dialect {
+	if db == nil {
+		return Postgres
+	}
+	switch db.Driver().(type) {
+	case *sqlite.Driver:
+		return SQLite
+	case *mysql.MySQLDriver:
+		return MySQL
+	default:
+		return Postgres
+	}
+}
+
+// IsConstraintViolation reports integrity constraint violations of any
+// dialect.
+func IsConstraintViolation(err error) bool {
+	for _, d := range dialects {
+		if d.IsConstraintViolation(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUniqueViolation reports unique and primary key violations of any
+// dialect.
+func IsUniqueViolation(err error) bool {
+	for _, d := range dialects {
+		if d.IsUniqueViolation(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// driverError returns the error wrapped by the innermost custom error, the
+// one reported by the driver.
+func driverError(err error) error {
+	if e, ok := errors.GetFirstNestedError(err).(*errors.Error); ok {
+		return e.Err
+	}
+	return err
+}
+
+// upsert writes the ON CONFLICT clause shared by postgres and sqlite.
+func upsert(conflict []string, where string, update []string) string {
+	target := ""
+	if len(conflict) > 0 {
+		target = " (" + strings.Join(conflict, ", ") + ")"
+		if where != "" {
+			target += " WHERE " + where
+		}
+	}
+	if len(update) == 0 {
+		return " ON CONFLICT" + target + " DO NOTHING"
+	}
+
+	set := make([]string, 0, len(update))
+	for _, column := range update {
+		set = append(set, column+" = EXCLUDED."+column)
+	}
+	return " ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(set, ", ")
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string       { return "postgres" }
+func (postgresDialect) DriverName() string { return "postgres" }
+
+func (postgresDialect) DSN(cfg config.Database) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s search_path=%s sslmode=%s",
+		cfg.Host,
+		cfg.User,
+		cfg.Password,
+		cfg.DBName,
+		cfg.Port,
+		cfg.Schema,
+		cfg.SslMode)
+}
+
+func (postgresDialect) Placeholder(n int) string { return "$" + strconv.Itoa(n) }
+func (postgresDialect) SupportsReturning() bool  { return true }
+func (postgresDialect) SupportsDefault() bool    { return true }
+func (postgresDialect) ILike() string            { return "ILIKE" }
+func (postgresDialect) Now() string              { return "(NOW() AT TIME ZONE 'utc')" }
+
+func (postgresDialect) Upsert(conflict []string, where string, update []string) string {
+	return upsert(conflict, where, update)
+}
+
+func (postgresDialect) Lock() string   { return "SELECT pg_advisory_lock(hashtext($1))" }
+func (postgresDialect) Unlock() string { return "SELECT pg_advisory_unlock(hashtext($1))" }
+
+// Class 23 - Integrity Constraint Violation
+func (postgresDialect) IsConstraintViolation(err error) bool {
+	var pqErr *pq.Error
+	return nerrors.As(driverError(err), &pqErr) && pqErr.Code.Class() == "23"
+}
+
+func (postgresDialect) IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return nerrors.As(driverError(err), &pqErr) && pqErr.Code == "23505"
+}
+
+func (postgresDialect) IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return nerrors.As(driverError(err), &pqErr) && pqErr.Code == "40001"
+}
+
+func (postgresDialect) Columns() string {
+	return `SELECT column_name, data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position`
+}
+
+func (postgresDialect) ForeignKeys() string {
+	return `SELECT kcu.column_name, ccu.table_name FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema() AND tc.table_name = $1`
+}
+
+func (postgresDialect) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnID:
+		return "SERIAL PRIMARY KEY"
+	case ColumnTimestamp:
+		return "TIMESTAMP WITHOUT TIME ZONE"
+	}
+	return columnType(kind)
+}
+
+func (postgresDialect) AlterColumnType(table, column, typ string) string {
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table, column, typ)
+}
+
+func (postgresDialect) AddForeignKey(table, column, references string) string {
+	return addForeignKey(table, column, references)
+}
+
+func (postgresDialect) DropForeignKey(table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", table, foreignKeyName(table, column))
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string       { return "sqlite" }
+func (sqliteDialect) DriverName() string { return "sqlite" }
+
+// DSN opens the file of cfg.Path with foreign keys enforced, writers wait
+// for each other instead of failing right away.
+func (sqliteDialect) DSN(cfg config.Database) string {
+	return "file:" + cfg.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+}
+
+func (sqliteDialect) Placeholder(n int) string { return "$" + strconv.Itoa(n) }
+func (sqliteDialect) SupportsReturning() bool  { return true }
+func (sqliteDialect) SupportsDefault() bool    { return false }
+
+// ILike is LIKE, it ignores the case of ASCII letters.
+func (sqliteDialect) ILike() string { return "LIKE" }
+func (sqliteDialect) Now() string   { return "CURRENT_TIMESTAMP" }
+
+func (sqliteDialect) Upsert(conflict []string, where string, update []string) string {
+	return upsert(conflict, where, update)
+}
+
+// Lock is empty, writers of the file already wait for each other.
+func (sqliteDialect) Lock() string   { return "" }
+func (sqliteDialect) Unlock() string { return "" }
+
+func (sqliteDialect) IsConstraintViolation(err error) bool {
+	code, ok := sqliteCode(err)
+	return ok && code&0xff == sqlite3.SQLITE_CONSTRAINT
+}
+
+func (sqliteDialect) IsUniqueViolation(err error) bool {
+	code, ok := sqliteCode(err)
+	return ok && (code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
+
+// IsSerializationFailure reports a database locked by another writer for
+// longer than the busy timeout.
+func (sqliteDialect) IsSerializationFailure(err error) bool {
+	code, ok := sqliteCode(err)
+	return ok && code&0xff == sqlite3.SQLITE_BUSY
+}
+
+func (sqliteDialect) Columns() string {
+	return `SELECT name, type FROM pragma_table_info($1) ORDER BY cid`
+}
+
+func (sqliteDialect) ForeignKeys() string {
+	return `SELECT "from", "table" FROM pragma_foreign_key_list($1)`
+}
+
+func (sqliteDialect) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnID:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	case ColumnTimestamp:
+		return "TIMESTAMP"
+	}
+	return columnType(kind)
+}
+
+// AlterColumnType is empty, sqlite cannot change the type of a column.
+func (sqliteDialect) AlterColumnType(table, column, typ string) string { return "" }
+
+// AddForeignKey and DropForeignKey are empty, sqlite cannot change the
+// constraints of a table.
+func (sqliteDialect) AddForeignKey(table, column, references string) string { return "" }
+func (sqliteDialect) DropForeignKey(table, column string) string            { return "" }
+
+func sqliteCode(err error) (int, bool) {
+	var sqliteErr *sqlite.Error
+	if !nerrors.As(driverError(err), &sqliteErr) {
+		return 0, false
+	}
+	return sqliteErr.Code(), true
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string       { return "mysql" }
+func (mysqlDialect) DriverName() string { return "mysql" }
+
+// DSN connects over TCP reading timestamps as UTC, TLS is skipped when
+// cfg.SslMode is disable and left unverified when it is require.
+func (mysqlDialect) DSN(cfg config.Database) string {
+	c := mysql.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = cfg.Host + ":" + cfg.Port
+	c.DBName = cfg.DBName
+	c.ParseTime = true
+	c.Loc = time.UTC
+	switch cfg.SslMode {
+	case "", "disable":
+	case "require":
+		c.TLSConfig = "skip-verify"
+	default:
+		c.TLSConfig = "true"
+	}
+	return c.FormatDSN()
+}
+
+func (mysqlDialect) Placeholder(int) string  { return "?" }
+func (mysqlDialect) SupportsReturning() bool { return false }
+func (mysqlDialect) SupportsDefault() bool   { return true }
+
+// ILike is LIKE, the default collations ignore the case.
+func (mysqlDialect) ILike() string { return "LIKE" }
+func (mysqlDialect) Now() string   { return "UTC_TIMESTAMP()" }
+
+// Upsert ignores the conflict columns and the where condition, every unique
+// key of the table is checked. Without columns to update the first conflict
+// column is set to itself so the row is left untouched.
+func (mysqlDialect) Upsert(conflict []string, _ string, update []string) string {
+	set := make([]string, 0, len(update))
+	for _, column := range update {
+		set = append(set, column+" = VALUES("+column+")")
+	}
+	if len(set) == 0 && len(conflict) > 0 {
+		set = append(set, conflict[0]+" = "+conflict[0])
+	}
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+}
+
+// Lock waits for the lock without a timeout.
+func (mysqlDialect) Lock() string   { return "SELECT GET_LOCK($1, -1)" }
+func (mysqlDialect) Unlock() string { return "SELECT RELEASE_LOCK($1)" }
+
+// mysql server error numbers
+const (
+	mysqlDuplicateEntry         = 1062
+	mysqlDuplicateEntryWithKey  = 1586
+	mysqlBadNull                = 1048
+	mysqlNoReferencedRow        = 1216
+	mysqlRowIsReferenced        = 1217
+	mysqlRowIsReferenced2       = 1451
+	mysqlNoReferencedRow2       = 1452
+	mysqlCheckConstraintViolate = 3819
+	mysqlLockDeadlock           = 1213
+)
+
+func (mysqlDialect) IsConstraintViolation(err error) bool {
+	number, ok := mysqlNumber(err)
+	if !ok {
+		return false
+	}
+	switch number {
+	case mysqlDuplicateEntry, mysqlDuplicateEntryWithKey, mysqlBadNull,
+		mysqlNoReferencedRow, mysqlRowIsReferenced, mysqlRowIsReferenced2,
+		mysqlNoReferencedRow2, mysqlCheckConstraintViolate:
+		return true
+	}
+	return false
+}
+
+func (mysqlDialect) IsUniqueViolation(err error) bool {
+	number, ok := mysqlNumber(err)
+	return ok && (number == mysqlDuplicateEntry || number == mysqlDuplicateEntryWithKey)
+}
+
+// IsSerializationFailure reports deadlocks, InnoDB rolls back the whole
+// transaction of the victim.
+func (mysqlDialect) IsSerializationFailure(err error) bool {
+	number, ok := mysqlNumber(err)
+	return ok && number == mysqlLockDeadlock
+}
+
+// Columns reads the column type, the data type does not tell booleans from
+// tinyints.
+func (mysqlDialect) Columns() string {
+	return `SELECT column_name, column_type FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = $1 ORDER BY ordinal_position`
+}
+
+func (mysqlDialect) ForeignKeys() string {
+	return `SELECT column_name, referenced_table_name FROM information_schema.key_column_usage
+		WHERE table_schema = DATABASE() AND table_name = $1 AND referenced_table_name IS NOT NULL`
+}
+
+func (mysqlDialect) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnID:
+		return "INT AUTO_INCREMENT PRIMARY KEY"
+	case ColumnTimestamp:
+		return "DATETIME"
+	}
+	return columnType(kind)
+}
+
+func (mysqlDialect) AlterColumnType(table, column, typ string) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", table, column, typ)
+}
+
+func (mysqlDialect) AddForeignKey(table, column, references string) string {
+	return addForeignKey(table, column, references)
+}
+
+func (mysqlDialect) DropForeignKey(table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", table, foreignKeyName(table, column))
+}
+
+func mysqlNumber(err error) (uint16, bool) {
+	var mysqlErr *mysql.MySQLError
+	if !nerrors.As(driverError(err), &mysqlErr) {
+		return 0, false
+	}
+	return mysqlErr.Number, true
+}
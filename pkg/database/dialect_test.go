@@ -0,0 +1,278 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialect_Upsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		conflict []string
+		where    string
+		update   []string
+		want     string
+	}{
+		{
+			name:     "Postgres updates the columns",
+			dialect:  Postgres,
+			conflict: []string{"organization_id", "name"},
+			update:   []string{"external_id", "updated_at"},
+			want:     " ON CONFLICT (organization_id, name) DO UPDATE SET external_id = EXCLUDED.external_id, updated_at = EXCLUDED.updated_at",
+		},
+		{
+			name:     "Postgres targets the partial unique index",
+			dialect:  Postgres,
+			conflict: []string{"organization_id", "username"},
+			where:    "deleted_at IS NULL",
+			update:   []string{"email"},
+			want:     " ON CONFLICT (organization_id, username) WHERE deleted_at IS NULL DO UPDATE SET email = EXCLUDED.email",
+		},
+		{
+			name:     "SQLite ignores the insert without columns",
+			dialect:  SQLite,
+			conflict: []string{"user_id", "role_id"},
+			want:     " ON CONFLICT (user_id, role_id) DO NOTHING",
+		},
+		{
+			name:     "MySQL updates the columns",
+			dialect:  MySQL,
+			conflict: []string{"organization_id", "name"},
+			update:   []string{"external_id"},
+			want:     " ON DUPLICATE KEY UPDATE external_id = VALUES(external_id)",
+		},
+		{
+			name:     "MySQL ignores the where condition",
+			dialect:  MySQL,
+			conflict: []string{"organization_id", "username"},
+			where:    "deleted_at IS NULL",
+			update:   []string{"email"},
+			want:     " ON DUPLICATE KEY UPDATE email = VALUES(email)",
+		},
+		{
+			name:     "MySQL keeps the row without columns",
+			dialect:  MySQL,
+			conflict: []string{"user_id", "role_id"},
+			want:     " ON DUPLICATE KEY UPDATE user_id = user_id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dialect.Upsert(tt.conflict, tt.where, tt.update))
+		})
+	}
+}
+
+func TestDialectByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Dialect
+		wantErr bool
+	}{
+		{name: "", want: Postgres},
+		{name: "postgres", want: Postgres},
+		{name: "sqlite", want: SQLite},
+		{name: "mysql", want: MySQL},
+		{name: "oracle", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DialectByName(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsConstraintViolation(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		constraint bool
+		unique     bool
+	}{
+		{
+			name:       "Postgres unique violation",
+			err:        &pq.Error{Code: "23505"},
+			constraint: true,
+			unique:     true,
+		},
+		{
+			name:       "Postgres foreign key violation",
+			err:        &pq.Error{Code: "23503"},
+			constraint: true,
+		},
+		{
+			name: "Postgres serialization failure",
+			err:  &pq.Error{Code: "40001"},
+		},
+		{
+			name:       "Wrapped by a custom error",
+			err:        errors.Build(errors.WithError(&pq.Error{Code: "23505"})),
+			constraint: true,
+			unique:     true,
+		},
+		{
+			name:       "MySQL duplicate entry",
+			err:        &mysql.MySQLError{Number: 1062},
+			constraint: true,
+			unique:     true,
+		},
+		{
+			name:       "MySQL foreign key violation",
+			err:        &mysql.MySQLError{Number: 1452},
+			constraint: true,
+		},
+		{
+			name: "MySQL deadlock",
+			err:  &mysql.MySQLError{Number: 1213},
+		},
+		{
+			name: "Other errors",
+			err:  fmt.Errorf("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.constraint, IsConstraintViolation(tt.err))
+			assert.Equal(t, tt.unique, IsUniqueViolation(tt.err))
+		})
+	}
+}
+
+func TestQueryBuilder_SQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	assert.Equal(t, SQLite, DialectOf(db))
+
+	user := models.Users{
+		OrganizationID: 1,
+		Username:       "jane",
+		Email:          "jane@example.com",
+		Active:         true,
+		Credentials: models.Credentials{
+			Salt:     "salt",
+			PassHash: "hash",
+		},
+	}
+
+	id, err := With[models.Users](ctx, db).Insert(user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+
+	_, err = With[models.Users](ctx, db).Insert(user)
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "duplicate username is a bad request: %v", err)
+
+	got, err := With[models.Users](ctx, db).Preload("Credentials").Where("username", ILike, "JANE").Run()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "jane@example.com", got[0].Email)
+	assert.True(t, got[0].Active)
+	assert.Equal(t, "hash", got[0].Credentials.PassHash)
+	assert.WithinDuration(t, time.Now(), got[0].CreatedAt, time.Minute)
+
+	updated, err := With[models.Users](ctx, db).
+		Where("id", Equal, id).
+		Update(models.Users{DisplayName: "Jane"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	count, err := With[models.Users](ctx, db).Where("display_name", Equal, "Jane").Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	deleted, err := With[models.Users](ctx, db).Where("id", In, []int64{id}).Delete()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestMySQLDialect_DSN(t *testing.T) {
+	cfg := config.Database{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "auth",
+		Password: "strong-pw",
+		DBName:   "auth",
+		SslMode:  "disable",
+	}
+	assert.Equal(t, "auth:strong-pw@tcp(localhost:3306)/auth?parseTime=true", MySQL.DSN(cfg))
+
+	cfg.SslMode = "require"
+	assert.Equal(t, "auth:strong-pw@tcp(localhost:3306)/auth?parseTime=true&tls=skip-verify", MySQL.DSN(cfg))
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		args      []any
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "Reorders and repeats the arguments",
+			query:     "UPDATE users SET username = $2 WHERE id = $1 OR credentials_id = $1",
+			args:      []any{1, "jane"},
+			wantQuery: "UPDATE users SET username = ? WHERE id = ? OR credentials_id = ?",
+			wantArgs:  []any{"jane", 1, 1},
+		},
+		{
+			name:      "Leaves quoted text untouched",
+			query:     "SELECT '$1', external_id FROM users WHERE external_id = NULLIF($1, '')",
+			args:      []any{"x"},
+			wantQuery: "SELECT '$1', external_id FROM users WHERE external_id = NULLIF(?, '')",
+			wantArgs:  []any{"x"},
+		},
+		{
+			name:      "Positional placeholders are kept",
+			query:     "SELECT id FROM users WHERE id = ?",
+			args:      []any{1},
+			wantQuery: "SELECT id FROM users WHERE id = ?",
+			wantArgs:  []any{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := rebind(tt.query, tt.args)
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestQueryBuilder_MySQLInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO credentials\(id, salt, passhash, created_at, updated_at, deleted_at\) VALUES \(default, \?, \?, \?, \?, default\)$`).
+		WithArgs("salt", "hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	q := With[models.Credentials](context.Background(), db)
+	q.dialect = MySQL
+
+	id, err := q.Insert(models.Credentials{Salt: "salt", PassHash: "hash"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
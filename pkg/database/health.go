@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// PoolStats are the statistics of the connection pool.
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
+
+// HealthChecker reports whether the primary answers, how its connection pool
+// is used and how many replicas serve reads.
+type HealthChecker struct {
+	db      *DB
+	timeout time.Duration
+}
+
+// NewHealthChecker returns a checker giving up on pings after timeout, zero
+// waits as long as the context of the check.
+func NewHealthChecker(db *DB, timeout time.Duration) *HealthChecker {
+	return &HealthChecker{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// Check pings the primary, the replicas are checked by MonitorReplicas.
+func (h *HealthChecker) Check(ctx context.Context) error {
+	const op errors.Op = "database.HealthChecker.Check"
+
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	if err := h.db.Primary().PingContext(ctx); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Database is unavailable"),
+		)
+	}
+	return nil
+}
+
+// Stats returns the statistics of the pool of the primary.
+func (h *HealthChecker) Stats() PoolStats {
+	s := h.db.Primary().Stats()
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration,
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// Replicas returns how many replicas are in rotation, the reads fall back to
+// the primary when none is.
+func (h *HealthChecker) Replicas() ReplicaStats {
+	return h.db.ReplicaStats()
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name    string
+		retries int
+		fails   int
+		wantErr bool
+	}{
+		{name: "Answers at once", retries: 2},
+		{name: "Answers after retries", retries: 2, fails: 2},
+		{name: "Gives up after the retries", retries: 1, fails: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			defer db.Close()
+
+			for i := 0; i < tt.fails && i <= tt.retries; i++ {
+				mock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+			}
+			if tt.fails <= tt.retries {
+				mock.ExpectPing()
+			}
+
+			err = ping(context.Background(), db, tt.retries, time.Millisecond)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHealthChecker(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectPing()
+	mock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+
+	h := NewHealthChecker(NewDB(db), time.Second)
+	assert.NoError(t, h.Check(context.Background()))
+	assert.Error(t, h.Check(context.Background()))
+	assert.Equal(t, 1, h.Stats().OpenConnections)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthChecker_Replicas(t *testing.T) {
+	primary, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer primary.Close()
+	up, upMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer up.Close()
+	down, downMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer down.Close()
+
+	h := NewHealthChecker(NewDB(primary, up, down), time.Second)
+	assert.Equal(t, ReplicaStats{Total: 2, Healthy: 2}, h.Replicas(), "replicas serve reads from the start")
+
+	upMock.ExpectPing()
+	downMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+	h.db.CheckReplicas(context.Background())
+	assert.Equal(t, ReplicaStats{Total: 2, Healthy: 1}, h.Replicas())
+
+	assert.Equal(t, ReplicaStats{}, NewHealthChecker(NewDB(primary), time.Second).Replicas())
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+)
+
+// QueryEvent is a statement run on a database with hooks. Args are
+// redacted, so hooks can log them without leaking the data written. Duration
+// and Err are only set once the statement ran. For queries returning rows
+// they cover running the query until its first rows are ready, reading the
+// rows is not measured and the errors met while reading them are not
+// reported.
+type QueryEvent struct {
+	Dialect  Dialect
+	Query    string
+	Args     []any
+	Duration time.Duration
+	Err      error
+}
+
+// Hook observes the statements run on a database. Before runs before the
+// statement and the context it returns is given to After, so hooks can keep
+// state like a span around the statement.
+type Hook interface {
+	Before(ctx context.Context, event *QueryEvent) context.Context
+	After(ctx context.Context, event *QueryEvent)
+}
+
+// Use adds hooks observing every statement run on the primary or the
+// replicas of db through the query builder, the connections of its Conn
+// methods and the transactions of its Begin and NewTransactor. It is not safe
+// to call once the database is in use.
+func (db *DB) Use(hooks ...Hook) {
+	db.hooks = append(db.hooks, hooks...)
+}
+
+// WithHooks adds hooks observing the statements of the query.
+func (q *queryBuilder[T]) WithHooks(hooks ...Hook) *queryBuilder[T] {
+	q.hooks = append(q.hooks, hooks...)
+	return q
+}
+
+// hooked returns conn running the hooks of the query around its statements.
+func (q *queryBuilder[T]) hooked(conn Querier) Querier {
+	return withHooks(conn, q.dialect, q.hooks)
+}
+
+// withHooks returns conn running the hooks around its statements.
+func withHooks(conn Querier, dialect Dialect, hooks []Hook) Querier {
+	if len(hooks) == 0 {
+		return conn
+	}
+	return hookedQuerier{q: conn, dialect: dialect, hooks: hooks}
+}
+
+type hookedQuerier struct {
+	q       Querier
+	dialect Dialect
+	hooks   []Hook
+}
+
+func (h hookedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, event, start := h.before(ctx, query, args)
+	res, err := h.q.ExecContext(ctx, query, args...)
+	h.after(ctx, event, start, err)
+	return res, err
+}
+
+// QueryContext runs the after hooks once the first rows are ready, the rows
+// are read by the caller after them.
+func (h hookedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, event, start := h.before(ctx, query, args)
+	rows, err := h.q.QueryContext(ctx, query, args...)
+	h.after(ctx, event, start, err)
+	return rows, err
+}
+
+func (h hookedQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, event, start := h.before(ctx, query, args)
+	row := h.q.QueryRowContext(ctx, query, args...)
+	h.after(ctx, event, start, row.Err())
+	return row
+}
+
+func (h hookedQuerier) before(ctx context.Context, query string, args []any) (context.Context, *QueryEvent, time.Time) {
+	event := &QueryEvent{Dialect: h.dialect, Query: query, Args: redact(args)}
+	for _, hook := range h.hooks {
+		ctx = hook.Before(ctx, event)
+	}
+	return ctx, event, time.Now()
+}
+
+// after runs the hooks in the reverse order of before, so the first hook
+// wraps the others.
+func (h hookedQuerier) after(ctx context.Context, event *QueryEvent, start time.Time, err error) {
+	event.Duration = time.Since(start)
+	event.Err = err
+	for i := len(h.hooks) - 1; i >= 0; i-- {
+		h.hooks[i].After(ctx, event)
+	}
+}
+
+const redacted = "[redacted]"
+
+// redact hides the text and binary arguments, where the credentials and the
+// personal data of the rows are, and keeps the ids, flags and times that
+// identify them.
+func redact(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		switch arg.(type) {
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+			float32, float64, time.Time:
+			out[i] = arg
+		default:
+			out[i] = redacted
+		}
+	}
+	return out
+}
+
+// LogHook logs the failed statements as errors and the ones slower than the
+// threshold as warnings, the others are logged at debug level.
+type LogHook struct {
+	log  logger.Interface
+	slow time.Duration
+}
+
+// NewLogHook returns a hook logging to l, a zero slow threshold disables
+// the slow query warnings.
+func NewLogHook(l logger.Interface, slow time.Duration) *LogHook {
+	return &LogHook{log: l, slow: slow}
+}
+
+func (h *LogHook) Before(ctx context.Context, _ *QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *LogHook) After(_ context.Context, event *QueryEvent) {
+	switch {
+	case event.Err != nil:
+		h.log.Error("query failed after %s: %s %v: %s", event.Duration, event.Query, event.Args, event.Err)
+	case h.slow > 0 && event.Duration >= h.slow:
+		h.log.Warn("slow query took %s: %s %v", event.Duration, event.Query, event.Args)
+	default:
+		h.log.Debug("query took %s: %s %v", event.Duration, event.Query, event.Args)
+	}
+}
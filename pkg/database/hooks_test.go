@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type recordingHook struct {
+	before []QueryEvent
+	after  []QueryEvent
+}
+
+func (h *recordingHook) Before(ctx context.Context, event *QueryEvent) context.Context {
+	h.before = append(h.before, *event)
+	return ctx
+}
+
+func (h *recordingHook) After(_ context.Context, event *QueryEvent) {
+	h.after = append(h.after, *event)
+}
+
+// recordingLogger records the messages of logger.Interface by level.
+type recordingLogger struct {
+	messages map[string][]string
+}
+
+func (l *recordingLogger) record(level string, message any, args ...any) {
+	if l.messages == nil {
+		l.messages = make(map[string][]string)
+	}
+	l.messages[level] = append(l.messages[level], fmt.Sprintf(fmt.Sprint(message), args...))
+}
+
+func (l *recordingLogger) Debug(message any, args ...any)   { l.record("debug", message, args...) }
+func (l *recordingLogger) Info(message string, args ...any) { l.record("info", message, args...) }
+func (l *recordingLogger) Warn(message string, args ...any) { l.record("warn", message, args...) }
+func (l *recordingLogger) Error(message any, args ...any)   { l.record("error", message, args...) }
+func (l *recordingLogger) Fatal(message any, args ...any)   { l.record("fatal", message, args...) }
+
+func TestQueryBuilder_Hooks(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	hook := &recordingHook{}
+
+	_, err = With[models.Credentials](ctx, db).WithHooks(hook).
+		Insert(models.Credentials{Salt: "salt", PassHash: "secret"})
+	require.NoError(t, err)
+	_, err = With[models.Credentials](ctx, db).WithHooks(hook).Where("id", Equal, 1).Run()
+	require.NoError(t, err)
+	_, err = With[models.Credentials](ctx, db).WithHooks(hook).From("missing").Count()
+	require.Error(t, err)
+
+	require.Len(t, hook.before, 3)
+	require.Len(t, hook.after, 3)
+
+	insert := hook.after[0]
+	assert.True(t, strings.HasPrefix(insert.Query, "INSERT INTO credentials"), insert.Query)
+	assert.Equal(t, SQLite, insert.Dialect)
+	assert.NotContains(t, insert.Args, "secret", "text arguments are redacted")
+	assert.Contains(t, insert.Args, redacted)
+	assert.NoError(t, insert.Err)
+	assert.Zero(t, hook.before[0].Duration, "the duration is only known after the statement")
+
+	assert.Equal(t, []any{1}, hook.after[1].Args, "ids are kept")
+	assert.Error(t, hook.after[2].Err)
+}
+
+func TestDB_Use(t *testing.T) {
+	primary, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer primary.Close()
+
+	hook := &recordingHook{}
+	db := NewDB(primary)
+	db.Use(hook)
+
+	ctx := context.Background()
+	_, err = On[models.Credentials](ctx, db).Count()
+	require.NoError(t, err)
+	require.Len(t, hook.after, 1, "the statements of the builder are observed once")
+	assert.Equal(t, "SELECT COUNT(*) FROM credentials WHERE deleted_at IS NULL", hook.after[0].Query)
+
+	// the handwritten statements are observed as well, in and out of
+	// transactions
+	_, err = db.ReadConn(ctx).ExecContext(ctx, `UPDATE credentials SET salt = $1 WHERE id = $2`, "salt", 1)
+	require.NoError(t, err)
+	tx, err := db.Begin(ctx, nil)
+	require.NoError(t, err)
+	_, err = db.WriteConn(tx.Context(ctx)).ExecContext(ctx, `DELETE FROM credentials WHERE id = $1`, 1)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	err = db.NewTransactor().WithinTx(ctx, func(ctx context.Context) error {
+		_, err := On[models.Credentials](ctx, db).Count()
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, hook.after, 4)
+	assert.Equal(t, "UPDATE credentials SET salt = $1 WHERE id = $2", hook.after[1].Query)
+	assert.Equal(t, []any{redacted, 1}, hook.after[1].Args)
+	assert.Equal(t, "DELETE FROM credentials WHERE id = $1", hook.after[2].Query)
+	assert.Equal(t, "SELECT COUNT(*) FROM credentials WHERE deleted_at IS NULL", hook.after[3].Query)
+
+	// the hooks belong to db, the other users of the same pool do not run
+	// them
+	_, err = Conn(ctx, primary).ExecContext(ctx, `DELETE FROM credentials`)
+	require.NoError(t, err)
+	_, err = NewDB(primary).WriteConn(ctx).ExecContext(ctx, `DELETE FROM credentials`)
+	require.NoError(t, err)
+	assert.Len(t, hook.after, 4)
+}
+
+func TestLogHook(t *testing.T) {
+	tests := []struct {
+		name  string
+		slow  time.Duration
+		event QueryEvent
+		level string
+	}{
+		{
+			name:  "Failed query is logged as an error",
+			slow:  time.Second,
+			event: QueryEvent{Query: "SELECT 1", Err: fmt.Errorf("boom")},
+			level: "error",
+		},
+		{
+			name:  "Slow query is logged as a warning",
+			slow:  time.Second,
+			event: QueryEvent{Query: "SELECT 1", Duration: 2 * time.Second},
+			level: "warn",
+		},
+		{
+			name:  "Fast query is logged at debug level",
+			slow:  time.Second,
+			event: QueryEvent{Query: "SELECT 1", Duration: time.Millisecond},
+			level: "debug",
+		},
+		{
+			name:  "Zero threshold disables the slow query warnings",
+			slow:  0,
+			event: QueryEvent{Query: "SELECT 1", Duration: time.Hour},
+			level: "debug",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &recordingLogger{}
+			h := NewLogHook(l, tt.slow)
+
+			ctx := h.Before(context.Background(), &tt.event)
+			h.After(ctx, &tt.event)
+
+			require.Len(t, l.messages[tt.level], 1, "%v", l.messages)
+			assert.Contains(t, l.messages[tt.level][0], "SELECT 1")
+		})
+	}
+}
+
+func TestTracingHook(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	hook := NewTracingHook(tp)
+
+	ctx, request := tp.Tracer("test").Start(context.Background(), "GET /users")
+	_, err = With[models.Credentials](ctx, db).WithHooks(hook).Count()
+	require.NoError(t, err)
+	_, err = With[models.Credentials](ctx, db).WithHooks(hook).From("missing").Count()
+	require.Error(t, err)
+	request.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	count := spans[0]
+	assert.Equal(t, "SELECT", count.Name())
+	assert.Equal(t, request.SpanContext().SpanID(), count.Parent().SpanID(), "query spans are children of the request")
+	assert.Equal(t, request.SpanContext().TraceID(), count.SpanContext().TraceID())
+	assert.Contains(t, count.Attributes(), dbSystem(SQLite))
+	assert.Equal(t, codes.Unset, count.Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1, "the error is recorded")
+}
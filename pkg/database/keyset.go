@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+)
+
+// keysetColumn is the sort key of the pages, ids are unique and never
+// change so a page does not skip nor repeat rows written meanwhile.
+const keysetColumn = "id"
+
+// After sets the cursor of the page read by RunPage, the zero cursor reads
+// the first page. The cursors read from the pages of other tables are
+// rejected.
+func (q *queryBuilder[T]) After(cursor pagination.Cursor) *queryBuilder[T] {
+	if cursor != (pagination.Cursor{}) && cursor.Resource != q.table {
+		q.fail(fmt.Errorf("cursor of %q used on %s", cursor.Resource, q.table))
+	}
+	q.after = &cursor
+	return q
+}
+
+// PageSize sets the number of rows of the page read by RunPage.
+func (q *queryBuilder[T]) PageSize(size int) *queryBuilder[T] {
+	if size <= 0 {
+		q.fail(fmt.Errorf("invalid page size %d", size))
+	}
+	q.pageSize = size
+	return q
+}
+
+// RunPage reads the page of the rows matching the conditions at the cursor
+// set by After, sorted by id. Unlike Offset the cost of a page does not grow
+// with the number of rows before it.
+func (q *queryBuilder[T]) RunPage() (pagination.Page[T], error) {
+	const op errors.Op = "database.RunPage"
+
+	t := reflect.TypeOf(*new(T))
+	key, ok := fieldIndexes(t, "")[keysetColumn]
+	if ok {
+		kind := t.FieldByIndex(key).Type.Kind()
+		ok = kind == reflect.Int32 || kind == reflect.Int64
+	}
+	switch {
+	case !ok:
+		q.fail(fmt.Errorf("%T has no %s to paginate by", *new(T), keysetColumn))
+	case q.pageSize == 0:
+		q.fail(fmt.Errorf("missing page size"))
+	case len(q.orderBy) > 0 || q.limit > 0 || q.offset > 0:
+		q.fail(fmt.Errorf("pages are sorted by %s and sized by PageSize", keysetColumn))
+	}
+	if q.err != nil {
+		return pagination.Page[T]{}, invalidQuery(op, q.err)
+	}
+
+	cursor := pagination.Cursor{}
+	if q.after != nil {
+		cursor = *q.after
+	}
+	direction := Asc
+	if cursor.Backward {
+		direction = Desc
+	}
+	q.orderBy = []order{{column: keysetColumn, direction: direction}}
+	// the extra row tells whether there is a page beyond this one
+	q.limit = q.pageSize + 1
+
+	items, err := q.Run()
+	if err != nil {
+		return pagination.Page[T]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithNestedErrorCopy(err),
+		)
+	}
+
+	more := len(items) > q.pageSize
+	if more {
+		items = items[:q.pageSize]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	page := pagination.Page[T]{Items: items}
+	if len(items) == 0 {
+		// an empty page past the end goes back to the rows before it
+		if cursor.ID != 0 && !cursor.Backward {
+			page.Prev = &pagination.Cursor{ID: cursor.ID + 1, Backward: true, Resource: q.table}
+		}
+		return page, nil
+	}
+
+	// going forward the extra row is after the page, going backwards it is
+	// before it, and the cursor was read from the page on the other side
+	hasNext, hasPrev := more, cursor.ID != 0
+	if cursor.Backward {
+		hasNext, hasPrev = cursor.ID != 0, more
+	}
+	if hasNext {
+		last := reflect.ValueOf(&items[len(items)-1]).Elem().FieldByIndex(key).Int()
+		page.Next = &pagination.Cursor{ID: last, Resource: q.table}
+	}
+	if hasPrev {
+		first := reflect.ValueOf(&items[0]).Elem().FieldByIndex(key).Int()
+		page.Prev = &pagination.Cursor{ID: first, Backward: true, Resource: q.table}
+	}
+	return page, nil
+}
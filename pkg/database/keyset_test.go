@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder_RunPage(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	credentials := make([]models.Credentials, 0, 6)
+	for i := 0; i < 6; i++ {
+		credentials = append(credentials, models.Credentials{Salt: "salt", PassHash: "hash"})
+	}
+	_, err = With[models.Credentials](ctx, db).InsertMany(credentials)
+	require.NoError(t, err)
+	// soft deleted rows are not in the pages
+	_, err = With[models.Credentials](ctx, db).Where("id", Equal, 6).Delete()
+	require.NoError(t, err)
+
+	ids := func(page pagination.Page[models.Credentials]) []int32 {
+		ids := make([]int32, 0, len(page.Items))
+		for _, c := range page.Items {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+	read := func(cursor pagination.Cursor) pagination.Page[models.Credentials] {
+		page, err := With[models.Credentials](ctx, db).Where("salt", Equal, "salt").After(cursor).PageSize(2).RunPage()
+		require.NoError(t, err)
+		return page
+	}
+
+	first := read(pagination.Cursor{})
+	assert.Equal(t, []int32{1, 2}, ids(first))
+	assert.Nil(t, first.Prev)
+	require.NotNil(t, first.Next)
+
+	second := read(*first.Next)
+	assert.Equal(t, []int32{3, 4}, ids(second))
+	require.NotNil(t, second.Next)
+	require.NotNil(t, second.Prev)
+
+	last := read(*second.Next)
+	assert.Equal(t, []int32{5}, ids(last))
+	assert.Nil(t, last.Next)
+	require.NotNil(t, last.Prev)
+
+	back := read(*last.Prev)
+	assert.Equal(t, []int32{3, 4}, ids(back))
+	assert.Equal(t, second.Next, back.Next)
+	assert.Equal(t, second.Prev, back.Prev)
+
+	back = read(*back.Prev)
+	assert.Equal(t, []int32{1, 2}, ids(back))
+	assert.Nil(t, back.Prev)
+	assert.Equal(t, first.Next, back.Next)
+
+	past := read(pagination.Cursor{ID: 9, Resource: "credentials"})
+	assert.Empty(t, past.Items)
+	assert.Nil(t, past.Next)
+	require.NotNil(t, past.Prev)
+	assert.Equal(t, []int32{4, 5}, ids(read(*past.Prev)))
+
+	_, err = With[models.Credentials](ctx, db).After(pagination.Cursor{ID: 3, Resource: "users"}).PageSize(2).RunPage()
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "cursors are bound to their table: %v", err)
+
+	_, err = With[models.Credentials](ctx, db).OrderBy("salt", Asc).PageSize(2).RunPage()
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "pages are sorted by id: %v", err)
+
+	_, err = With[models.Credentials](ctx, db).RunPage()
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "pages need a size: %v", err)
+}
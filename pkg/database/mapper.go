@@ -0,0 +1,132 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/reflection"
+)
+
+// reflectionMapper scans the columns of a row into the fields of T with the
+// same name tag. The name of a reference field maps to the id of the
+// referenced struct, and the columns of a joined reference are read from
+// aliases such as "credentials.salt".
+type reflectionMapper[T any] struct {
+	m rowMapper
+}
+
+func newReflectionMapper[T any]() reflectionMapper[T] {
+	return reflectionMapper[T]{
+		m: newRowMapper(reflect.TypeOf(*new(T))),
+	}
+}
+
+func (m reflectionMapper[T]) Map(rows *sql.Rows) (T, error) {
+	var model T
+	err := m.m.scan(rows, reflect.ValueOf(&model).Elem())
+	return model, err
+}
+
+// rowMapper is the mapper of a type only known at runtime, such as the
+// elements of a preloaded slice.
+type rowMapper struct {
+	fields map[string][]int
+}
+
+func newRowMapper(t reflect.Type) rowMapper {
+	return rowMapper{
+		fields: fieldIndexes(t, ""),
+	}
+}
+
+// fieldIndexes returns the index path of the field of every column of the
+// struct type, columns of references are prefixed with the reference name.
+func fieldIndexes(t reflect.Type, prefix string) map[string][]int {
+	indexes := make(map[string][]int)
+	if t == nil || t.Kind() != reflect.Struct {
+		return indexes
+	}
+
+	references := reflection.GetFieldIndexesByTag(t, reference.String())
+	for column, i := range reflection.GetFieldIndexesByTag(t, name.String()) {
+		indexes[prefix+column] = []int{i}
+	}
+	for ref, i := range references {
+		nested := fieldIndexes(t.Field(i).Type, prefix+ref+".")
+		for column, path := range nested {
+			indexes[column] = append([]int{i}, path...)
+		}
+		// the foreign key is the id of the referenced struct
+		if id, ok := nested[prefix+ref+".id"]; ok {
+			indexes[prefix+t.Field(i).Tag.Get(name.String())] = append([]int{i}, id...)
+		}
+	}
+
+	return indexes
+}
+
+func (m rowMapper) scan(rows *sql.Rows, val reflect.Value) error {
+	const op errors.Op = "database.Map"
+
+	if val.Kind() != reflect.Struct {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("cannot map rows into %s", val.Type())),
+			errors.WithMessage("Failed to read entry"),
+		)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to read entry"),
+		)
+	}
+
+	dest := make([]any, len(columns))
+	nullable := make(map[int]reflect.Value)
+	for i, column := range columns {
+		path, ok := m.fields[column]
+		if !ok {
+			return errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("no field of %s for column %q", val.Type(), column)),
+				errors.WithMessage("Failed to read entry"),
+			)
+		}
+
+		field := val.FieldByIndex(path)
+		switch {
+		case field.Kind() == reflect.Ptr, field.Addr().Type().Implements(scannerType):
+			// pointers are set to nil and scanners handle NULL themselves
+			dest[i] = field.Addr().Interface()
+		default:
+			// other fields keep their zero value on NULL, as left joins return
+			ptr := reflect.New(reflect.PointerTo(field.Type()))
+			nullable[i] = ptr
+			dest[i] = ptr.Interface()
+		}
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to read entry"),
+		)
+	}
+
+	for i, ptr := range nullable {
+		if v := ptr.Elem(); !v.IsNil() {
+			val.FieldByIndex(m.fields[columns[i]]).Set(v.Elem())
+		}
+	}
+
+	return nil
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapperCredentials struct {
+	ID        int32     `name:"id"`
+	Salt      string    `name:"salt"`
+	CreatedAt time.Time `name:"created_at"`
+}
+
+type mapperUsers struct {
+	ID          int32             `name:"id"`
+	Username    string            `name:"username"`
+	Nickname    *string           `name:"nickname"`
+	Email       sql.NullString    `name:"email"`
+	Active      bool              `name:"active"`
+	Credentials mapperCredentials `name:"credentials_id" reference:"credentials"`
+	DeletedAt   *time.Time        `name:"deleted_at"`
+}
+
+func TestReflectionMapper_Map(t *testing.T) {
+	now := time.Date(2023, 7, 20, 12, 0, 0, 0, time.UTC)
+	nickname := "jd"
+
+	tests := []struct {
+		name    string
+		columns []string
+		values  []any
+		want    mapperUsers
+		wantErr bool
+	}{
+		{
+			name:    "Columns by name tag",
+			columns: []string{"id", "username", "nickname", "email", "active", "credentials_id", "deleted_at"},
+			values:  []any{int64(1), "jane", "jd", "jane@example.com", true, int64(4), now},
+			want: mapperUsers{
+				ID:          1,
+				Username:    "jane",
+				Nickname:    &nickname,
+				Email:       sql.NullString{String: "jane@example.com", Valid: true},
+				Active:      true,
+				Credentials: mapperCredentials{ID: 4},
+				DeletedAt:   &now,
+			},
+		},
+		{
+			name:    "Null columns",
+			columns: []string{"id", "username", "nickname", "email", "credentials_id", "deleted_at"},
+			values:  []any{int64(1), nil, nil, nil, nil, nil},
+			want:    mapperUsers{ID: 1},
+		},
+		{
+			name:    "Joined reference",
+			columns: []string{"id", "credentials.id", "credentials.salt", "credentials.created_at"},
+			values:  []any{int64(1), int64(4), "salt", now},
+			want: mapperUsers{
+				ID:          1,
+				Credentials: mapperCredentials{ID: 4, Salt: "salt", CreatedAt: now},
+			},
+		},
+		{
+			name:    "Unknown column",
+			columns: []string{"id", "password"},
+			values:  []any{int64(1), "secret"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT").WillReturnRows(
+				sqlmock.NewRows(tt.columns).AddRow(sqlValues(tt.values)...),
+			)
+
+			rows, err := db.Query("SELECT")
+			require.NoError(t, err)
+			defer rows.Close()
+			require.True(t, rows.Next())
+
+			got, err := newReflectionMapper[mapperUsers]().Map(rows)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueryBuilder_RunWithDefaultMapper(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, salt, created_at FROM mappercredentials WHERE salt = \$1`).
+		WithArgs("salt").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "salt", "created_at"}).
+			AddRow(int64(1), "salt", nil).
+			AddRow(int64(2), "salt", nil))
+
+	got, err := With[mapperCredentials](context.Background(), db).Where("salt", Equal, "salt").Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []mapperCredentials{{ID: 1, Salt: "salt"}, {ID: 2, Salt: "salt"}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func sqlValues(values []any) []driver.Value {
+	res := make([]driver.Value, 0, len(values))
+	for _, v := range values {
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/pressly/goose/v3"
+)
+
+// migrationsLock names the session lock held while migrating at startup.
+const migrationsLock = "go-auth-migrations"
+
+// Migrator applies the migrations of the dialect of the database, the
+// migrations file system holds a directory per dialect.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations fs.FS
+	out        io.Writer
+}
+
+// NewMigrator returns a migrator writing the progress and the status of the
+// migrations to out.
+func NewMigrator(db *sql.DB, migrations fs.FS, out io.Writer) *Migrator {
+	return &Migrator{
+		db:         db,
+		dialect:    DialectOf(db),
+		migrations: migrations,
+		out:        out,
+	}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	const op errors.Op = "database.Migrator.Up"
+
+	return m.run(op, "Failed to apply migrations", func(dir string) error {
+		return goose.Up(m.db, dir)
+	})
+}
+
+// Down rolls back the last applied migration.
+func (m *Migrator) Down() error {
+	const op errors.Op = "database.Migrator.Down"
+
+	return m.run(op, "Failed to roll back migration", func(dir string) error {
+		return goose.Down(m.db, dir)
+	})
+}
+
+// To applies or rolls back migrations until version is the last applied one.
+func (m *Migrator) To(version int64) error {
+	const op errors.Op = "database.Migrator.To"
+
+	return m.run(op, "Failed to migrate to version", func(dir string) error {
+		current, err := goose.EnsureDBVersion(m.db)
+		if err != nil {
+			return err
+		}
+		if version < current {
+			return goose.DownTo(m.db, dir, version)
+		}
+		return goose.UpTo(m.db, dir, version)
+	})
+}
+
+// Status writes whether every migration is applied or pending.
+func (m *Migrator) Status() error {
+	const op errors.Op = "database.Migrator.Status"
+
+	return m.run(op, "Failed to get migrations status", func(dir string) error {
+		return goose.Status(m.db, dir)
+	})
+}
+
+// UpLocked applies every pending migration holding a session lock, replicas
+// starting together wait for each other instead of racing. The lock is held
+// by a connection of its own while goose migrates on the others, so the pool
+// needs at least two connections.
+func (m *Migrator) UpLocked(ctx context.Context) error {
+	const op errors.Op = "database.Migrator.UpLocked"
+
+	lock := m.dialect.Lock()
+	if lock == "" {
+		return m.Up()
+	}
+	if max := m.db.Stats().MaxOpenConnections; max > 0 && max < 2 {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("pool of %d connection cannot hold the lock while migrating", max)),
+			errors.WithMessage("Locked migrations need a pool of at least 2 connections, raise max_open_conns or run the migrate command instead"),
+		)
+	}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to lock migrations"),
+		)
+	}
+	defer conn.Close()
+
+	locked := bind(conn, m.dialect)
+	if _, err := locked.ExecContext(ctx, lock, migrationsLock); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to lock migrations"),
+		)
+	}
+	defer locked.ExecContext(context.Background(), m.dialect.Unlock(), migrationsLock) // nolint: errcheck
+
+	return m.Up()
+}
+
+// run points goose to the migrations of the dialect, goose keeps them in
+// package state so migrators must not run concurrently.
+func (m *Migrator) run(op errors.Op, message string, fn func(dir string) error) error {
+	goose.SetBaseFS(m.migrations)
+	goose.SetLogger(log.New(m.out, "", 0))
+
+	err := goose.SetDialect(gooseDialect(m.dialect))
+	if err == nil {
+		err = fn(m.dialect.Name())
+	}
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage(message),
+		)
+	}
+	return nil
+}
+
+// gooseDialect is the name goose gives to the dialect.
+func gooseDialect(d Dialect) string {
+	if d == SQLite {
+		return "sqlite3"
+	}
+	return d.Name()
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	db, err := Open(config.Database{Driver: SQLite.Name(), Path: ":memory:"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	out := &bytes.Buffer{}
+	m := NewMigrator(db, migrations.FS, out)
+
+	require.NoError(t, m.UpLocked(context.Background()))
+	version, err := goose.GetDBVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230815120000), version)
+
+	require.NoError(t, m.Down())
+	version, err = goose.GetDBVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230810120000), version)
+
+	require.NoError(t, m.To(20230622125724))
+	version, err = goose.GetDBVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230622125724), version)
+
+	out.Reset()
+	require.NoError(t, m.Status())
+	assert.Contains(t, out.String(), "Pending                  -- 20230710120000_organizations_table.sql")
+
+	require.NoError(t, m.To(20230710120000))
+	version, err = goose.GetDBVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230710120000), version)
+}
+
+func TestMigrator_UpLockedSingleConnection(t *testing.T) {
+	// the pool is never connected, the size is refused beforehand
+	db, err := sql.Open("mysql", "user:password@tcp(127.0.0.1:1)/auth")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	err = NewMigrator(db, migrations.FS, io.Discard).UpLocked(context.Background())
+	assert.ErrorContains(t, err, "pool of 1 connection")
+}
@@ -1,10 +1,11 @@
 package database
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Pedrommb91/go-auth/pkg/reflection"
 )
@@ -18,6 +19,27 @@ func (t Tag) String() string {
 const (
 	name      Tag = "name"
 	reference Tag = "reference"
+	// foreignKey is the column of the referenced table that points back to
+	// the model, it declares one to many relations on slice fields.
+	foreignKey Tag = "foreign_key"
+	// timestamp marks the time fields managed by the query builder, created
+	// and updated are set when the row is written and deleted soft deletes
+	// the rows of the model.
+	timestamp Tag = "timestamp"
+	// version marks the column incremented by every update, updates based
+	// on a stale version are refused.
+	version Tag = "version"
+)
+
+const (
+	createdTimestamp = "created"
+	updatedTimestamp = "updated"
+	deletedTimestamp = "deleted"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
 )
 
 type modelParser[T any] struct {
@@ -43,44 +65,186 @@ func (p modelParser[T]) GetQueryColumns() string {
 	return strings.Join(p.GetColumns()[:], ", ")
 }
 
-func (p modelParser[T]) GetValues() []string {
-	values := reflection.GetAllValues(p.t)
-	params := make([]string, 0)
+// GetValues returns the value of every column, nil marks the columns that
+// are left to the database default. Booleans and floats are always bound,
+// their zero value is not a missing one.
+func (p modelParser[T]) GetValues() ([]any, error) {
+	values, err := reflection.GetAllValuesWithTag(p.t, name.String())
+	if err != nil {
+		return nil, err
+	}
+	params := make([]any, 0)
 
 	for _, v := range values {
-		t := reflect.TypeOf(v)
-		switch t.Kind() {
-		case reflect.String:
+		switch v := v.(type) {
+		case nil:
+			params = append(params, nil)
+		case string:
 			if v == "" {
-				params = append(params, "default")
+				params = append(params, nil)
 			} else {
-				params = append(params, fmt.Sprintf("'%s'", v.(string)))
+				params = append(params, v)
 			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if v.(int64) == 0 {
-				params = append(params, "default")
+		case int64:
+			if v == 0 {
+				params = append(params, nil)
 			} else {
-				values = append(values, strconv.FormatInt(v.(int64), 10))
+				params = append(params, v)
+			}
+		case uint64:
+			if v == 0 {
+				params = append(params, nil)
+			} else {
+				params = append(params, v)
+			}
+		case bool, float64, driver.Valuer:
+			params = append(params, v)
+		case time.Time:
+			if v.IsZero() {
+				params = append(params, nil)
+			} else {
+				params = append(params, v)
 			}
 		default:
-			params = append(params, "default")
+			return nil, fmt.Errorf("unsupported value %T of %s", v, p.GetTableName())
+		}
+	}
+	return params, nil
+}
+
+// GetRowValues returns GetValues aligned with GetColumns, the references
+// are nil so they are left to the column default.
+func (p modelParser[T]) GetRowValues() ([]any, error) {
+	values, err := p.GetValues()
+	if err != nil {
+		return nil, err
+	}
+	row := make([]any, 0, len(values))
+
+	t := reflect.Indirect(reflect.ValueOf(p.t)).Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get(name.String()) == "" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType && !field.Type.Implements(valuerType) {
+			row = append(row, nil)
+			continue
+		}
+		row = append(row, values[0])
+		values = values[1:]
+	}
+	return row, nil
+}
+
+// GetQueryValues returns the placeholders of the values together with the
+// arguments they are bound to.
+func (p modelParser[T]) GetQueryValues() (string, []any, error) {
+	values, err := p.GetValues()
+	if err != nil {
+		return "", nil, err
+	}
+	list, args := placeholders(values)
+	return list, args, nil
+}
+
+// placeholders numbers the values from $1, values without an argument are
+// written as default.
+func placeholders(values []any) (string, []any) {
+	p := &params{args: make([]any, 0, len(values))}
+	list := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v == nil {
+			list = append(list, "default")
+			continue
 		}
+		list = append(list, p.add(v))
 	}
-	return params
+	return strings.Join(list, ", "), p.args
 }
 
-func (p modelParser[T]) GetQueryValues() string {
-	return strings.Join(p.GetValues()[:], ", ")
+// GetFieldValues returns the value of every column that is not a
+// reference, keyed by column name.
+func (p modelParser[T]) GetFieldValues() map[string]any {
+	values := make(map[string]any)
+
+	val := reflect.Indirect(reflect.ValueOf(p.t))
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		column := field.Tag.Get(name.String())
+		if column == "" || field.Tag.Get(reference.String()) != "" {
+			continue
+		}
+		values[column] = val.Field(i).Interface()
+	}
+	return values
 }
 
 func (p modelParser[T]) HasRelations() bool {
 	return len(reflection.GetAllTagsWithName(p.t, reference.String())) > 0
 }
 
+// GetAllRelationalStructs returns the referenced structs, one to many
+// relations are not written with the model.
 func (p modelParser[T]) GetAllRelationalStructs() []any {
-	return reflection.GetAllStructsWithTagName(p.t, reference.String())
+	structs := make([]any, 0)
+	for _, v := range reflection.GetAllStructsWithTagName(p.t, reference.String()) {
+		if reflect.TypeOf(v).Kind() == reflect.Struct {
+			structs = append(structs, v)
+		}
+	}
+	return structs
 }
 
 func (p modelParser[T]) GetTagNameByTypeName(field string) string {
 	return reflection.GetTagByTypeName(p.t, field, name.String())
 }
+
+// GetTimestampColumn returns the column of the timestamp of the kind, empty
+// when the model has none.
+func (p modelParser[T]) GetTimestampColumn(kind string) string {
+	t := reflect.Indirect(reflect.ValueOf(p.t)).Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type == timeType && field.Tag.Get(timestamp.String()) == kind {
+			return field.Tag.Get(name.String())
+		}
+	}
+	return ""
+}
+
+// GetVersionColumn returns the version column, empty when the model has
+// none.
+func (p modelParser[T]) GetVersionColumn() string {
+	t := reflect.Indirect(reflect.ValueOf(p.t)).Type()
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Tag.Get(version.String()) != "" {
+			return field.Tag.Get(name.String())
+		}
+	}
+	return ""
+}
+
+// stamp returns a copy of the struct model with its zero timestamps of the
+// kinds set to now.
+func stamp(model any, now time.Time, kinds ...string) any {
+	if reflect.TypeOf(model).Kind() != reflect.Struct {
+		return model
+	}
+
+	v := reflect.New(reflect.TypeOf(model)).Elem()
+	v.Set(reflect.ValueOf(model))
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Type() != timeType || !field.IsZero() {
+			continue
+		}
+		for _, kind := range kinds {
+			if v.Type().Field(i).Tag.Get(timestamp.String()) == kind {
+				field.Set(reflect.ValueOf(now))
+			}
+		}
+	}
+	return v.Interface()
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+)
+
+func TestModelParser_GetQueryValues(t *testing.T) {
+	now := time.Date(2023, 7, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		model      any
+		wantValues string
+		wantArgs   []any
+	}{
+		{
+			name: "Values are bound as arguments",
+			model: models.Credentials{
+				Salt:      "salt",
+				PassHash:  "it's'); DROP TABLE users; --",
+				CreatedAt: now,
+			},
+			wantValues: "default, $1, $2, $3, default, default",
+			wantArgs:   []any{"salt", "it's'); DROP TABLE users; --", now},
+		},
+		{
+			name: "Zero values are left to the database",
+			model: models.Credentials{
+				ID:   3,
+				Salt: "salt",
+			},
+			wantValues: "$1, $2, default, default, default, default",
+			wantArgs:   []any{int64(3), "salt"},
+		},
+		{
+			name: "False booleans are bound",
+			model: models.Users{
+				OrganizationID: 2,
+				Username:       "jane",
+				Active:         false,
+			},
+			wantValues: "default, $1, $2, default, default, default, default, default, $3, default, default, default, default",
+			wantArgs:   []any{int64(2), "jane", false},
+		},
+		{
+			name: "Floats, unsigned integers and valuers are bound",
+			model: struct {
+				ID       uint                        `name:"id"`
+				Score    float64                     `name:"score"`
+				Settings models.OrganizationSettings `name:"settings"`
+			}{ID: 4, Score: 0.5},
+			wantValues: "$1, $2, $3",
+			wantArgs:   []any{uint64(4), 0.5, models.OrganizationSettings{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValues, gotArgs, err := NewModelParser[any](tt.model).GetQueryValues()
+			if err != nil {
+				t.Fatalf("GetQueryValues() error = %v", err)
+			}
+			if gotValues != tt.wantValues {
+				t.Errorf("GetQueryValues() values = %v, want %v", gotValues, tt.wantValues)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("GetQueryValues() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestModelParser_GetValues_UnsupportedKind(t *testing.T) {
+	model := struct {
+		ID   int32    `name:"id"`
+		Tags []string `name:"tags"`
+	}{ID: 1, Tags: []string{"a"}}
+
+	if _, err := NewModelParser[any](model).GetValues(); err == nil {
+		t.Errorf("GetValues() expected an error for a slice column")
+	}
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// relation is a reference field of the model loaded with it. A struct is
+// joined on the id its name column points to, a slice is read with a second
+// query on the foreign key of its elements.
+type relation struct {
+	field      int
+	table      string
+	alias      string
+	column     string
+	foreignKey string
+	columns    []string
+	elem       reflect.Type
+	many       bool
+}
+
+// Preload loads the reference field with the model, for example
+// Preload("Credentials") on users. Slice fields need a foreign_key tag with
+// the column of the referenced table that holds the id of the model.
+func (q *queryBuilder[T]) Preload(field string) *queryBuilder[T] {
+	t := reflect.TypeOf(*new(T))
+	if t == nil || t.Kind() != reflect.Struct {
+		q.fail(fmt.Errorf("cannot preload %s on %T", field, *new(T)))
+		return q
+	}
+
+	f, ok := t.FieldByName(field)
+	if !ok || f.Tag.Get(reference.String()) == "" {
+		q.fail(fmt.Errorf("%s is not a reference of %s", field, t.Name()))
+		return q
+	}
+
+	rel := relation{
+		field: f.Index[0],
+		alias: f.Tag.Get(reference.String()),
+		elem:  f.Type,
+	}
+	switch {
+	case f.Type.Kind() == reflect.Struct && f.Tag.Get(name.String()) != "":
+		rel.column = f.Tag.Get(name.String())
+	case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct && f.Tag.Get(foreignKey.String()) != "":
+		rel.many = true
+		rel.elem = f.Type.Elem()
+		rel.foreignKey = f.Tag.Get(foreignKey.String())
+	default:
+		q.fail(fmt.Errorf("unsupported reference %s of %s", field, t.Name()))
+		return q
+	}
+
+	model := reflect.Zero(rel.elem).Interface()
+	rel.table = NewModelParser[any](model).GetTableName()
+	rel.columns = NewModelParser[any](model).GetColumns()
+
+	q.preloads = append(q.preloads, rel)
+	return q
+}
+
+// preloadMany reads the elements of the relation of every model in a single
+// query and appends them to the slice of their model.
+func (q *queryBuilder[T]) preloadMany(data []T, rel relation) error {
+	const op errors.Op = "database.preloadMany"
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	id, ok := fieldIndexes(reflect.TypeOf(*new(T)), "")["id"]
+	if !ok {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("%T has no id to preload %s", *new(T), rel.table)),
+			errors.WithMessage("Failed to preload entries"),
+		)
+	}
+	mapper := newRowMapper(rel.elem)
+	fk, ok := mapper.fields[rel.foreignKey]
+	if !ok {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("%s has no column %s", rel.elem.Name(), rel.foreignKey)),
+			errors.WithMessage("Failed to preload entries"),
+		)
+	}
+
+	ids := make([]any, 0, len(data))
+	for i := range data {
+		ids = append(ids, reflect.ValueOf(&data[i]).Elem().FieldByIndex(id).Interface())
+	}
+
+	p := q.newParams()
+	list, _ := p.addList(ids)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)",
+		strings.Join(rel.columns, ", "), rel.table, rel.foreignKey, list)
+	child := reflect.New(rel.elem).Elem().Interface()
+	if deleted := NewModelParser[any](child).GetTimestampColumn(deletedTimestamp); deleted != "" && !q.unscoped {
+		query += " AND " + deleted + " IS NULL"
+	}
+	if _, ok := mapper.fields["id"]; ok {
+		query += " ORDER BY id"
+	}
+
+	rows, err := q.readConn().QueryContext(q.ctx, query, p.args...)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to preload entries"),
+		)
+	}
+	defer rows.Close()
+
+	children := make(map[any]reflect.Value)
+	for rows.Next() {
+		child := reflect.New(rel.elem).Elem()
+		if err := mapper.scan(rows, child); err != nil {
+			return errors.Build(
+				errors.WithOp(op),
+				errors.WithNestedErrorCopy(err),
+			)
+		}
+
+		key := keyOf(child.FieldByIndex(fk))
+		slice, ok := children[key]
+		if !ok {
+			slice = reflect.MakeSlice(reflect.SliceOf(rel.elem), 0, 1)
+		}
+		children[key] = reflect.Append(slice, child)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to preload entries"),
+		)
+	}
+
+	for i := range data {
+		model := reflect.ValueOf(&data[i]).Elem()
+		slice, ok := children[keyOf(model.FieldByIndex(id))]
+		if !ok {
+			slice = reflect.MakeSlice(reflect.SliceOf(rel.elem), 0, 0)
+		}
+		model.Field(rel.field).Set(slice)
+	}
+
+	return nil
+}
+
+// keyOf compares ids of different integer types by their value.
+func keyOf(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type preloadSessions struct {
+	ID     int32  `name:"id"`
+	UserID int64  `name:"user_id"`
+	Agent  string `name:"agent"`
+}
+
+type preloadUsers struct {
+	ID          int32             `name:"id"`
+	Username    string            `name:"username"`
+	Credentials mapperCredentials `name:"credentials_id" reference:"credentials"`
+	Sessions    []preloadSessions `reference:"sessions" foreign_key:"user_id"`
+}
+
+func TestQueryBuilder_Preload(t *testing.T) {
+	now := time.Date(2023, 7, 20, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT preloadusers.id, preloadusers.username, preloadusers.credentials_id, `+
+		`credentials.id AS "credentials.id", credentials.salt AS "credentials.salt", credentials.created_at AS "credentials.created_at" `+
+		`FROM preloadusers LEFT JOIN mappercredentials AS credentials ON credentials.id = preloadusers.credentials_id `+
+		`WHERE preloadusers.id IN ($1, $2) ORDER BY preloadusers.id ASC`)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "credentials_id", "credentials.id", "credentials.salt", "credentials.created_at"}).
+			AddRow(int64(1), "jane", int64(4), int64(4), "salt", now).
+			AddRow(int64(2), "john", nil, nil, nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, agent FROM preloadsessions WHERE user_id IN ($1, $2) ORDER BY id`)).
+		WithArgs(int32(1), int32(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "agent"}).
+			AddRow(int64(10), int64(1), "firefox").
+			AddRow(int64(11), int64(1), "curl"))
+
+	got, err := With[preloadUsers](context.Background(), db).
+		Preload("Credentials").
+		Preload("Sessions").
+		Where("id", In, []int{1, 2}).
+		OrderBy("id", Asc).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []preloadUsers{
+		{
+			ID:          1,
+			Username:    "jane",
+			Credentials: mapperCredentials{ID: 4, Salt: "salt", CreatedAt: now},
+			Sessions: []preloadSessions{
+				{ID: 10, UserID: 1, Agent: "firefox"},
+				{ID: 11, UserID: 1, Agent: "curl"},
+			},
+		},
+		{
+			ID:       2,
+			Username: "john",
+			Sessions: []preloadSessions{},
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_PreloadUnknownField(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = With[preloadUsers](context.Background(), db).Preload("Username").Run()
+	assert.Error(t, err)
+}
@@ -3,39 +3,156 @@ package database
 import (
 	"context"
 	"database/sql"
-	nerrors "errors"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Pedrommb91/go-auth/pkg/clock"
 	"github.com/Pedrommb91/go-auth/pkg/errors"
-	"github.com/lib/pq"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/Pedrommb91/go-auth/pkg/slices"
 	"github.com/rs/zerolog"
 )
 
 type queryBuilder[T any] struct {
+	ctx      context.Context
 	db       *sql.DB
-	queryStr string
+	source   *DB
+	dialect  Dialect
+	table    string
+	columns  []string
+	where    *Conditions
+	orderBy  []order
+	limit    int
+	offset   int
+	preloads []relation
 	mapper   QueryMapper[T]
+	clock    clock.Clock
+	unscoped bool
+	// version is the version the rows of an update must have
+	version any
+	// after and pageSize select the page read by RunPage
+	after    *pagination.Cursor
+	pageSize int
+	hooks    []Hook
+	err      error
+}
+
+type order struct {
+	column    string
+	direction string
 }
 
 type QueryMapper[T any] interface {
 	Map(rows *sql.Rows) (T, error)
 }
 
-func With[T any](db *sql.DB) *queryBuilder[T] {
+const (
+	Asc  = "ASC"
+	Desc = "DESC"
+)
+
+// With starts a query on the table of T, columns are the name tags of T.
+// Every statement of the query runs with ctx, so cancelling it aborts the
+// statement in the database.
+func With[T any](ctx context.Context, db *sql.DB) *queryBuilder[T] {
+	var model T
+	parser := NewModelParser[T](model)
+
 	b := &queryBuilder[T]{}
+	b.ctx = ctx
 	b.db = db
+	b.dialect = DialectOf(db)
+	b.table = parser.GetTableName()
+	b.where = newConditions(parser.GetColumns())
+	b.clock = &clock.RealClock{}
+	return b
+}
+
+// On starts a query on the table of T like With, the reads run on the
+// replicas of db and the writes on its primary.
+func On[T any](ctx context.Context, db *DB) *queryBuilder[T] {
+	b := With[T](ctx, db.Primary())
+	b.source = db
 	return b
 }
 
+// begin starts a transaction on the database of the writes.
+func (q *queryBuilder[T]) begin() (*Tx, error) {
+	if q.source != nil {
+		return q.source.Begin(q.ctx, nil)
+	}
+	return Begin(q.ctx, q.db, nil)
+}
+
+// conn returns the transaction of the query context, or the database of the
+// writes.
+func (q *queryBuilder[T]) conn() Querier {
+	if q.source != nil {
+		return q.hooked(q.source.WriteConn(q.ctx))
+	}
+	return q.hooked(Conn(q.ctx, q.db))
+}
+
+// readConn returns the transaction of the query context, or the database of
+// the reads.
+func (q *queryBuilder[T]) readConn() Querier {
+	if q.source != nil {
+		return q.hooked(q.source.ReadConn(q.ctx))
+	}
+	return q.hooked(Conn(q.ctx, q.db))
+}
+
+func (q *queryBuilder[T]) newParams() *params {
+	return &params{dialect: q.dialect}
+}
+
+// WithClock replaces the clock of the timestamps written by the query.
+func (q *queryBuilder[T]) WithClock(c clock.Clock) *queryBuilder[T] {
+	q.clock = c
+	return q
+}
+
+// Unscoped includes the soft deleted rows in the query, and makes Delete
+// remove the rows instead of soft deleting them.
+func (q *queryBuilder[T]) Unscoped() *queryBuilder[T] {
+	q.unscoped = true
+	return q
+}
+
+func (q *queryBuilder[T]) now() time.Time {
+	return q.clock.Now().UTC()
+}
+
+// timestampColumn returns the column of the timestamp of the kind of T.
+func (q *queryBuilder[T]) timestampColumn(kind string) string {
+	return NewModelParser[T](*new(T)).GetTimestampColumn(kind)
+}
+
+// versionColumn returns the version column of T, empty when T has none.
+func (q *queryBuilder[T]) versionColumn() string {
+	return NewModelParser[T](*new(T)).GetVersionColumn()
+}
+
+// deletedColumn returns the column soft deleting the rows, empty when T has
+// none or the query is unscoped.
+func (q *queryBuilder[T]) deletedColumn() string {
+	if q.unscoped {
+		return ""
+	}
+	return q.timestampColumn(deletedTimestamp)
+}
+
+// Insert writes the model and its references, the zero created and updated
+// timestamps are set to the time of the clock.
 func (q *queryBuilder[T]) Insert(model T) (int64, error) {
 	const op errors.Op = "database.Create"
 
+	model = stamp(model, q.now(), createdTimestamp, updatedTimestamp).(T)
 	parser := NewModelParser[T](model)
 	if parser.HasRelations() {
-		tx, err := q.db.BeginTx(context.TODO(), nil)
+		tx, err := q.begin()
 		if err != nil {
 			return 0, errors.Build(
 				errors.WithOp(op),
@@ -69,16 +186,63 @@ func (q *queryBuilder[T]) Insert(model T) (int64, error) {
 	}
 }
 
-func (q *queryBuilder[T]) Select(field string) *queryBuilder[T] {
-	q.queryStr += " SELECT " + field
+// Select sets the columns read by Run, all the columns of T by default.
+func (q *queryBuilder[T]) Select(columns ...string) *queryBuilder[T] {
+	q.columns = append(q.columns, columns...)
 	return q
 }
 
 func (q *queryBuilder[T]) From(table string) *queryBuilder[T] {
-	q.queryStr += " FROM " + table
+	q.table = table
 	return q
 }
 
+func (q *queryBuilder[T]) Where(column, operator string, value any) *queryBuilder[T] {
+	q.where.Where(column, operator, value)
+	return q
+}
+
+func (q *queryBuilder[T]) And(group func(c *Conditions)) *queryBuilder[T] {
+	q.where.And(group)
+	return q
+}
+
+func (q *queryBuilder[T]) Or(group func(c *Conditions)) *queryBuilder[T] {
+	q.where.Or(group)
+	return q
+}
+
+func (q *queryBuilder[T]) OrderBy(column, direction string) *queryBuilder[T] {
+	direction = strings.ToUpper(direction)
+	switch {
+	case !q.where.columns[column]:
+		q.fail(fmt.Errorf("unknown column %q", column))
+	case direction != Asc && direction != Desc:
+		q.fail(fmt.Errorf("unknown direction %q", direction))
+	}
+
+	q.orderBy = append(q.orderBy, order{column: column, direction: direction})
+	return q
+}
+
+func (q *queryBuilder[T]) Limit(limit int) *queryBuilder[T] {
+	if limit < 0 {
+		q.fail(fmt.Errorf("negative limit %d", limit))
+	}
+	q.limit = limit
+	return q
+}
+
+func (q *queryBuilder[T]) Offset(offset int) *queryBuilder[T] {
+	if offset < 0 {
+		q.fail(fmt.Errorf("negative offset %d", offset))
+	}
+	q.offset = offset
+	return q
+}
+
+// WithMapper replaces the default mapper, which scans columns into the
+// fields with the same name tag.
 func (q *queryBuilder[T]) WithMapper(mapper QueryMapper[T]) *queryBuilder[T] {
 	q.mapper = mapper
 	return q
@@ -86,24 +250,24 @@ func (q *queryBuilder[T]) WithMapper(mapper QueryMapper[T]) *queryBuilder[T] {
 
 func (q *queryBuilder[T]) Run() ([]T, error) {
 	const op errors.Op = "database.Run"
-	if !q.queryIsValid() {
-		return nil, errors.Build(
-			errors.WithOp(op),
-			errors.WithError(fmt.Errorf("query is not valid")),
-			errors.WithMessage("Query to database invalid"),
-			errors.KindBadRequest(),
-			errors.WithSeverity(zerolog.WarnLevel),
-		)
+
+	if q.mapper == nil {
+		q.mapper = newReflectionMapper[T]()
+	}
+	query, args, err := q.selectSQL()
+	if err != nil {
+		return nil, invalidQuery(op, err)
 	}
 
-	rows, err := q.db.Query(q.queryStr)
+	rows, err := q.readConn().QueryContext(q.ctx, query, args...)
 	if err != nil {
 		return nil, errors.Build(
 			errors.WithOp(op),
-			errors.WithMessage("Failed to get users from database"),
+			errors.WithMessage("Failed to get entries from database"),
 			errors.WithError(err),
 		)
 	}
+	defer rows.Close()
 
 	data := make([]T, 0)
 	for rows.Next() {
@@ -116,26 +280,335 @@ func (q *queryBuilder[T]) Run() ([]T, error) {
 		}
 		data = append(data, element)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithMessage("Failed to get entries from database"),
+			errors.WithError(err),
+		)
+	}
+
+	for _, rel := range q.preloads {
+		if !rel.many {
+			continue
+		}
+		if err := q.preloadMany(data, rel); err != nil {
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to get entries from database"),
+			)
+		}
+	}
+
 	return data, nil
 }
 
-func (q *queryBuilder[T]) queryIsValid() bool {
-	return q.db != nil && q.queryStr != "" && q.mapper != nil
+// Count returns the number of rows matching the conditions, ignoring the
+// order, limit and offset.
+func (q *queryBuilder[T]) Count() (int64, error) {
+	const op errors.Op = "database.Count"
+
+	p := q.newParams()
+	where, err := q.whereSQL(p, "")
+	if err != nil {
+		return 0, invalidQuery(op, err)
+	}
+
+	var count int64
+	err = q.readConn().QueryRowContext(q.ctx, "SELECT COUNT(*) FROM "+q.table+where, p.args...).Scan(&count)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithMessage("Failed to count entries"),
+			errors.WithError(err),
+		)
+	}
+
+	return count, nil
+}
+
+// Update writes the columns of the model to the rows matching the
+// conditions. Without columns only the non zero fields are written, like
+// Insert leaves zero fields to their default. The updated timestamp is
+// always set to the time of the clock.
+//
+// The version of versioned models is incremented. When the model has a non
+// zero version only the rows still at that version are updated, and a
+// conflict error is returned when none is.
+func (q *queryBuilder[T]) Update(model T, columns ...string) (int64, error) {
+	const op errors.Op = "database.Update"
+
+	updated := q.timestampColumn(updatedTimestamp)
+	versioned := q.versionColumn()
+	values := NewModelParser[T](model).GetFieldValues()
+	if len(columns) == 0 {
+		for _, column := range NewModelParser[T](model).GetColumns() {
+			if column == versioned {
+				continue
+			}
+			if v, ok := values[column]; ok && !reflect.ValueOf(v).IsZero() {
+				columns = append(columns, column)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		q.fail(fmt.Errorf("nothing to update"))
+	}
+	if updated != "" {
+		values[updated] = q.now()
+		if _, ok := slices.Contains(columns, func(c string) bool { return c == updated }); !ok {
+			columns = append(columns, updated)
+		}
+	}
+
+	p := q.newParams()
+	set := make([]string, 0, len(columns))
+	for _, column := range columns {
+		v, ok := values[column]
+		if !ok {
+			q.fail(fmt.Errorf("unknown column %q", column))
+			break
+		}
+		if column == versioned {
+			continue
+		}
+		set = append(set, column+" = "+p.add(v))
+	}
+	if versioned != "" {
+		set = append(set, versioned+" = "+versioned+" + 1")
+		if v := values[versioned]; !reflect.ValueOf(v).IsZero() {
+			q.version = v
+		}
+	}
+
+	where, err := q.requiredWhereSQL(p)
+	if err != nil {
+		return 0, invalidQuery(op, err)
+	}
+
+	affected, err := q.exec(op, "UPDATE "+q.table+" SET "+strings.Join(set, ", ")+where, p.args)
+	if err == nil && affected == 0 && q.version != nil {
+		return 0, VersionConflict(op)
+	}
+	return affected, err
+}
+
+// Delete removes the rows matching the conditions. The rows of models with
+// a deleted timestamp are soft deleted instead, unless the query is
+// unscoped.
+func (q *queryBuilder[T]) Delete() (int64, error) {
+	const op errors.Op = "database.Delete"
+
+	p := q.newParams()
+	if deleted := q.deletedColumn(); deleted != "" {
+		set := deleted + " = " + p.add(q.now())
+		where, err := q.requiredWhereSQL(p)
+		if err != nil {
+			return 0, invalidQuery(op, err)
+		}
+		return q.exec(op, "UPDATE "+q.table+" SET "+set+where, p.args)
+	}
+
+	where, err := q.requiredWhereSQL(p)
+	if err != nil {
+		return 0, invalidQuery(op, err)
+	}
+
+	return q.exec(op, "DELETE FROM "+q.table+where, p.args)
+}
+
+func (q *queryBuilder[T]) exec(op errors.Op, query string, args []any) (int64, error) {
+	res, err := q.conn().ExecContext(q.ctx, query, args...)
+	if err != nil {
+		return 0, constraintError(op, err, "write entries")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithMessage("Failed to write entries"),
+			errors.WithError(err),
+		)
+	}
+
+	return affected, nil
+}
+
+func (q *queryBuilder[T]) selectSQL() (string, []any, error) {
+	columns := append([]string{}, q.columns...)
+	if len(columns) == 0 {
+		columns = NewModelParser[T](*new(T)).GetColumns()
+	}
+
+	// joined tables share column names, so every column is qualified
+	var qualifier, joins string
+	for _, rel := range q.preloads {
+		if rel.many {
+			continue
+		}
+		if qualifier == "" {
+			qualifier = q.table
+			for i, column := range columns {
+				columns[i] = qualify(q.table, column)
+			}
+		}
+		joins += fmt.Sprintf(" LEFT JOIN %s AS %s ON %s.id = %s",
+			rel.table, rel.alias, rel.alias, qualify(q.table, rel.column))
+		for _, column := range rel.columns {
+			columns = append(columns, fmt.Sprintf(`%s.%s AS "%s.%s"`, rel.alias, column, rel.alias, column))
+		}
+	}
+
+	p := q.newParams()
+	where, err := q.whereSQL(p, qualifier)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM " + q.table + joins + where
+	if len(q.orderBy) > 0 {
+		orders := make([]string, 0, len(q.orderBy))
+		for _, o := range q.orderBy {
+			orders = append(orders, qualify(qualifier, o.column)+" "+o.direction)
+		}
+		query += " ORDER BY " + strings.Join(orders, ", ")
+	}
+	if q.limit > 0 {
+		query += " LIMIT " + p.add(q.limit)
+	}
+	if q.offset > 0 {
+		query += " OFFSET " + p.add(q.offset)
+	}
+
+	return query, p.args, nil
+}
+
+func (q *queryBuilder[T]) whereSQL(p *params, qualifier string) (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	if q.db == nil {
+		return "", fmt.Errorf("missing database")
+	}
+
+	var where string
+	if !q.where.empty() {
+		var err error
+		if where, err = q.where.sql(p, qualifier); err != nil {
+			return "", err
+		}
+	}
+
+	// soft deleted rows are left out unless the query is unscoped, the rows
+	// of an update must still be at the version it was based on and pages
+	// start at their cursor
+	scopes := make([]string, 0, 3)
+	if deleted := q.deletedColumn(); deleted != "" {
+		scopes = append(scopes, qualify(qualifier, deleted)+" IS NULL")
+	}
+	if q.version != nil {
+		scopes = append(scopes, qualify(qualifier, q.versionColumn())+" = "+p.add(q.version))
+	}
+	if q.after != nil && q.after.ID != 0 {
+		operator := Greater
+		if q.after.Backward {
+			operator = Less
+		}
+		scopes = append(scopes, qualify(qualifier, keysetColumn)+" "+operator+" "+p.add(q.after.ID))
+	}
+
+	switch {
+	case len(scopes) == 0 && where == "":
+		return "", nil
+	case len(scopes) == 0:
+		return " WHERE " + where, nil
+	case where == "":
+		return " WHERE " + strings.Join(scopes, " AND "), nil
+	}
+	return " WHERE (" + where + ") AND " + strings.Join(scopes, " AND "), nil
+}
+
+// requiredWhereSQL refuses to write statements that would change every row
+// of the table.
+func (q *queryBuilder[T]) requiredWhereSQL(p *params) (string, error) {
+	if q.where.empty() {
+		q.fail(fmt.Errorf("missing conditions"))
+	}
+	return q.whereSQL(p, "")
+}
+
+func (q *queryBuilder[T]) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// ErrVersionConflict is the error of the updates based on a stale version.
+var ErrVersionConflict = fmt.Errorf("version conflict")
+
+// VersionConflict reports an update based on a stale version of the entry,
+// it was changed or deleted since it was read.
+func VersionConflict(op errors.Op) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(ErrVersionConflict),
+		errors.WithMessage("Entry was modified by another request"),
+		errors.KindConflict(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+func invalidQuery(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Query to database invalid"),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
+
+// invalidModel reports the models whose fields cannot be written, action
+// completes the "failed to" message.
+func invalidModel(op errors.Op, err error, action string) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Failed to "+action),
+	)
+}
+
+// constraintError reports integrity constraint violations as bad requests,
+// action completes the "failed to" message.
+func constraintError(op errors.Op, err error, action string) error {
+	if IsConstraintViolation(err) {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithMessage("Constrain violation: failed to "+action),
+			errors.WithError(err),
+			errors.WithSeverity(zerolog.WarnLevel),
+			errors.KindBadRequest(),
+		)
+	}
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithMessage("Failed to "+action),
+		errors.WithError(err),
+	)
 }
 
 func (q *queryBuilder[T]) insertWithoutRelations(model any) (int64, error) {
 	const op errors.Op = "database.createWithoutRelations"
 
 	parser := NewModelParser[T](model)
+	values, err := parser.GetValues()
+	if err != nil {
+		return 0, invalidModel(op, err, "insert entry")
+	}
 
-	sqlStatement := fmt.Sprintf("INSERT INTO %s(%s) VALUES (%s) RETURNING id;",
-		parser.GetTableName(),
-		parser.GetQueryColumns(),
-		parser.GetQueryValues(),
-	)
-
-	var id int64
-	err := q.db.QueryRowContext(context.TODO(), sqlStatement).Scan(&id)
+	id, err := q.insertRow(q.conn(), parser.GetTableName(), parser.GetColumns(), values)
 	if err != nil {
 		return 0, errors.Build(
 			errors.WithOp(op),
@@ -147,11 +620,54 @@ func (q *queryBuilder[T]) insertWithoutRelations(model any) (int64, error) {
 	return id, nil
 }
 
-func (q *queryBuilder[T]) insertWithRelations(tx *sql.Tx, model any) (int64, error) {
+// insertRow inserts the values and returns the id of the row. Nil values are
+// left to the column default.
+func (q *queryBuilder[T]) insertRow(conn Querier, table string, columns []string, values []any) (int64, error) {
+	p := q.newParams()
+	query := q.insertSQL(p, table, columns, [][]any{values})
+	return InsertID(q.ctx, conn, q.dialect, query, p.args...)
+}
+
+// insertSQL writes the INSERT of the rows, nil values are left to the column
+// default. Without DEFAULT in VALUES the columns nil in the first row are
+// omitted, so every row must leave the same columns nil.
+func (q *queryBuilder[T]) insertSQL(p *params, table string, columns []string, rows [][]any) string {
+	names := make([]string, 0, len(columns))
+	written := make([]bool, len(columns))
+	for i, column := range columns {
+		written[i] = rows[0][i] != nil || q.dialect.SupportsDefault()
+		if written[i] {
+			names = append(names, column)
+		}
+	}
+	if len(names) == 0 {
+		return "INSERT INTO " + table + " DEFAULT VALUES"
+	}
+
+	tuples := make([]string, 0, len(rows))
+	for _, row := range rows {
+		list := make([]string, 0, len(names))
+		for i, v := range row {
+			switch {
+			case !written[i]:
+			case v == nil:
+				list = append(list, "default")
+			default:
+				list = append(list, p.add(v))
+			}
+		}
+		tuples = append(tuples, "("+strings.Join(list, ", ")+")")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s", table, strings.Join(names, ", "), strings.Join(tuples, ", "))
+}
+
+func (q *queryBuilder[T]) insertWithRelations(tx *Tx, model any) (int64, error) {
 	const op errors.Op = "database.createWithRelations"
 
+	model = stamp(model, q.now(), createdTimestamp, updatedTimestamp)
 	parser := NewModelParser[T](model)
-	var references map[string]string = make(map[string]string)
+	var references map[string]int64 = make(map[string]int64)
 	for _, v := range parser.GetAllRelationalStructs() {
 		id, err := q.insertWithRelations(tx, v)
 		if err != nil {
@@ -162,7 +678,7 @@ func (q *queryBuilder[T]) insertWithRelations(tx *sql.Tx, model any) (int64, err
 			)
 		}
 		field := reflect.TypeOf(v).Name()
-		references[parser.GetTagNameByTypeName(field)] = strconv.FormatInt(id, 10)
+		references[parser.GetTagNameByTypeName(field)] = id
 	}
 
 	id, err := q.insertWithParentRelations(tx, model, references)
@@ -177,46 +693,25 @@ func (q *queryBuilder[T]) insertWithRelations(tx *sql.Tx, model any) (int64, err
 	return id, nil
 }
 
-func (q *queryBuilder[T]) insertWithParentRelations(tx *sql.Tx, model any, references map[string]string) (int64, error) {
+func (q *queryBuilder[T]) insertWithParentRelations(tx *Tx, model any, references map[string]int64) (int64, error) {
 	const op errors.Op = "database.createWithParentRelations"
 
 	parser := NewModelParser[T](model)
 	columns := parser.GetColumns()
-	values := parser.GetValues()
+	values, err := parser.GetValues()
+	if err != nil {
+		return 0, invalidModel(op, err, "insert entry")
+	}
 	for i, v := range columns {
-		if references[v] != "" {
+		if id, ok := references[v]; ok {
 			values = append(values[:i+1], values[i:]...) // index < len(a)
-			values[i] = references[v]
+			values[i] = id
 		}
 	}
 
-	sqlStatement := fmt.Sprintf("INSERT INTO %s(%s) VALUES (%s) RETURNING id;",
-		parser.GetTableName(),
-		strings.Join(columns[:], ", "),
-		strings.Join(values[:], ", "),
-	)
-
-	var id int64
-	err := tx.QueryRowContext(context.TODO(), sqlStatement).Scan(&id)
+	id, err := q.insertRow(q.hooked(tx), parser.GetTableName(), columns, values)
 	if err != nil {
-		var pqErr *pq.Error
-		if nerrors.As(err, &pqErr) {
-			// Class 23 - Integrity Constraint Violation
-			if strings.HasPrefix(string(pqErr.Code), "23") {
-				return 0, errors.Build(
-					errors.WithOp(op),
-					errors.WithMessage("Constrain violation: failed to insert entry"),
-					errors.WithError(err),
-					errors.WithSeverity(zerolog.WarnLevel),
-					errors.KindBadRequest(),
-				)
-			}
-		}
-		return 0, errors.Build(
-			errors.WithOp(op),
-			errors.WithMessage("Failed to insert entry"),
-			errors.WithError(err),
-		)
+		return 0, constraintError(op, err, "insert entry")
 	}
 
 	return id, nil
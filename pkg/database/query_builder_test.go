@@ -1,14 +1,18 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/mocks"
 	"github.com/Pedrommb91/go-auth/pkg/errors"
 	"github.com/go-faker/faker/v4"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func (s *DatabaseTestSuite) TestCreateWithRelation() {
@@ -81,7 +85,7 @@ func (s *DatabaseTestSuite) TestCreateWithRelation() {
 	}
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			got, err := With[models.Users](s.db).Insert(tt.agrs.user)
+			got, err := With[models.Users](context.Background(), s.db).Insert(tt.agrs.user)
 			if !errors.Equal(errors.GetFirstNestedError(err), tt.wantErr) {
 				s.T().Errorf("Insert() = %v, want %v", err, tt.wantErr)
 			}
@@ -115,7 +119,7 @@ func (s *DatabaseTestSuite) TestCreateWithoutRelation() {
 	}
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			got, err := With[models.Credentials](s.db).Insert(tt.agrs.credentials)
+			got, err := With[models.Credentials](context.Background(), s.db).Insert(tt.agrs.credentials)
 			if !errors.Equal(errors.GetFirstNestedError(err), tt.wantErr) {
 				s.T().Errorf("Insert() = %v, want %v", err, tt.wantErr)
 			}
@@ -125,3 +129,120 @@ func (s *DatabaseTestSuite) TestCreateWithoutRelation() {
 		})
 	}
 }
+
+func (s *DatabaseTestSuite) TestUpdateDeleteCount() {
+	salt := faker.Password()
+	id, err := With[models.Credentials](context.Background(), s.db).Insert(models.Credentials{
+		Salt:     salt,
+		PassHash: faker.Password(),
+	})
+	s.Require().NoError(err)
+
+	count, err := With[models.Credentials](context.Background(), s.db).Where("salt", Equal, salt).Count()
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+
+	updated, err := With[models.Credentials](context.Background(), s.db).
+		Where("id", Equal, id).
+		Update(models.Credentials{PassHash: "it's updated"}, "passhash")
+	s.Require().NoError(err)
+	s.Equal(int64(1), updated)
+
+	count, err = With[models.Credentials](context.Background(), s.db).
+		Where("id", Equal, id).
+		And(func(c *Conditions) {
+			c.Where("passhash", Equal, "it's updated")
+		}).
+		Count()
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+
+	deleted, err := With[models.Credentials](context.Background(), s.db).Where("id", Equal, id).Delete()
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+}
+
+func TestQueryBuilder_Timestamps(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	created := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	deleted := updated.Add(time.Hour)
+
+	c := mocks.NewClock(t)
+	c.On("Now").Return(created).Once()
+	id, err := With[models.Credentials](ctx, db).WithClock(c).Insert(models.Credentials{Salt: "salt", PassHash: "hash"})
+	require.NoError(t, err)
+
+	c.On("Now").Return(updated).Once()
+	_, err = With[models.Credentials](ctx, db).WithClock(c).Where("id", Equal, id).Update(models.Credentials{PassHash: "new"})
+	require.NoError(t, err)
+
+	got, err := With[models.Credentials](ctx, db).Where("id", Equal, id).Run()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.True(t, created.Equal(got[0].CreatedAt), "created at %v", got[0].CreatedAt)
+	assert.True(t, updated.Equal(got[0].UpdatedAt), "updated at %v", got[0].UpdatedAt)
+	assert.True(t, got[0].DeletedAt.IsZero())
+
+	c.On("Now").Return(deleted).Once()
+	count, err := With[models.Credentials](ctx, db).WithClock(c).Where("id", Equal, id).Delete()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = With[models.Credentials](ctx, db).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count, "soft deleted rows are left out")
+
+	count, err = With[models.Credentials](ctx, db).Where("id", Equal, id).Delete()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count, "soft deleted rows are not deleted again")
+
+	got, err = With[models.Credentials](ctx, db).Unscoped().Where("id", Equal, id).Run()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.True(t, deleted.Equal(got[0].DeletedAt), "deleted at %v", got[0].DeletedAt)
+
+	count, err = With[models.Credentials](ctx, db).Unscoped().Where("id", Equal, id).Delete()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = With[models.Credentials](ctx, db).Unscoped().Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count, "unscoped deletes remove the rows")
+}
+
+func TestQueryBuilder_Versions(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	id, err := With[models.Users](ctx, db).Insert(models.Users{
+		OrganizationID: 1,
+		Username:       "jane",
+		Email:          "jane@example.com",
+		Credentials:    models.Credentials{Salt: "salt", PassHash: "hash"},
+	})
+	require.NoError(t, err)
+
+	updated, err := With[models.Users](ctx, db).Where("id", Equal, id).Update(models.Users{DisplayName: "Jane", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	_, err = With[models.Users](ctx, db).Where("id", Equal, id).Update(models.Users{DisplayName: "Janet", Version: 1})
+	assert.True(t, errors.IsKind(err, errors.Conflict), "stale version is a conflict: %v", err)
+
+	// without a version the update is not checked but still versioned
+	_, err = With[models.Users](ctx, db).Where("id", Equal, id).Update(models.Users{DisplayName: "Janet"})
+	require.NoError(t, err)
+
+	got, err := With[models.Users](ctx, db).Where("id", Equal, id).Run()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Janet", got[0].DisplayName)
+	assert.Equal(t, int32(3), got[0].Version)
+}
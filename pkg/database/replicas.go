@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// DB is the primary database and its read replicas. Reads are spread over
+// the healthy replicas, writes and transactions go to the primary.
+type DB struct {
+	primary  *sql.DB
+	replicas []*replica
+	next     atomic.Uint32
+	hooks    []Hook
+}
+
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// NewDB returns the primary with its replicas, the replicas serve reads from
+// the start and are ejected by CheckReplicas once they stop answering.
+func NewDB(primary *sql.DB, replicas ...*sql.DB) *DB {
+	db := &DB{primary: primary}
+	for _, r := range replicas {
+		rep := &replica{db: r}
+		rep.healthy.Store(true)
+		db.replicas = append(db.replicas, rep)
+	}
+	return db
+}
+
+// ConnectDB connects to the primary, waiting for it like Connect, and to the
+// configured replicas. Replicas not answering yet are ejected until they do.
+func ConnectDB(ctx context.Context, cfg config.Database) (*DB, error) {
+	const op errors.Op = "database.ConnectDB"
+
+	primary, err := Connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make([]*sql.DB, 0, len(cfg.Replicas))
+	for _, dsn := range cfg.Replicas {
+		r, err := openDSN(cfg, dsn)
+		if err != nil {
+			NewDB(primary, replicas...).Close()
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage("Failed to open database replica"),
+			)
+		}
+		replicas = append(replicas, r)
+	}
+
+	db := NewDB(primary, replicas...)
+	db.CheckReplicas(ctx)
+	return db, nil
+}
+
+func (db *DB) Primary() *sql.DB {
+	return db.primary
+}
+
+// Reader returns the database serving the reads of ctx, the next healthy
+// replica in turn. The primary serves them when ctx carries a transaction or
+// already wrote with read your writes, and when no replica is healthy.
+func (db *DB) Reader(ctx context.Context) *sql.DB {
+	if _, ok := txFromContext(ctx); ok {
+		return db.primary
+	}
+	if p, ok := ctx.Value(pinKey{}).(*pin); ok && p.primary.Load() {
+		return db.primary
+	}
+
+	n := len(db.replicas)
+	for i := 0; i < n; i++ {
+		r := db.replicas[int(db.next.Add(1))%n]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return db.primary
+}
+
+// Writer returns the primary, with read your writes it also pins the
+// following reads of ctx to it.
+func (db *DB) Writer(ctx context.Context) *sql.DB {
+	if p, ok := ctx.Value(pinKey{}).(*pin); ok {
+		p.primary.Store(true)
+	}
+	return db.primary
+}
+
+// ReadConn returns the transaction of ctx, or the database of its reads.
+func (db *DB) ReadConn(ctx context.Context) Querier {
+	return db.conn(ctx, db.Reader(ctx))
+}
+
+// WriteConn returns the transaction of ctx, or the database of its writes.
+func (db *DB) WriteConn(ctx context.Context) Querier {
+	return db.conn(ctx, db.Writer(ctx))
+}
+
+// PrimaryConn returns the transaction of ctx, or the primary without pinning
+// the reads of ctx to it.
+func (db *DB) PrimaryConn(ctx context.Context) Querier {
+	return db.conn(ctx, db.primary)
+}
+
+// conn returns the transaction of ctx, or target running the hooks of db
+// around its statements. The transactions run the hooks they were started
+// with.
+func (db *DB) conn(ctx context.Context, target *sql.DB) Querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.conn
+	}
+	d := DialectOf(target)
+	return withHooks(bind(target, d), d, db.hooks)
+}
+
+// Begin starts a transaction on the primary like Begin, the hooks of db run
+// around its statements.
+func (db *DB) Begin(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	return begin(ctx, db.Writer(ctx), opts, db.hooks)
+}
+
+// NewTransactor returns a transactor of the primary whose transactions run
+// the hooks of db.
+func (db *DB) NewTransactor(opts ...TransactorOption) *Transactor {
+	t := NewTransactor(db.primary, opts...)
+	t.hooks = db.hooks
+	return t
+}
+
+// CheckReplicas pings the replicas, the ones failing are ejected and the
+// ones answering again are restored.
+func (db *DB) CheckReplicas(ctx context.Context) {
+	for _, r := range db.replicas {
+		r.healthy.Store(r.db.PingContext(ctx) == nil)
+	}
+}
+
+// ReplicaStats counts the replicas and the ones serving reads.
+type ReplicaStats struct {
+	Total   int `json:"total"`
+	Healthy int `json:"healthy"`
+}
+
+// ReplicaStats returns how many replicas are in rotation, as of the last
+// check.
+func (db *DB) ReplicaStats() ReplicaStats {
+	stats := ReplicaStats{Total: len(db.replicas)}
+	for _, r := range db.replicas {
+		if r.healthy.Load() {
+			stats.Healthy++
+		}
+	}
+	return stats
+}
+
+// MonitorReplicas checks the replicas every interval until ctx is done.
+func (db *DB) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(db.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			db.CheckReplicas(ctx)
+		}
+	}
+}
+
+// Close closes the primary and the replicas.
+func (db *DB) Close() error {
+	err := db.primary.Close()
+	for _, r := range db.replicas {
+		if rerr := r.db.Close(); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+type pinKey struct{}
+
+// pin records whether the context wrote to the primary.
+type pin struct {
+	primary atomic.Bool
+}
+
+// WithReadYourWrites returns ctx whose reads are served by the primary once
+// it wrote, so they see their own writes despite the replication lag.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(pinKey{}).(*pin); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, pinKey{}, &pin{})
+}
+
+// PinPrimary returns ctx whose reads are served by the primary, for reads
+// that must see a write made just before.
+func PinPrimary(ctx context.Context) context.Context {
+	p := &pin{}
+	p.primary.Store(true)
+	return context.WithValue(ctx, pinKey{}, p)
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func TestDB_Reader(t *testing.T) {
+	primary, _ := newMockDB(t)
+	first, firstMock := newMockDB(t)
+	second, secondMock := newMockDB(t)
+	db := NewDB(primary, first, second)
+	ctx := context.Background()
+
+	t.Run("Spreads the reads over the replicas", func(t *testing.T) {
+		a, b := db.Reader(ctx), db.Reader(ctx)
+		assert.True(t, a == first || a == second)
+		assert.True(t, b == first || b == second)
+		assert.NotSame(t, a, b)
+		assert.Same(t, a, db.Reader(ctx))
+	})
+
+	t.Run("Ejects the replicas failing the checks", func(t *testing.T) {
+		firstMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+		secondMock.ExpectPing()
+		db.CheckReplicas(ctx)
+
+		assert.Same(t, second, db.Reader(ctx))
+		assert.Same(t, second, db.Reader(ctx))
+
+		firstMock.ExpectPing()
+		secondMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+		db.CheckReplicas(ctx)
+		assert.Same(t, first, db.Reader(ctx))
+	})
+
+	t.Run("Falls back to the primary without healthy replicas", func(t *testing.T) {
+		firstMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+		secondMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+		db.CheckReplicas(ctx)
+
+		assert.Same(t, primary, db.Reader(ctx))
+		assert.Same(t, primary, NewDB(primary).Reader(ctx))
+	})
+}
+
+func TestDB_ReadYourWrites(t *testing.T) {
+	primary, _ := newMockDB(t)
+	replica, _ := newMockDB(t)
+	db := NewDB(primary, replica)
+
+	ctx := context.Background()
+	assert.Same(t, primary, db.Writer(ctx))
+	assert.Same(t, replica, db.Reader(ctx), "reads without read your writes stay on the replicas")
+
+	ctx = WithReadYourWrites(ctx)
+	assert.Same(t, replica, db.Reader(ctx))
+	assert.Same(t, primary, db.Writer(ctx))
+	assert.Same(t, primary, db.Reader(ctx), "reads after a write are pinned to the primary")
+
+	assert.Same(t, primary, db.Reader(PinPrimary(context.Background())))
+}
+
+func TestQueryBuilder_On(t *testing.T) {
+	primary, primaryMock := newMockDB(t)
+	replica, replicaMock := newMockDB(t)
+	db := NewDB(primary, replica)
+	ctx := WithReadYourWrites(context.Background())
+
+	replicaMock.ExpectQuery(`SELECT COUNT\(\*\) FROM users`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	primaryMock.ExpectExec(`UPDATE users SET display_name = \$1, updated_at = \$2, version = version \+ 1 WHERE \(id = \$3\) AND deleted_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	primaryMock.ExpectQuery(`SELECT COUNT\(\*\) FROM users`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+
+	_, err := On[models.Users](ctx, db).Count()
+	require.NoError(t, err)
+
+	_, err = On[models.Users](ctx, db).Where("id", Equal, 1).Update(models.Users{DisplayName: "Jane"})
+	require.NoError(t, err)
+
+	_, err = On[models.Users](ctx, db).Count()
+	require.NoError(t, err)
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+}
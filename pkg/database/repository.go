@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/rs/zerolog"
+)
+
+// RepositoryInterface is the CRUD of the rows of a model by id. Missing rows
+// are reported with the NotFound kind and writes clashing with a unique key
+// with the Conflict kind.
+type RepositoryInterface[T any] interface {
+	Create(ctx context.Context, model T) (int64, error)
+	GetByID(ctx context.Context, id int64) (T, error)
+	// FindBy returns the models whose column equals value, sorted by id
+	FindBy(ctx context.Context, column string, value any) ([]T, error)
+	// Update writes the columns of the model, or its non zero fields
+	// without columns, like the Update of the query builder
+	Update(ctx context.Context, id int64, model T, columns ...string) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, cursor pagination.Cursor, size int) (pagination.Page[T], error)
+	Exists(ctx context.Context, id int64) (bool, error)
+}
+
+// Repository implements RepositoryInterface with the query builder for any
+// struct with name tags and an id column, new entities only need their own
+// repository for the queries it does not cover.
+type Repository[T any] struct {
+	db *DB
+}
+
+var _ RepositoryInterface[struct{}] = (*Repository[struct{}])(nil)
+
+func NewRepository[T any](db *DB) *Repository[T] {
+	return &Repository[T]{db: db}
+}
+
+func (r *Repository[T]) Create(ctx context.Context, model T) (int64, error) {
+	const op errors.Op = "database.Repository.Create"
+
+	id, err := On[T](ctx, r.db).Insert(model)
+	if err != nil {
+		return 0, writeError(op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository[T]) GetByID(ctx context.Context, id int64) (T, error) {
+	const op errors.Op = "database.Repository.GetByID"
+
+	var model T
+	rows, err := On[T](ctx, r.db).Where(keysetColumn, Equal, id).Limit(1).Run()
+	if err != nil {
+		return model, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+	if len(rows) == 0 {
+		return model, entryNotFound(op, sql.ErrNoRows)
+	}
+
+	return rows[0], nil
+}
+
+func (r *Repository[T]) FindBy(ctx context.Context, column string, value any) ([]T, error) {
+	const op errors.Op = "database.Repository.FindBy"
+
+	rows, err := On[T](ctx, r.db).Where(column, Equal, value).OrderBy(keysetColumn, Asc).Run()
+	if err != nil {
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+
+	return rows, nil
+}
+
+func (r *Repository[T]) Update(ctx context.Context, id int64, model T, columns ...string) error {
+	const op errors.Op = "database.Repository.Update"
+
+	affected, err := On[T](ctx, r.db).Where(keysetColumn, Equal, id).Update(model, columns...)
+	if err != nil {
+		return writeError(op, err)
+	}
+	if affected == 0 {
+		return entryNotFound(op, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Delete removes the row, models with a deleted timestamp are soft deleted.
+func (r *Repository[T]) Delete(ctx context.Context, id int64) error {
+	const op errors.Op = "database.Repository.Delete"
+
+	affected, err := On[T](ctx, r.db).Where(keysetColumn, Equal, id).Delete()
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+	if affected == 0 {
+		return entryNotFound(op, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository[T]) List(ctx context.Context, cursor pagination.Cursor, size int) (pagination.Page[T], error) {
+	const op errors.Op = "database.Repository.List"
+
+	page, err := On[T](ctx, r.db).After(cursor).PageSize(size).RunPage()
+	if err != nil {
+		return pagination.Page[T]{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+
+	return page, nil
+}
+
+func (r *Repository[T]) Exists(ctx context.Context, id int64) (bool, error) {
+	const op errors.Op = "database.Repository.Exists"
+
+	count, err := On[T](ctx, r.db).Where(keysetColumn, Equal, id).Count()
+	if err != nil {
+		return false, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+		)
+	}
+
+	return count > 0, nil
+}
+
+// writeError reports the writes clashing with a unique key as conflicts,
+// the conflict wraps the error of the driver so it is the innermost kind.
+func writeError(op errors.Op, err error) error {
+	if IsUniqueViolation(err) {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(driverError(err)),
+			errors.WithMessage("Entry already exists"),
+			errors.KindConflict(),
+			errors.WithSeverity(zerolog.WarnLevel),
+		)
+	}
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+	)
+}
+
+func entryNotFound(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Entry not found"),
+		errors.KindNotFound(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositorySQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepository[models.Credentials](NewDB(db))
+
+	id, err := repo.Create(ctx, models.Credentials{Salt: "salt", PassHash: "hash"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, models.Credentials{Salt: "salt", PassHash: "other"})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "hash", got.PassHash)
+
+	found, err := repo.FindBy(ctx, "salt", "salt")
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	_, err = repo.FindBy(ctx, "unknown", "salt")
+	assert.True(t, errors.IsKind(err, errors.BadRequest), "unknown columns are a bad request: %v", err)
+
+	require.NoError(t, repo.Update(ctx, id, models.Credentials{PassHash: "changed"}))
+	got, err = repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", got.PassHash)
+	assert.Equal(t, "salt", got.Salt, "zero fields are not written")
+
+	page, err := repo.List(ctx, pagination.Cursor{}, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, id, int64(page.Items[0].ID))
+	assert.NotNil(t, page.Next)
+
+	exists, err := repo.Exists(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	exists, err = repo.Exists(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, exists, "deleted rows do not exist")
+
+	_, err = repo.GetByID(ctx, id)
+	assert.True(t, errors.IsKind(err, errors.NotFound), "%v", err)
+	assert.True(t, errors.IsKind(repo.Update(ctx, id, models.Credentials{Salt: "x"}), errors.NotFound))
+	assert.True(t, errors.IsKind(repo.Delete(ctx, id), errors.NotFound))
+}
+
+func TestRepository_ConflictSQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepository[models.Users](NewDB(db))
+
+	credentials := models.Credentials{Salt: "salt", PassHash: "hash"}
+	jane := models.Users{OrganizationID: 1, Username: "jane", Email: "jane@example.com", Credentials: credentials}
+	_, err = repo.Create(ctx, jane)
+	require.NoError(t, err)
+	id, err := repo.Create(ctx, models.Users{OrganizationID: 1, Username: "john", Email: "john@example.com", Credentials: credentials})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, jane)
+	assert.True(t, errors.IsKind(err, errors.Conflict), "%v", err)
+
+	err = repo.Update(ctx, id, models.Users{Username: "jane"}, "username")
+	assert.True(t, errors.IsKind(err, errors.Conflict), "%v", err)
+}
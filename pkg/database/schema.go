@@ -0,0 +1,302 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// ColumnKind is the kind of value of a column, the types of the dialects
+// are compared through it.
+type ColumnKind string
+
+const (
+	// ColumnID is the auto incremented primary key, compared as an integer.
+	ColumnID        ColumnKind = "id"
+	ColumnInteger   ColumnKind = "integer"
+	ColumnText      ColumnKind = "text"
+	ColumnBoolean   ColumnKind = "boolean"
+	ColumnTimestamp ColumnKind = "timestamp"
+)
+
+// TableSchema is the columns and the foreign keys of a table.
+type TableSchema struct {
+	Table       string
+	Columns     []Column
+	ForeignKeys []ForeignKey
+}
+
+// Column is a column of a table. Type is the type reported by the database,
+// it is empty for the columns of the models.
+type Column struct {
+	Name string
+	Type string
+	Kind ColumnKind
+}
+
+type ForeignKey struct {
+	Column     string
+	References string
+}
+
+func (s TableSchema) column(name string) (Column, bool) {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+func (s TableSchema) foreignKey(column string) (ForeignKey, bool) {
+	for _, fk := range s.ForeignKeys {
+		if fk.Column == column {
+			return fk, true
+		}
+	}
+	return ForeignKey{}, false
+}
+
+// ModelSchema returns the table expected by the tags of T. Referenced
+// structs are foreign keys to the table of their reference tag.
+func ModelSchema[T any]() TableSchema {
+	var model T
+	schema := TableSchema{Table: NewModelParser[T](model).GetTableName()}
+
+	t := reflect.TypeOf(model)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		column := field.Tag.Get(name.String())
+		if column == "" {
+			continue
+		}
+
+		if references := field.Tag.Get(reference.String()); references != "" {
+			schema.Columns = append(schema.Columns, Column{Name: column, Kind: ColumnInteger})
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{Column: column, References: references})
+			continue
+		}
+		schema.Columns = append(schema.Columns, Column{Name: column, Kind: fieldKind(field.Type)})
+	}
+	return schema
+}
+
+func fieldKind(t reflect.Type) ColumnKind {
+	if t == timeType {
+		return ColumnTimestamp
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ColumnInteger
+	case reflect.String:
+		return ColumnText
+	case reflect.Bool:
+		return ColumnBoolean
+	}
+	return ""
+}
+
+// typeKind returns the kind of a type reported by the database, the type
+// itself when it is of no known kind.
+func typeKind(typ string) ColumnKind {
+	typ = strings.ToLower(typ)
+	switch {
+	case strings.Contains(typ, "bool"), typ == "tinyint(1)":
+		return ColumnBoolean
+	case strings.Contains(typ, "timestamp"), strings.Contains(typ, "date"):
+		return ColumnTimestamp
+	case strings.Contains(typ, "int"), strings.Contains(typ, "serial"):
+		return ColumnInteger
+	case strings.Contains(typ, "char"), strings.Contains(typ, "text"), strings.Contains(typ, "clob"):
+		return ColumnText
+	}
+	return ColumnKind(typ)
+}
+
+// InspectTable reads the columns and the foreign keys of the table from the
+// database, a missing table has no columns.
+func InspectTable(ctx context.Context, db *sql.DB, table string) (TableSchema, error) {
+	const op errors.Op = "database.InspectTable"
+
+	d := DialectOf(db)
+	conn := Conn(ctx, db)
+	schema := TableSchema{Table: table}
+
+	err := scanPairs(ctx, conn, d.Columns(), table, func(column, typ string) {
+		schema.Columns = append(schema.Columns, Column{Name: column, Type: typ, Kind: typeKind(typ)})
+	})
+	if err == nil {
+		err = scanPairs(ctx, conn, d.ForeignKeys(), table, func(column, references string) {
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{Column: column, References: references})
+		})
+	}
+	if err != nil {
+		return TableSchema{}, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to inspect table "+table),
+		)
+	}
+
+	return schema, nil
+}
+
+// scanPairs runs the query of the table and calls fn with the two columns
+// of every row.
+func scanPairs(ctx context.Context, conn Querier, query, table string, fn func(a, b string)) error {
+	rows, err := conn.QueryContext(ctx, query, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a, b string
+		if err := rows.Scan(&a, &b); err != nil {
+			return err
+		}
+		fn(a, b)
+	}
+	return rows.Err()
+}
+
+type DriftKind string
+
+const (
+	MissingTable      DriftKind = "missing table"
+	MissingColumn     DriftKind = "missing column"
+	TypeMismatch      DriftKind = "type mismatch"
+	MissingForeignKey DriftKind = "missing foreign key"
+)
+
+// Drift is a difference between a model and its table. Expected is the
+// column of the model and Actual the one of the table.
+type Drift struct {
+	Kind       DriftKind
+	Table      string
+	Expected   Column
+	Actual     Column
+	References string
+}
+
+func (d Drift) String() string {
+	switch d.Kind {
+	case MissingTable:
+		return fmt.Sprintf("%s: %s", d.Table, d.Kind)
+	case TypeMismatch:
+		return fmt.Sprintf("%s.%s: %s, the model is %s but the column is %s",
+			d.Table, d.Expected.Name, d.Kind, d.Expected.Kind, d.Actual.Type)
+	case MissingForeignKey:
+		return fmt.Sprintf("%s.%s: %s to %s", d.Table, d.Expected.Name, d.Kind, d.References)
+	}
+	return fmt.Sprintf("%s.%s: %s", d.Table, d.Expected.Name, d.Kind)
+}
+
+// Compare returns the drifts of the table from the model: the columns and
+// foreign keys of the model missing from the table and the columns of
+// another kind. Columns of the table unknown to the model are ignored.
+func Compare(model, table TableSchema) []Drift {
+	if len(table.Columns) == 0 {
+		return []Drift{{Kind: MissingTable, Table: model.Table}}
+	}
+
+	drifts := make([]Drift, 0)
+	for _, expected := range model.Columns {
+		actual, ok := table.column(expected.Name)
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{Kind: MissingColumn, Table: model.Table, Expected: expected})
+		case expected.Kind != "" && expected.Kind != actual.Kind:
+			drifts = append(drifts, Drift{Kind: TypeMismatch, Table: model.Table, Expected: expected, Actual: actual})
+		}
+	}
+	for _, fk := range model.ForeignKeys {
+		if actual, ok := table.foreignKey(fk.Column); ok && actual.References == fk.References {
+			continue
+		}
+		expected, _ := model.column(fk.Column)
+		drifts = append(drifts, Drift{Kind: MissingForeignKey, Table: model.Table, Expected: expected, References: fk.References})
+	}
+	return drifts
+}
+
+// Scaffold returns the statements applying the drifts of the model in the
+// dialect and the ones reverting them. The changes the dialect cannot make
+// are left as comments to complete by hand.
+func Scaffold(d Dialect, model TableSchema, drifts []Drift) (up []string, down []string) {
+	for _, drift := range drifts {
+		var do, undo string
+		switch drift.Kind {
+		case MissingTable:
+			do, undo = createTable(d, model), "DROP TABLE "+model.Table
+		case MissingColumn:
+			column := drift.Expected
+			do = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", model.Table, column.Name, d.ColumnType(column.Kind))
+			undo = fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", model.Table, column.Name)
+		case TypeMismatch:
+			column := drift.Expected
+			do = d.AlterColumnType(model.Table, column.Name, d.ColumnType(column.Kind))
+			undo = d.AlterColumnType(model.Table, column.Name, drift.Actual.Type)
+		case MissingForeignKey:
+			do = d.AddForeignKey(model.Table, drift.Expected.Name, drift.References)
+			undo = d.DropForeignKey(model.Table, drift.Expected.Name)
+		}
+
+		if do == "" {
+			do = "-- TODO " + d.Name() + " cannot fix the " + drift.String() + " in place, the table must be rebuilt"
+		} else {
+			do += ";"
+		}
+		up = append(up, do)
+		if undo != "" {
+			down = append([]string{undo + ";"}, down...)
+		}
+	}
+	return up, down
+}
+
+func createTable(d Dialect, model TableSchema) string {
+	columns := make([]string, 0, len(model.Columns)+len(model.ForeignKeys))
+	for _, column := range model.Columns {
+		kind := column.Kind
+		if column.Name == keysetColumn {
+			kind = ColumnID
+		}
+		columns = append(columns, "  "+column.Name+" "+d.ColumnType(kind))
+	}
+	for _, fk := range model.ForeignKeys {
+		columns = append(columns, fmt.Sprintf("  CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id)",
+			foreignKeyName(model.Table, fk.Column), fk.Column, fk.References))
+	}
+	return "CREATE TABLE " + model.Table + " (\n" + strings.Join(columns, ",\n") + "\n)"
+}
+
+// MigrationFile returns a goose migration running the statements.
+func MigrationFile(up, down []string) string {
+	return "-- +goose Up\n" + strings.Join(up, "\n") + "\n\n-- +goose Down\n" + strings.Join(down, "\n") + "\n"
+}
+
+// columnType is the type of the kinds written the same by every dialect.
+func columnType(kind ColumnKind) string {
+	switch kind {
+	case ColumnInteger:
+		return "INT"
+	case ColumnBoolean:
+		return "BOOLEAN"
+	}
+	return "VARCHAR(254)"
+}
+
+func foreignKeyName(table, column string) string {
+	return "fk_" + table + "_" + column
+}
+
+func addForeignKey(table, column, references string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id)",
+		table, foreignKeyName(table, column), column, references)
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/internal/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelSchema(t *testing.T) {
+	schema := ModelSchema[models.Users]()
+
+	assert.Equal(t, "users", schema.Table)
+	assert.Contains(t, schema.Columns, Column{Name: "credentials_id", Kind: ColumnInteger})
+	assert.Contains(t, schema.Columns, Column{Name: "active", Kind: ColumnBoolean})
+	assert.Contains(t, schema.Columns, Column{Name: "deleted_at", Kind: ColumnTimestamp})
+	assert.Equal(t, []ForeignKey{{Column: "credentials_id", References: "credentials"}}, schema.ForeignKeys)
+}
+
+func TestCompare_MigratedSQLite(t *testing.T) {
+	db, err := NewSQLiteTestDB()
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, model := range []TableSchema{ModelSchema[models.Credentials](), ModelSchema[models.Users]()} {
+		table, err := InspectTable(context.Background(), db, model.Table)
+		require.NoError(t, err)
+		assert.Empty(t, Compare(model, table), "the migrations match the %s model", model.Table)
+	}
+}
+
+func TestCompare_DriftedSQLite(t *testing.T) {
+	db, err := Open(config.Database{Driver: SQLite.Name(), Path: ":memory:"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, `CREATE TABLE credentials (id INTEGER PRIMARY KEY AUTOINCREMENT, salt INT, created_at TIMESTAMP)`)
+	require.NoError(t, err)
+
+	credentials := ModelSchema[models.Credentials]()
+	table, err := InspectTable(ctx, db, "credentials")
+	require.NoError(t, err)
+	drifts := Compare(credentials, table)
+	assert.Equal(t, []string{
+		"credentials.salt: type mismatch, the model is text but the column is INT",
+		"credentials.passhash: missing column",
+		"credentials.updated_at: missing column",
+		"credentials.deleted_at: missing column",
+	}, driftStrings(drifts))
+
+	users := ModelSchema[models.Users]()
+	table, err = InspectTable(ctx, db, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []Drift{{Kind: MissingTable, Table: "users"}}, Compare(users, table))
+
+	// sqlite adds the columns but cannot change their type
+	up, down := Scaffold(SQLite, credentials, drifts)
+	for _, statement := range up {
+		_, err := db.ExecContext(ctx, statement)
+		require.NoError(t, err, statement)
+	}
+	table, err = InspectTable(ctx, db, "credentials")
+	require.NoError(t, err)
+	assert.Equal(t, drifts[:1], Compare(credentials, table))
+
+	for _, statement := range down {
+		_, err := db.ExecContext(ctx, statement)
+		require.NoError(t, err, statement)
+	}
+	table, err = InspectTable(ctx, db, "credentials")
+	require.NoError(t, err)
+	assert.Equal(t, drifts, Compare(credentials, table))
+}
+
+func TestScaffold(t *testing.T) {
+	users := ModelSchema[models.Users]()
+	drifts := []Drift{
+		{Kind: MissingColumn, Table: "users", Expected: Column{Name: "version", Kind: ColumnInteger}},
+		{Kind: TypeMismatch, Table: "users", Expected: Column{Name: "active", Kind: ColumnBoolean}, Actual: Column{Name: "active", Type: "integer"}},
+		{Kind: MissingForeignKey, Table: "users", Expected: Column{Name: "credentials_id", Kind: ColumnInteger}, References: "credentials"},
+	}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		wantUp   []string
+		wantDown []string
+	}{
+		{
+			name:    "Postgres alters the table",
+			dialect: Postgres,
+			wantUp: []string{
+				"ALTER TABLE users ADD COLUMN version INT;",
+				"ALTER TABLE users ALTER COLUMN active TYPE BOOLEAN;",
+				"ALTER TABLE users ADD CONSTRAINT fk_users_credentials_id FOREIGN KEY (credentials_id) REFERENCES credentials (id);",
+			},
+			wantDown: []string{
+				"ALTER TABLE users DROP CONSTRAINT fk_users_credentials_id;",
+				"ALTER TABLE users ALTER COLUMN active TYPE integer;",
+				"ALTER TABLE users DROP COLUMN version;",
+			},
+		},
+		{
+			name:    "MySQL alters the table",
+			dialect: MySQL,
+			wantUp: []string{
+				"ALTER TABLE users ADD COLUMN version INT;",
+				"ALTER TABLE users MODIFY COLUMN active BOOLEAN;",
+				"ALTER TABLE users ADD CONSTRAINT fk_users_credentials_id FOREIGN KEY (credentials_id) REFERENCES credentials (id);",
+			},
+			wantDown: []string{
+				"ALTER TABLE users DROP FOREIGN KEY fk_users_credentials_id;",
+				"ALTER TABLE users MODIFY COLUMN active integer;",
+				"ALTER TABLE users DROP COLUMN version;",
+			},
+		},
+		{
+			name:    "SQLite leaves the table rebuilds to do by hand",
+			dialect: SQLite,
+			wantUp: []string{
+				"ALTER TABLE users ADD COLUMN version INT;",
+				"-- TODO sqlite cannot fix the users.active: type mismatch, the model is boolean but the column is integer in place, the table must be rebuilt",
+				"-- TODO sqlite cannot fix the users.credentials_id: missing foreign key to credentials in place, the table must be rebuilt",
+			},
+			wantDown: []string{
+				"ALTER TABLE users DROP COLUMN version;",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down := Scaffold(tt.dialect, users, drifts)
+			assert.Equal(t, tt.wantUp, up)
+			assert.Equal(t, tt.wantDown, down)
+		})
+	}
+}
+
+func TestScaffold_MissingTable(t *testing.T) {
+	credentials := ModelSchema[models.Credentials]()
+	up, down := Scaffold(Postgres, credentials, []Drift{{Kind: MissingTable, Table: "credentials"}})
+
+	assert.Equal(t, "-- +goose Up\n"+
+		"CREATE TABLE credentials (\n"+
+		"  id SERIAL PRIMARY KEY,\n"+
+		"  salt VARCHAR(254),\n"+
+		"  passhash VARCHAR(254),\n"+
+		"  created_at TIMESTAMP WITHOUT TIME ZONE,\n"+
+		"  updated_at TIMESTAMP WITHOUT TIME ZONE,\n"+
+		"  deleted_at TIMESTAMP WITHOUT TIME ZONE\n"+
+		");\n\n"+
+		"-- +goose Down\n"+
+		"DROP TABLE credentials;\n", MigrationFile(up, down))
+}
+
+func driftStrings(drifts []Drift) []string {
+	out := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		out = append(out, d.String())
+	}
+	return out
+}
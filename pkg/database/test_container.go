@@ -4,14 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
-	"path"
-	"runtime"
+	"io"
 	"time"
 
 	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/migrations"
 	"github.com/docker/go-connections/nat"
-	"github.com/pressly/goose/v3"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -81,24 +79,11 @@ func NewPostgresTestContainer(ctx context.Context, name string) *ContainerDBConf
 }
 
 func (c ContainerDBConfigs) RunMigrations() error {
-	var sqlMigrations *sql.DB
-	sqlMigrations, err := sql.Open("postgres", c.DSN)
+	db, err := sql.Open("postgres", c.DSN)
 	if err != nil {
 		return err
 	}
-	// nolint: dogsled
-	_, filename, _, _ := runtime.Caller(0)
-	dir := path.Join(path.Dir(filename), "../../migrations")
+	defer db.Close()
 
-	files := os.DirFS(dir)
-	goose.SetBaseFS(files)
-
-	if err := goose.SetDialect("postgres"); err != nil {
-		panic(err)
-	}
-
-	if err := goose.Up(sqlMigrations, "."); err != nil {
-		panic(err)
-	}
-	return nil
+	return NewMigrator(db, migrations.FS, io.Discard).Up()
 }
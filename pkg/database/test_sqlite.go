@@ -0,0 +1,24 @@
+package database
+
+import (
+	"database/sql"
+	"io"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/migrations"
+)
+
+// NewSQLiteTestDB opens a migrated in memory sqlite database, tests using it
+// do not need docker.
+func NewSQLiteTestDB() (*sql.DB, error) {
+	db, err := Open(config.Database{Driver: SQLite.Name(), Path: ":memory:"})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := NewMigrator(db, migrations.FS, io.Discard).Up(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Pedrommb91/go-auth/pkg/database"
+
+// TracingHook wraps every statement in an OpenTelemetry span, a child of the
+// span of the context the query was started with, like the span of the
+// request being served.
+type TracingHook struct {
+	tracer trace.Tracer
+}
+
+// NewTracingHook returns a hook starting its spans with a tracer of tp.
+func NewTracingHook(tp trace.TracerProvider) *TracingHook {
+	return &TracingHook{tracer: tp.Tracer(tracerName, trace.WithSchemaURL(semconv.SchemaURL))}
+}
+
+func (h *TracingHook) Before(ctx context.Context, event *QueryEvent) context.Context {
+	operation := statementOperation(event.Query)
+	ctx, _ = h.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			dbSystem(event.Dialect),
+			semconv.DBOperation(operation),
+			semconv.DBStatement(event.Query),
+		),
+	)
+	return ctx
+}
+
+func (h *TracingHook) After(ctx context.Context, event *QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	if event.Err != nil {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End()
+}
+
+// statementOperation returns the keyword starting the statement, like
+// SELECT or INSERT.
+func statementOperation(query string) string {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	return strings.ToUpper(operation)
+}
+
+func dbSystem(d Dialect) attribute.KeyValue {
+	switch d {
+	case Postgres:
+		return semconv.DBSystemPostgreSQL
+	case MySQL:
+		return semconv.DBSystemMySQL
+	default:
+		return semconv.DBSystemSqlite
+	}
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// Querier runs statements, it is satisfied by *sql.DB, *sql.Tx and *Tx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// txState is the transaction shared by every Tx started with the same context.
+type txState struct {
+	tx         *sql.Tx
+	conn       Querier
+	savepoints int
+}
+
+func txFromContext(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	return state, ok
+}
+
+// Conn returns the transaction of ctx, or db when ctx carries none.
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.conn
+	}
+	return bind(db, DialectOf(db))
+}
+
+// Tx is a transaction, or a savepoint when it was started inside another one.
+type Tx struct {
+	state     *txState
+	savepoint string
+	done      bool
+}
+
+// Begin starts a transaction on db. When ctx already carries a transaction a
+// savepoint is created instead, so committing it only releases the savepoint
+// and rolling it back only undoes the work done since Begin.
+func Begin(ctx context.Context, db *sql.DB, opts *sql.TxOptions) (*Tx, error) {
+	return begin(ctx, db, opts, nil)
+}
+
+// begin is Begin running the hooks around the statements of a new
+// transaction, savepoints keep the hooks of their transaction.
+func begin(ctx context.Context, db *sql.DB, opts *sql.TxOptions, hooks []Hook) (*Tx, error) {
+	if state, ok := txFromContext(ctx); ok {
+		state.savepoints++
+		savepoint := fmt.Sprintf("sp_%d", state.savepoints)
+		if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			return nil, err
+		}
+		return &Tx{state: state, savepoint: savepoint}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	d := DialectOf(db)
+	return &Tx{state: &txState{tx: tx, conn: withHooks(bind(tx, d), d, hooks)}}, nil
+}
+
+// Context returns ctx carrying the transaction, statements run through Conn
+// or Begin with it join the transaction.
+func (t *Tx) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, t.state)
+}
+
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.state.conn.ExecContext(ctx, query, args...)
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.state.conn.QueryContext(ctx, query, args...)
+}
+
+func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.state.conn.QueryRowContext(ctx, query, args...)
+}
+
+// Commit commits the transaction or releases the savepoint.
+func (t *Tx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	if t.savepoint == "" {
+		return t.state.tx.Commit()
+	}
+	_, err := t.state.tx.Exec("RELEASE SAVEPOINT " + t.savepoint)
+	return err
+}
+
+// Rollback aborts the transaction or rolls back to the savepoint. It is a
+// no-op returning sql.ErrTxDone after Commit, so it can always be deferred.
+func (t *Tx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	if t.savepoint == "" {
+		return t.state.tx.Rollback()
+	}
+	_, err := t.state.tx.Exec("ROLLBACK TO SAVEPOINT " + t.savepoint)
+	return err
+}
+
+const defaultMaxRetries = 3
+
+// Transactor runs units of work spanning several repositories in a single
+// transaction.
+type Transactor struct {
+	db         *sql.DB
+	dialect    Dialect
+	isolation  sql.IsolationLevel
+	maxRetries int
+	hooks      []Hook
+}
+
+type TransactorOption func(*Transactor)
+
+// WithIsolationLevel sets the isolation level of the transactions started by
+// the transactor. Nested units of work keep the level of the outer one.
+func WithIsolationLevel(level sql.IsolationLevel) TransactorOption {
+	return func(t *Transactor) {
+		t.isolation = level
+	}
+}
+
+// WithMaxRetries sets how many times a unit of work is retried after a
+// serialization failure.
+func WithMaxRetries(retries int) TransactorOption {
+	return func(t *Transactor) {
+		t.maxRetries = retries
+	}
+}
+
+func NewTransactor(db *sql.DB, opts ...TransactorOption) *Transactor {
+	t := &Transactor{
+		db:         db,
+		dialect:    DialectOf(db),
+		isolation:  sql.LevelDefault,
+		maxRetries: defaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WithinTx runs fn in a transaction, the context given to fn carries it so
+// repositories called with that context join the transaction. The
+// transaction is committed when fn returns nil and rolled back otherwise.
+//
+// When ctx already carries a transaction fn runs in a savepoint of it.
+// Otherwise the whole unit of work is retried when the database reports a
+// serialization failure, so fn must be safe to run more than once.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op errors.Op = "database.WithinTx"
+
+	if _, nested := txFromContext(ctx); nested {
+		return t.run(ctx, fn)
+	}
+
+	var err error
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		err = t.run(ctx, fn)
+		if !t.dialect.IsSerializationFailure(err) {
+			break
+		}
+	}
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(*errors.Error); ok {
+		return err
+	}
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Failed to run transaction"),
+	)
+}
+
+func (t *Transactor) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := begin(ctx, t.db, &sql.TxOptions{Isolation: t.isolation}, t.hooks)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx.Context(ctx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor_WithinTx(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001"}
+
+	tests := []struct {
+		name      string
+		opts      []TransactorOption
+		expect    func(mock sqlmock.Sqlmock)
+		fn        func(tr *Transactor, calls *int) func(ctx context.Context) error
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "Commits when the unit of work succeeds",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO credentials`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					_, err := Conn(ctx, tr.db).ExecContext(ctx, `INSERT INTO credentials (salt) VALUES ('salt')`)
+					return err
+				}
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Rolls back when the unit of work fails",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					return errors.Build(errors.WithError(fmt.Errorf("boom")))
+				}
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "Nested unit of work rolls back to its savepoint",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`RELEASE SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					err := tr.WithinTx(ctx, func(ctx context.Context) error {
+						return fmt.Errorf("boom")
+					})
+					if err == nil {
+						return fmt.Errorf("expected nested error")
+					}
+					return tr.WithinTx(ctx, func(ctx context.Context) error {
+						return nil
+					})
+				}
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Retries serialization failures",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE users`).WillReturnError(serializationFailure)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE users`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					_, err := Conn(ctx, tr.db).ExecContext(ctx, `UPDATE users SET username = 'jane'`)
+					if err != nil {
+						return errors.Build(errors.WithError(err))
+					}
+					return nil
+				}
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "Gives up after the configured retries",
+			opts: []TransactorOption{WithMaxRetries(1)},
+			expect: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < 2; i++ {
+					mock.ExpectBegin()
+					mock.ExpectRollback()
+				}
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					return serializationFailure
+				}
+			},
+			wantCalls: 2,
+			wantErr:   true,
+		},
+		{
+			name: "Other errors are not retried",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(tr *Transactor, calls *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					*calls++
+					return &pq.Error{Code: "23505"}
+				}
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.expect(mock)
+
+			tr := NewTransactor(db, tt.opts...)
+			calls := 0
+			err = tr.WithinTx(context.Background(), tt.fn(tr, &calls))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestQueryBuilder_JoinsTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM mappercredentials`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectRollback()
+
+	tx, err := Begin(context.Background(), db, nil)
+	require.NoError(t, err)
+
+	count, err := With[mapperCredentials](tx.Context(context.Background()), db).Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assert.NoError(t, tx.Rollback())
+	assert.ErrorIs(t, tx.Commit(), sql.ErrTxDone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -21,6 +21,7 @@ const (
 	NotFound            = Kind(http.StatusNotFound)
 	RequestTimeout      = Kind(http.StatusRequestTimeout)
 	Conflict            = Kind(http.StatusConflict)
+	PreconditionFailed  = Kind(http.StatusPreconditionFailed)
 	InternalServerError = Kind(http.StatusInternalServerError)
 	BadGateway          = Kind(http.StatusBadGateway)
 )
@@ -35,6 +36,7 @@ var kindLookUp = map[Kind]string{
 	NotFound:            "Not Found",
 	RequestTimeout:      "Request Timeout",
 	Conflict:            "Conflict",
+	PreconditionFailed:  "Precondition Failed",
 	InternalServerError: "Internal server error",
 }
 
@@ -88,6 +90,12 @@ func KindConflict() ErrorOption {
 	}
 }
 
+func KindPreconditionFailed() ErrorOption {
+	return func(e *Error) {
+		e.Kind = PreconditionFailed
+	}
+}
+
 func KindRequestTimout() ErrorOption {
 	return func(e *Error) {
 		e.Kind = RequestTimeout
@@ -251,6 +259,16 @@ func GetFirstNestedError(e error) error {
 	return err
 }
 
+// IsKind reports whether the innermost custom error has the given kind.
+func IsKind(e error, k Kind) bool {
+	err, ok := GetFirstNestedError(e).(*Error)
+	if !ok {
+		return false
+	}
+
+	return err.Kind == k
+}
+
 // Logs the error by level.
 func LogError(l logger.Interface, err error) {
 	ll, ok := l.(*logger.Logger)
@@ -418,6 +418,13 @@ func TestWithKind(t *testing.T) {
 			},
 			want: Build(KindConflict()),
 		},
+		{
+			name: "When PreconditionFailed",
+			args: args{
+				k: PreconditionFailed,
+			},
+			want: Build(KindPreconditionFailed()),
+		},
 		{
 			name: "When RequestTimeout",
 			args: args{
@@ -476,6 +483,55 @@ func TestGetFirstNestedError(t *testing.T) {
 	}
 }
 
+func TestIsKind(t *testing.T) {
+	type args struct {
+		e error
+		k Kind
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "nested error with kind",
+			args: args{
+				e: Build(
+					WithError(Build(KindNotFound(), WithError(fmt.Errorf("first error")))),
+				),
+				k: NotFound,
+			},
+			want: true,
+		},
+		{
+			name: "nested error with other kind",
+			args: args{
+				e: Build(
+					KindNotFound(),
+					WithError(Build(KindConflict(), WithError(fmt.Errorf("first error")))),
+				),
+				k: NotFound,
+			},
+			want: false,
+		},
+		{
+			name: "native error",
+			args: args{
+				e: fmt.Errorf("test error"),
+				k: Unexpected,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsKind(tt.args.e, tt.args.k); got != tt.want {
+				t.Errorf("IsKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWithNestedErrorCopy(t *testing.T) {
 	dummyErrID := "e157f89f-abd0-4b1a-bc58-de8bd8fd04cd"
 	NewUUID = func() uuid.UUID {
@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+)
+
+// LogMailer writes the emails to the log instead of sending them, it is
+// meant for development.
+type LogMailer struct {
+	log logger.Interface
+}
+
+func NewLogMailer(l logger.Interface) *LogMailer {
+	return &LogMailer{
+		log: l,
+	}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	m.log.Info("email to %s with subject %q:\n%s", strings.Join(msg.To, ", "), msg.Subject, msg.Body)
+	return nil
+}
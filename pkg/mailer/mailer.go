@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+)
+
+const (
+	SMTPBackend = "smtp"
+	LogBackend  = "log"
+)
+
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+func New(cfg config.Mailer, l logger.Interface) (Mailer, error) {
+	const op errors.Op = "mailer.New"
+
+	switch cfg.Backend {
+	case SMTPBackend:
+		return NewSMTPMailer(cfg), nil
+	case LogBackend, "":
+		return NewLogMailer(l), nil
+	default:
+		return nil, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(fmt.Errorf("unknown mailer backend %q", cfg.Backend)),
+			errors.WithMessage("Unknown mailer backend"),
+		)
+	}
+}
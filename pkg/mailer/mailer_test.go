@@ -0,0 +1,99 @@
+package mailer
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	l := logger.New("info")
+
+	m, err := New(config.Mailer{Backend: LogBackend}, l)
+	assert.NoError(t, err)
+	assert.IsType(t, &LogMailer{}, m)
+
+	m, err = New(config.Mailer{Backend: SMTPBackend}, l)
+	assert.NoError(t, err)
+	assert.IsType(t, &SMTPMailer{}, m)
+
+	_, err = New(config.Mailer{Backend: "pigeon"}, l)
+	assert.Error(t, err)
+}
+
+// serveSMTP accepts a single session and returns the received data.
+func serveSMTP(ln net.Listener) <-chan string {
+	received := make(chan string, 1)
+	go func() {
+		defer close(received)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				received <- strings.Join(data, "\n")
+				_ = tp.PrintfLine("250 ok")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+	return received
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+		received := serveSMTP(ln)
+
+		host, port, _ := net.SplitHostPort(ln.Addr().String())
+		m := NewSMTPMailer(config.Mailer{Host: host, Port: port, From: "no-reply@example.com"})
+		err = m.Send(Message{
+			To:      []string{"alice@example.com"},
+			Subject: "Welcome",
+			Body:    "Hello\nAlice",
+		})
+		assert.NoError(t, err)
+
+		data := <-received
+		assert.Contains(t, data, "To: alice@example.com")
+		assert.Contains(t, data, "Subject: Welcome")
+		assert.True(t, strings.HasSuffix(data, "Hello\nAlice"))
+	})
+
+	t.Run("Server unavailable", func(t *testing.T) {
+		m := NewSMTPMailer(config.Mailer{Host: "127.0.0.1", Port: "1", From: "no-reply@example.com"})
+		err := m.Send(Message{To: []string{"alice@example.com"}})
+		assert.True(t, errors.IsKind(err, errors.BadGateway))
+	})
+}
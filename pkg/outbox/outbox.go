@@ -0,0 +1,65 @@
+// Package outbox publishes domain events without dual writes: events are
+// written to the outbox table in the transaction of the change they describe
+// and a relay delivers them to the sinks once it committed.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+// Message is an event of the outbox, as delivered to the sinks.
+type Message struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	// Attempts counts the deliveries tried, including the current one
+	Attempts int `json:"-"`
+}
+
+type Outbox struct {
+	db    *database.DB
+	clock clock.Clock
+}
+
+func New(db *database.DB, c clock.Clock) *Outbox {
+	return &Outbox{
+		db:    db,
+		clock: c,
+	}
+}
+
+// Record adds the event to the outbox in the transaction of ctx, so it is
+// only delivered once that transaction commits.
+func (o *Outbox) Record(ctx context.Context, eventType string, payload any) error {
+	const op errors.Op = "outbox.Record"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage(fmt.Sprintf("Failed to encode %s event", eventType)),
+		)
+	}
+
+	now := o.clock.Now().UTC()
+	_, err = o.db.WriteConn(ctx).ExecContext(ctx, `INSERT INTO outbox (event_type, payload, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4)`, eventType, string(data), now, now)
+	if err != nil {
+		return errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage(fmt.Sprintf("Failed to record %s event", eventType)),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// recordingSink records the messages it is given, failing while fail is set.
+type recordingSink struct {
+	mu       sync.Mutex
+	fail     error
+	messages []Message
+}
+
+func (s *recordingSink) Publish(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	return s.fail
+}
+
+func (s *recordingSink) received() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+func newTestOutbox(t *testing.T) (*database.DB, *fakeClock) {
+	t.Helper()
+	primary, err := database.NewSQLiteTestDB()
+	require.NoError(t, err)
+	t.Cleanup(func() { primary.Close() })
+
+	return database.NewDB(primary), &fakeClock{now: time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC)}
+}
+
+type outboxRow struct {
+	attempts    int
+	lastError   sql.NullString
+	delivered   bool
+	nextAttempt time.Time
+}
+
+func readRow(t *testing.T, db *database.DB, id int64) outboxRow {
+	t.Helper()
+	var row outboxRow
+	var delivered sql.NullTime
+	err := db.Primary().QueryRow(`SELECT attempts, last_error, delivered_at, next_attempt_at FROM outbox WHERE id = ?`, id).
+		Scan(&row.attempts, &row.lastError, &delivered, &row.nextAttempt)
+	require.NoError(t, err)
+	row.delivered = delivered.Valid
+	return row
+}
+
+func TestOutbox_RecordInTransaction(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	o := New(db, clock)
+	tx := database.NewTransactor(db.Primary())
+	ctx := context.Background()
+
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, o.Record(ctx, "user.registered", map[string]int{"user_id": 1}))
+		return fmt.Errorf("the change failed")
+	})
+	require.Error(t, err)
+
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		return o.Record(ctx, "user.registered", map[string]int{"user_id": 2})
+	})
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	delivered, err := NewRelay(db, map[string]Sink{"recording": sink}, logger.New("error"), clock).Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered, "the event of the rolled back transaction is not in the outbox")
+
+	messages := sink.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user.registered", messages[0].Type)
+	assert.JSONEq(t, `{"user_id": 2}`, string(messages[0].Payload))
+	assert.Equal(t, 1, messages[0].Attempts)
+}
+
+func TestRelay_Flush(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	o := New(db, clock)
+	ctx := context.Background()
+
+	require.NoError(t, o.Record(ctx, "user.registered", map[string]int{"user_id": 1}))
+	require.NoError(t, o.Record(ctx, "user.password_changed", map[string]int{"user_id": 1}))
+
+	first, second := &recordingSink{}, &recordingSink{}
+	relay := NewRelay(db, map[string]Sink{"first": first, "second": second}, logger.New("error"), clock)
+
+	delivered, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	for _, sink := range []*recordingSink{first, second} {
+		messages := sink.received()
+		require.Len(t, messages, 2)
+		assert.ElementsMatch(t, []string{"user.registered", "user.password_changed"},
+			[]string{messages[0].Type, messages[1].Type})
+	}
+	assert.True(t, readRow(t, db, 1).delivered)
+
+	delivered, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered, "delivered events are not sent again")
+}
+
+func TestRelay_Retries(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	ctx := context.Background()
+	require.NoError(t, New(db, clock).Record(ctx, "user.registered", map[string]int{"user_id": 1}))
+
+	sink := &recordingSink{fail: fmt.Errorf("webhook answered 503")}
+	relay := NewRelay(db, map[string]Sink{"webhook": sink}, logger.New("error"), clock, WithRetries(3, time.Minute))
+
+	delivered, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	row := readRow(t, db, 1)
+	assert.Equal(t, 1, row.attempts)
+	assert.Equal(t, "webhook: webhook answered 503", row.lastError.String)
+	assert.False(t, row.delivered)
+	assert.True(t, row.nextAttempt.Equal(clock.now.Add(time.Minute)), "%s", row.nextAttempt)
+
+	delivered, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered, "the event waits for its backoff")
+
+	clock.now = clock.now.Add(time.Minute)
+	_, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	row = readRow(t, db, 1)
+	assert.Equal(t, 2, row.attempts)
+	assert.True(t, row.nextAttempt.Equal(clock.now.Add(2*time.Minute)), "the backoff doubles: %s", row.nextAttempt)
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, readRow(t, db, 1).attempts)
+
+	clock.now = clock.now.Add(time.Hour)
+	delivered, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered, "the relay gives up after the maximum attempts")
+	assert.Len(t, sink.received(), 3)
+}
+
+func TestRelay_SucceedsAfterFailure(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	ctx := context.Background()
+	require.NoError(t, New(db, clock).Record(ctx, "user.registered", map[string]int{"user_id": 1}))
+
+	sink := &recordingSink{fail: fmt.Errorf("connection refused")}
+	relay := NewRelay(db, map[string]Sink{"recording": sink}, logger.New("error"), clock, WithRetries(3, time.Second))
+
+	_, err := relay.Flush(ctx)
+	require.NoError(t, err)
+
+	sink.fail = nil
+	clock.now = clock.now.Add(time.Second)
+	_, err = relay.Flush(ctx)
+	require.NoError(t, err)
+
+	row := readRow(t, db, 1)
+	assert.True(t, row.delivered)
+	assert.False(t, row.lastError.Valid, "the error is cleared once delivered")
+	messages := sink.received()
+	require.Len(t, messages, 2)
+	assert.Equal(t, messages[0].ID, messages[1].ID, "consumers can drop the redelivery by id")
+}
+
+func TestRelay_RetriesOnlyFailedSinks(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	ctx := context.Background()
+	require.NoError(t, New(db, clock).Record(ctx, "user.registered", map[string]int{"user_id": 1}))
+
+	working, failing := &recordingSink{}, &recordingSink{fail: fmt.Errorf("connection refused")}
+	relay := NewRelay(db, map[string]Sink{"failing": failing, "working": working}, logger.New("error"), clock,
+		WithRetries(3, time.Second))
+
+	_, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Len(t, working.received(), 1, "a failing sink does not hold back the others")
+	row := readRow(t, db, 1)
+	assert.False(t, row.delivered)
+	assert.Equal(t, "failing: connection refused", row.lastError.String)
+
+	failing.fail = nil
+	clock.now = clock.now.Add(time.Second)
+	_, err = relay.Flush(ctx)
+	require.NoError(t, err)
+
+	assert.True(t, readRow(t, db, 1).delivered)
+	assert.Len(t, failing.received(), 2)
+	assert.Len(t, working.received(), 1, "the retry only goes to the sinks that failed")
+}
+
+func TestRelay_Run(t *testing.T) {
+	db, clock := newTestOutbox(t)
+	o := New(db, clock)
+	sink := &recordingSink{}
+	relay := NewRelay(db, map[string]Sink{"recording": sink}, logger.New("error"), clock, WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(stopped)
+	}()
+
+	require.NoError(t, o.Record(context.Background(), "user.registered", map[string]int{"user_id": 1}))
+	assert.Eventually(t, func() bool { return len(sink.received()) == 1 }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the relay did not stop")
+	}
+}
@@ -0,0 +1,277 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+	"github.com/Pedrommb91/go-auth/pkg/database"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/Pedrommb91/go-auth/pkg/logger"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	defaultBackoff      = time.Second
+	maxBackoff          = time.Hour
+	// deliveryTimeout bounds the delivery of a message to the sinks, it is
+	// also how long a claimed message is hidden from the other relays
+	deliveryTimeout = 30 * time.Second
+)
+
+// Relay delivers the pending events of the outbox to the sinks. Events are
+// tried in the order they were recorded, but a failed event is retried after
+// the ones recorded after it, so sinks cannot rely on the order of the events.
+// Failed deliveries are retried with an exponential backoff until the maximum
+// attempts, only to the sinks that did not get the event yet. The events given
+// up on stay in the outbox with their last error.
+//
+// Several relays can share an outbox, every message is claimed by a single
+// one before its delivery.
+type Relay struct {
+	db          *database.DB
+	sinks       map[string]Sink
+	names       []string
+	log         logger.Interface
+	clock       clock.Clock
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+}
+
+type RelayOption func(*Relay)
+
+// WithPollInterval sets how often the relay looks for pending events, zero
+// keeps the default.
+func WithPollInterval(interval time.Duration) RelayOption {
+	return func(r *Relay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithBatchSize sets how many events are loaded at once, zero keeps the
+// default.
+func WithBatchSize(size int) RelayOption {
+	return func(r *Relay) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// WithRetries sets how many times an event is tried and the wait after its
+// first failure, zero values keep the defaults.
+func WithRetries(maxAttempts int, backoff time.Duration) RelayOption {
+	return func(r *Relay) {
+		if maxAttempts > 0 {
+			r.maxAttempts = maxAttempts
+		}
+		if backoff > 0 {
+			r.backoff = backoff
+		}
+	}
+}
+
+// NewRelay returns a relay delivering to the sinks by name, the deliveries
+// to each sink are recorded under its name so it must not change between
+// restarts.
+func NewRelay(db *database.DB, sinks map[string]Sink, l logger.Interface, c clock.Clock, opts ...RelayOption) *Relay {
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := &Relay{
+		db:          db,
+		sinks:       sinks,
+		names:       names,
+		log:         l,
+		clock:       c,
+		interval:    defaultPollInterval,
+		batchSize:   defaultBatchSize,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run delivers the pending events until ctx is done. The delivery in
+// progress is not interrupted, so Run returns once it is over.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more events are waiting
+		for {
+			delivered, err := r.Flush(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.log.Error(err)
+			}
+			if err != nil || delivered < r.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush tries to deliver a batch of pending events and returns how many were
+// tried. It stops early when ctx is done.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	const op errors.Op = "outbox.Flush"
+
+	messages, err := r.pending(ctx)
+	if err != nil {
+		return 0, errors.Build(
+			errors.WithOp(op),
+			errors.WithError(err),
+			errors.WithMessage("Failed to load pending events"),
+		)
+	}
+
+	for i, msg := range messages {
+		if ctx.Err() != nil {
+			return i, nil
+		}
+		if err := r.deliver(msg); err != nil {
+			return i, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(err),
+				errors.WithMessage(fmt.Sprintf("Failed to update event %d", msg.ID)),
+			)
+		}
+	}
+	return len(messages), nil
+}
+
+func (r *Relay) pending(ctx context.Context) ([]Message, error) {
+	rows, err := r.db.PrimaryConn(ctx).QueryContext(ctx, fmt.Sprintf(`SELECT id, event_type, payload, attempts, created_at
+		FROM outbox
+		WHERE delivered_at IS NULL AND attempts < $1 AND next_attempt_at <= $2
+		ORDER BY id
+		LIMIT %d`, r.batchSize), r.maxAttempts, r.clock.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]Message, 0)
+	for rows.Next() {
+		var msg Message
+		var payload string
+		if err := rows.Scan(&msg.ID, &msg.Type, &payload, &msg.Attempts, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+		msg.Payload = []byte(payload)
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// deliver publishes the message to the sinks that did not get it yet once
+// claimed, the context of the relay is not used so a shutdown does not cut a
+// delivery short.
+func (r *Relay) deliver(msg Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	conn := r.db.PrimaryConn(ctx)
+	res, err := conn.ExecContext(ctx, `UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $1
+		WHERE id = $2 AND attempts = $3 AND delivered_at IS NULL`, r.clock.Now().UTC().Add(deliveryTimeout), msg.ID, msg.Attempts)
+	if err != nil {
+		return err
+	}
+	if claimed, err := res.RowsAffected(); err != nil || claimed == 0 {
+		// another relay is delivering it
+		return err
+	}
+	msg.Attempts++
+
+	delivered, err := r.delivered(ctx, conn, msg.ID)
+	if err != nil {
+		return err
+	}
+
+	// a failing sink does not hold back the others, and the ones that got
+	// the event are not sent it again on the next attempt
+	failures := make([]string, 0)
+	for _, name := range r.names {
+		if delivered[name] {
+			continue
+		}
+		if err := r.sinks[name].Publish(ctx, msg); err != nil {
+			failures = append(failures, name+": "+err.Error())
+			continue
+		}
+		_, err = conn.ExecContext(ctx, `INSERT INTO outbox_deliveries (event_id, sink, delivered_at) VALUES ($1, $2, $3)`,
+			msg.ID, name, r.clock.Now().UTC())
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(failures) == 0 {
+		_, err = conn.ExecContext(ctx, `UPDATE outbox SET delivered_at = $1, last_error = NULL WHERE id = $2`,
+			r.clock.Now().UTC(), msg.ID)
+		return err
+	}
+
+	failure := strings.Join(failures, "; ")
+	if msg.Attempts >= r.maxAttempts {
+		r.log.Error("giving up on %s event %d after %d attempts: %s", msg.Type, msg.ID, msg.Attempts, failure)
+	} else {
+		r.log.Warn("delivery of %s event %d failed, attempt %d of %d: %s", msg.Type, msg.ID, msg.Attempts, r.maxAttempts, failure)
+	}
+	_, err = conn.ExecContext(ctx, `UPDATE outbox SET next_attempt_at = $1, last_error = $2 WHERE id = $3`,
+		r.clock.Now().UTC().Add(r.wait(msg.Attempts)), failure, msg.ID)
+	return err
+}
+
+// delivered returns the names of the sinks the event was delivered to.
+func (r *Relay) delivered(ctx context.Context, conn database.Querier, id int64) (map[string]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT sink FROM outbox_deliveries WHERE event_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delivered := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		delivered[name] = true
+	}
+	return delivered, rows.Err()
+}
+
+// wait is the backoff after the given number of failed attempts.
+func (r *Relay) wait(attempts int) time.Duration {
+	wait := r.backoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
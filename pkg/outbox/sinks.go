@@ -0,0 +1,167 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+)
+
+const (
+	WebhookSink = "webhook"
+	StdoutSink  = "stdout"
+	FileSink    = "file"
+)
+
+// Sink delivers the events of the outbox to another system. Events are
+// delivered at least once: a sink is only sent an event again when the relay
+// could not record its delivery, but it must still be idempotent and
+// consumers use the message id to drop duplicates.
+type Sink interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// NewSinks returns the sinks named in the configuration, by name.
+func NewSinks(cfg config.Outbox) (map[string]Sink, error) {
+	const op errors.Op = "outbox.NewSinks"
+
+	sinks := make(map[string]Sink, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case WebhookSink:
+			if cfg.WebhookURL == "" {
+				return nil, errors.Build(
+					errors.WithOp(op),
+					errors.WithError(fmt.Errorf("webhook sink without url")),
+					errors.WithMessage("The webhook sink needs a webhook url"),
+				)
+			}
+			sinks[name] = NewHTTPSink(cfg.WebhookURL, &http.Client{Timeout: 10 * time.Second})
+		case StdoutSink:
+			sinks[name] = NewWriterSink(os.Stdout)
+		case FileSink:
+			f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return nil, errors.Build(
+					errors.WithOp(op),
+					errors.WithError(err),
+					errors.WithMessage("Failed to open the outbox file"),
+				)
+			}
+			sinks[name] = NewWriterSink(f)
+		default:
+			return nil, errors.Build(
+				errors.WithOp(op),
+				errors.WithError(fmt.Errorf("unknown outbox sink %q", name)),
+				errors.WithMessage("Unknown outbox sink"),
+			)
+		}
+	}
+	return sinks, nil
+}
+
+// HTTPSink posts the messages as json to a webhook, any status but 2xx is a
+// failed delivery.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(msg.ID, 10))
+	req.Header.Set("X-Event-Type", msg.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// WriterSink writes the messages as json lines, to stdout or a file.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Publish(_ context.Context, msg Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// Publisher publishes data on a subject, it is satisfied by the connections
+// of the NATS client.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSSink publishes the messages as json on the subject of their type under
+// a prefix, user.registered is published on events.user.registered with the
+// events prefix.
+type NATSSink struct {
+	conn   Publisher
+	prefix string
+}
+
+func NewNATSSink(conn Publisher, prefix string) *NATSSink {
+	return &NATSSink{
+		conn:   conn,
+		prefix: prefix,
+	}
+}
+
+func (s *NATSSink) Publish(_ context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	subject := msg.Type
+	if s.prefix != "" {
+		subject = s.prefix + "." + subject
+	}
+	return s.conn.Publish(subject, data)
+}
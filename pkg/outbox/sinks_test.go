@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Pedrommb91/go-auth/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = Message{
+	ID:        7,
+	Type:      "user.registered",
+	Payload:   json.RawMessage(`{"user_id":1}`),
+	CreatedAt: time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC),
+}
+
+func TestNewSinks(t *testing.T) {
+	sinks, err := NewSinks(config.Outbox{
+		Sinks:      []string{WebhookSink, StdoutSink, FileSink},
+		WebhookURL: "http://localhost/events",
+		File:       filepath.Join(t.TempDir(), "events.jsonl"),
+	})
+	require.NoError(t, err)
+	require.Len(t, sinks, 3)
+	assert.IsType(t, &HTTPSink{}, sinks[WebhookSink])
+	assert.IsType(t, &WriterSink{}, sinks[StdoutSink])
+	assert.IsType(t, &WriterSink{}, sinks[FileSink])
+
+	_, err = NewSinks(config.Outbox{Sinks: []string{WebhookSink}})
+	assert.Error(t, err, "the webhook sink needs an url")
+
+	_, err = NewSinks(config.Outbox{Sinks: []string{"pigeon"}})
+	assert.Error(t, err)
+}
+
+func TestHTTPSink(t *testing.T) {
+	status := http.StatusAccepted
+	var received *http.Request
+	var body Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPSink(srv.URL, srv.Client())
+	require.NoError(t, sink.Publish(context.Background(), testMessage))
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "7", received.Header.Get("X-Event-ID"))
+	assert.Equal(t, "user.registered", received.Header.Get("X-Event-Type"))
+	assert.Equal(t, testMessage, body)
+
+	status = http.StatusServiceUnavailable
+	assert.EqualError(t, sink.Publish(context.Background(), testMessage), "webhook answered 503 Service Unavailable")
+}
+
+func TestWriterSink(t *testing.T) {
+	out := &bytes.Buffer{}
+	sink := NewWriterSink(out)
+
+	require.NoError(t, sink.Publish(context.Background(), testMessage))
+	require.NoError(t, sink.Publish(context.Background(), testMessage))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2, "one event per line")
+	assert.JSONEq(t, `{"id":7,"type":"user.registered","payload":{"user_id":1},"created_at":"2023-08-10T12:00:00Z"}`, string(lines[0]))
+}
+
+type recordingPublisher struct {
+	subjects []string
+	data     [][]byte
+}
+
+func (p *recordingPublisher) Publish(subject string, data []byte) error {
+	p.subjects = append(p.subjects, subject)
+	p.data = append(p.data, data)
+	return nil
+}
+
+func TestNATSSink(t *testing.T) {
+	conn := &recordingPublisher{}
+
+	require.NoError(t, NewNATSSink(conn, "events").Publish(context.Background(), testMessage))
+	require.NoError(t, NewNATSSink(conn, "").Publish(context.Background(), testMessage))
+
+	assert.Equal(t, []string{"events.user.registered", "user.registered"}, conn.subjects)
+	var got Message
+	require.NoError(t, json.Unmarshal(conn.data[0], &got))
+	assert.Equal(t, testMessage, got)
+}
@@ -0,0 +1,111 @@
+// Package pagination implements the cursors of keyset paginated listings.
+// Cursors leave the service as opaque tokens signed so that clients cannot
+// forge them.
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Cursor is a position in a listing sorted by id. The listing resumes after
+// the id or, going backwards, before it. The zero cursor is the first page.
+type Cursor struct {
+	ID       int64 `json:"id"`
+	Backward bool  `json:"backward,omitempty"`
+	// Resource is the listing the cursor was read from, the listings of
+	// other resources reject it.
+	Resource string `json:"resource,omitempty"`
+}
+
+// Page is a page of a listing with the cursors of the pages around it, they
+// are nil at the ends of the listing.
+type Page[T any] struct {
+	Items []T
+	Next  *Cursor
+	Prev  *Cursor
+}
+
+// payload is the signed content of a token, the cursor is bound to the
+// organization whose listing it was read from.
+type payload struct {
+	Cursor
+	OrganizationID int32 `json:"organization_id"`
+}
+
+// Signer encodes cursors into tokens and decodes the tokens it signed.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner returns a signer with the key, without a key a random one is
+// used and the tokens are only valid until the process exits.
+func NewSigner(key []byte) Signer {
+	if len(key) == 0 {
+		key = make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			panic(err)
+		}
+	}
+	return Signer{key: key}
+}
+
+// Sign returns the token of the cursor of a listing of the organization.
+func (s Signer) Sign(c Cursor, organizationID int32) string {
+	data, _ := json.Marshal(payload{Cursor: c, OrganizationID: organizationID})
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Parse returns the cursor of a token signed by Sign, the tokens of the
+// listings of other organizations are rejected.
+func (s Signer) Parse(token string, organizationID int32) (Cursor, error) {
+	const op errors.Op = "pagination.Parse"
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, invalidCursor(op, fmt.Errorf("malformed cursor"))
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return Cursor{}, invalidCursor(op, fmt.Errorf("invalid cursor signature"))
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, invalidCursor(op, err)
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Cursor{}, invalidCursor(op, err)
+	}
+	if p.OrganizationID != organizationID {
+		return Cursor{}, invalidCursor(op, fmt.Errorf("cursor of organization %d", p.OrganizationID))
+	}
+	return p.Cursor, nil
+}
+
+func (s Signer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
+
+func invalidCursor(op errors.Op, err error) error {
+	return errors.Build(
+		errors.WithOp(op),
+		errors.WithError(err),
+		errors.WithMessage("Invalid cursor"),
+		errors.KindBadRequest(),
+		errors.WithSeverity(zerolog.WarnLevel),
+	)
+}
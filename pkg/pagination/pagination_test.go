@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Pedrommb91/go-auth/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+
+	for _, c := range []Cursor{{}, {ID: 42, Resource: "users"}, {ID: 7, Backward: true, Resource: "users"}} {
+		got, err := s.Parse(s.Sign(c, 2), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, c, got)
+	}
+
+	token := s.Sign(Cursor{ID: 42}, 2)
+	payload, signature, _ := strings.Cut(token, ".")
+	forged := NewSigner([]byte("other")).Sign(Cursor{ID: 1}, 2)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty", token: ""},
+		{name: "Without signature", token: payload},
+		{name: "Signed with another key", token: forged},
+		{name: "Payload of another token", token: forgedPayload + "." + signature},
+		{name: "Invalid encoding", token: "!!." + signature},
+		{name: "Of another organization", token: s.Sign(Cursor{ID: 42}, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Parse(tt.token, 2)
+			assert.True(t, errors.IsKind(err, errors.BadRequest), "invalid cursors are bad requests: %v", err)
+		})
+	}
+}
+
+func TestNewSigner_RandomKey(t *testing.T) {
+	a, b := NewSigner(nil), NewSigner(nil)
+
+	_, err := b.Parse(a.Sign(Cursor{ID: 1}, 2), 2)
+	assert.Error(t, err)
+}
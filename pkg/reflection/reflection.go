@@ -1,7 +1,10 @@
 package reflection
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"reflect"
+	"time"
 )
 
 func GetType(myvar interface{}) string {
@@ -75,36 +78,74 @@ func GetTagByTypeName(s interface{}, field, tag string) string {
 	return ""
 }
 
-func GetAllValues(s interface{}) []any {
+// GetAllValues returns the value of every field. Structs other than
+// time.Time and driver.Valuer are relations and are skipped, nil pointers
+// are nil and the other kinds than the basic ones are an error.
+func GetAllValues(s interface{}) ([]any, error) {
+	return getValues(s, "")
+}
+
+// GetAllValuesWithTag is GetAllValues restricted to the fields with the tag.
+func GetAllValuesWithTag(s interface{}, tag string) ([]any, error) {
+	return getValues(s, tag)
+}
+
+func getValues(s interface{}, tag string) ([]any, error) {
 	values := make([]any, 0)
 	val := reflect.Indirect(reflect.ValueOf(s))
 
 	for i := 0; i < val.NumField(); i++ {
-		valueField := val.Field(i)
+		field := val.Type().Field(i)
+		if tag != "" && field.Tag.Get(tag) == "" {
+			continue
+		}
 
-		f := valueField.Interface()
-		val := reflect.ValueOf(f)
+		f := val.Field(i).Interface()
+		if valuer, ok := f.(driver.Valuer); ok {
+			values = append(values, valuer)
+			continue
+		}
 
+		val := reflect.ValueOf(f)
 		if val.Kind() == reflect.Ptr {
 			val = val.Elem()
 		}
 
 		switch val.Kind() {
+		case reflect.Invalid:
+			values = append(values, nil)
 		case reflect.String:
-			if val.Kind() == reflect.String {
-				values = append(values, val.String())
-			}
+			values = append(values, val.String())
+		case reflect.Bool:
+			values = append(values, val.Bool())
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			values = append(values, val.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values = append(values, val.Uint())
+		case reflect.Float32, reflect.Float64:
+			values = append(values, val.Float())
 		case reflect.Struct:
-			if val.Type().String() == "time.Time" {
+			if val.Type() == reflect.TypeOf(time.Time{}) {
 				values = append(values, val.Interface())
 			}
 		default:
-			values = append(values, "")
+			return nil, fmt.Errorf("unsupported kind %s of field %s", val.Kind(), field.Name)
 		}
+	}
+
+	return values, nil
+}
 
+// GetFieldIndexesByTag returns the index of every field of the struct type
+// with the tag, keyed by the tag value.
+func GetFieldIndexesByTag(t reflect.Type, tag string) map[string]int {
+	indexes := make(map[string]int)
+
+	for i := 0; i < t.NumField(); i++ {
+		if value := t.Field(i).Tag.Get(tag); value != "" {
+			indexes[value] = i
+		}
 	}
 
-	return values
+	return indexes
 }
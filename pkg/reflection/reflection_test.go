@@ -275,9 +275,55 @@ func TestGetAllValues(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetAllValues(tt.args.s); !reflect.DeepEqual(got, tt.want) {
+			got, err := GetAllValues(tt.args.s)
+			if err != nil {
+				t.Fatalf("GetAllValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetAllValuesAsString() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestGetFieldIndexesByTag(t *testing.T) {
+	type args struct {
+		t   reflect.Type
+		tag string
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]int
+	}{
+		{
+			name: "Get the index of the fields with name tag",
+			args: args{
+				t:   reflect.TypeOf(User{}),
+				tag: "name",
+			},
+			want: map[string]int{
+				"name":        0,
+				"id":          1,
+				"credentials": 2,
+			},
+		},
+		{
+			name: "Get the index of the fields with ref tag",
+			args: args{
+				t:   reflect.TypeOf(User{}),
+				tag: "ref",
+			},
+			want: map[string]int{
+				"cred": 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFieldIndexesByTag(tt.args.t, tt.args.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFieldIndexesByTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
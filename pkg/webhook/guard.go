@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Resolver looks up the addresses of a host, it is satisfied by
+// *net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Guard keeps the webhooks out of the network of the service: loopback,
+// link-local, private, multicast and unspecified addresses are refused
+// unless they are within one of the allowed networks.
+type Guard struct {
+	allowed  []*net.IPNet
+	resolver Resolver
+}
+
+// NewGuard returns a guard allowing the networks, written in CIDR notation,
+// besides the public addresses.
+func NewGuard(allowed []string, resolver Resolver) (*Guard, error) {
+	g := &Guard{resolver: resolver}
+	for _, cidr := range allowed {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network %q: %w", cidr, err)
+		}
+		g.allowed = append(g.allowed, network)
+	}
+	return g, nil
+}
+
+// Allowed reports whether the webhooks may reach the address.
+func (g *Guard) Allowed(ip net.IP) bool {
+	for _, network := range g.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// CheckURL refuses the urls whose host resolves to an address that is not
+// allowed. The host may resolve elsewhere by the time of the delivery, the
+// client of the guard checks the address again when it connects.
+func (g *Guard) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+
+	ips := make([]net.IP, 0, 1)
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		addrs, err := g.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("webhook host %s does not resolve: %w", host, err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if !g.Allowed(ip) {
+			return fmt.Errorf("webhook host %s resolves to the internal address %s", host, ip)
+		}
+	}
+	return nil
+}
+
+// control refuses the connections to the addresses that are not allowed, it
+// runs once the address is resolved so it holds whatever DNS answers.
+func (g *Guard) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !g.Allowed(ip) {
+		return fmt.Errorf("webhook address %s is not allowed", address)
+	}
+	return nil
+}
+
+// Client returns an http client only connecting to the allowed addresses.
+// Proxies are not used, the guard could not see the address they connect
+// to.
+func (g *Guard) Client(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.control,
+	}).DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Pedrommb91/go-auth/pkg/clock"
+)
+
+// Request is a signed delivery of an event to a webhook.
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventType  string
+	Body       []byte
+}
+
+// Sender posts the requests of the webhooks.
+type Sender struct {
+	client *http.Client
+	clock  clock.Clock
+}
+
+func NewSender(client *http.Client, c clock.Clock) *Sender {
+	return &Sender{
+		client: client,
+		clock:  c,
+	}
+}
+
+// Send posts the request and returns the status code of the answer, any
+// status but 2xx is an error. The status code is zero when no answer came.
+func (s *Sender) Send(ctx context.Context, r Request) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-auth-webhooks")
+	req.Header.Set(EventHeader, r.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(r.DeliveryID, 10))
+	req.Header.Set(SignatureHeader, Sign(r.Secret, s.clock.Now(), r.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
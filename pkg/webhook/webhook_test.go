@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// hosts resolves the names it holds, the other ones are unknown.
+type hosts map[string]string
+
+func (h hosts) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ip, ok := h[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1691668800, 0)
+	body := []byte(`{"id":1}`)
+
+	h := hmac.New(sha256.New, []byte("secret"))
+	h.Write([]byte(`1691668800.{"id":1}`))
+
+	assert.Equal(t, "t=1691668800,v1="+hex.EncodeToString(h.Sum(nil)), Sign("secret", at, body))
+}
+
+func TestVerify(t *testing.T) {
+	at := time.Unix(1691668800, 0)
+	body := []byte(`{"id":1}`)
+	header := Sign("secret", at, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "Valid signature", secret: "secret", header: header, body: body, now: at.Add(time.Minute)},
+		{name: "Other secret", secret: "other", header: header, body: body, now: at, wantErr: true},
+		{name: "Tampered body", secret: "secret", header: header, body: []byte(`{"id":2}`), now: at, wantErr: true},
+		{name: "Replayed too late", secret: "secret", header: header, body: body, now: at.Add(time.Hour), wantErr: true},
+		{name: "Malformed header", secret: "secret", header: "v1=abc", body: body, now: at, wantErr: true},
+		{name: "One of several signatures", secret: "secret", header: header + ",v1=00", body: body, now: at},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	now := time.Unix(1691668800, 0)
+	status := http.StatusNoContent
+	var verified error
+	var headers http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		headers = r.Header
+		verified = Verify("secret", r.Header.Get(SignatureHeader), body, time.Minute, now)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), fixedClock{now: now})
+	req := Request{URL: srv.URL, Secret: "secret", DeliveryID: 9, EventType: "user.registered", Body: []byte(`{"id":1}`)}
+
+	code, err := s.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.NoError(t, verified, "the receiver can verify the signature")
+	assert.Equal(t, "user.registered", headers.Get(EventHeader))
+	assert.Equal(t, "9", headers.Get(DeliveryHeader))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	status = http.StatusInternalServerError
+	code, err = s.Send(context.Background(), req)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	srv.Close()
+	code, err = s.Send(context.Background(), req)
+	assert.Error(t, err)
+	assert.Zero(t, code, "no status without an answer")
+}
+
+func TestGuard_CheckURL(t *testing.T) {
+	resolver := hosts{
+		"hooks.example.com":    "93.184.216.34",
+		"internal.example.com": "10.0.0.5",
+		"lab.example.com":      "192.168.10.7",
+	}
+	g, err := NewGuard([]string{"192.168.10.0/24"}, resolver)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "Public host", url: "https://hooks.example.com/events"},
+		{name: "Public address", url: "https://93.184.216.34/events"},
+		{name: "Allowed network", url: "http://lab.example.com/events"},
+		{name: "Loopback", url: "http://127.0.0.1:8080/events", wantErr: true},
+		{name: "Loopback v6", url: "http://[::1]/events", wantErr: true},
+		{name: "Link-local metadata address", url: "http://169.254.169.254/latest", wantErr: true},
+		{name: "Host resolving to a private address", url: "https://internal.example.com/events", wantErr: true},
+		{name: "Unspecified", url: "http://0.0.0.0/events", wantErr: true},
+		{name: "Unknown host", url: "https://missing.example.com/events", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := g.CheckURL(context.Background(), tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	_, err = NewGuard([]string{"10.0.0.0"}, resolver)
+	assert.Error(t, err, "networks are written in CIDR notation")
+}
+
+func TestGuard_Client(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	req := Request{URL: srv.URL, Secret: "secret", DeliveryID: 9, EventType: "user.registered", Body: []byte(`{"id":1}`)}
+
+	g, err := NewGuard(nil, hosts{})
+	require.NoError(t, err)
+	code, err := NewSender(g.Client(time.Second), fixedClock{}).Send(context.Background(), req)
+	assert.Error(t, err, "the loopback test server is refused when connecting")
+	assert.Zero(t, code)
+
+	g, err = NewGuard([]string{"127.0.0.0/8"}, hosts{})
+	require.NoError(t, err)
+	code, err = NewSender(g.Client(time.Second), fixedClock{}).Send(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+}